@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newAutoUpdateCmd() *cobra.Command {
+	var opts openclaw.AutoUpdateOptions
+
+	cmd := &cobra.Command{
+		Use:   "autoupdate NAME [enable|disable|pause|resume|check-now]",
+		Short: "Manage auto-update for an OpenClaw instance",
+		Long: `Show or change how the operator keeps an OpenClawInstance up to date.
+
+Without an action, the auto-update settings and status are shown.
+
+Actions:
+  enable      Turn auto-update on (with --check-interval and --constraint)
+  disable     Turn auto-update off
+  pause       Turn auto-update off until resume, e.g. for a manual upgrade
+  resume      Turn auto-update back on after pause
+  check-now   Ask the operator to check for a new version right away
+
+--constraint limits the versions auto-update moves to, e.g. "~1.4" for patch
+releases of 1.4, "^1" for anything below 2.0.0 or ">=1.2 <2".
+
+Pausing sets spec.autoUpdate.enabled to false, like disable, but keeps the
+other settings and marks the instance so that resume turns it back on.
+
+--constraint needs an operator whose CRD has spec.autoUpdate.versionConstraint;
+with an older one, enable fails and leaves auto-update as it was. check-now
+sets the openclaw.rocks/auto-update-check-requested annotation; an operator
+that does not act on it checks at the next interval as usual.`,
+		Example: `  # Show auto-update status
+  claw autoupdate my-agent
+
+  # Enable hourly checks, staying on 1.x
+  claw autoupdate my-agent enable --check-interval 1h --constraint "^1"
+
+  # Pause around a manual upgrade
+  claw autoupdate my-agent pause
+  claw upgrade my-agent v1.5.0 --wait
+  claw autoupdate my-agent resume
+
+  # Check for a new version now
+  claw autoupdate my-agent check-now
+
+  # Auto-update status of every instance
+  claw autoupdate list -A`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			action := ""
+			if len(args) > 1 {
+				action = args[1]
+			}
+			if action != "enable" && (opts.CheckInterval != "" || opts.VersionConstraint != "") {
+				return fmt.Errorf("--check-interval and --constraint can only be used with enable")
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx := context.TODO()
+
+			switch action {
+			case "":
+				inst, err := client.GetInstance(ctx, ns, name)
+				if err != nil {
+					return err
+				}
+				if openclaw.AutoUpdatePaused(inst) {
+					fmt.Printf("Auto-update is paused for %s/%s. Resume with:\n", ns, name)
+					fmt.Printf("  kubectl openclaw autoupdate %s resume\n", name)
+					return nil
+				}
+				if !inst.Spec.AutoUpdateEnabled() {
+					fmt.Printf("Auto-update is disabled for %s/%s.\n", ns, name)
+					return nil
+				}
+				printAutoUpdate(os.Stdout, &inst.Spec, &inst.Status)
+				return nil
+			case "enable":
+				err = client.EnableAutoUpdate(ctx, ns, name, opts)
+			case "disable":
+				err = client.DisableAutoUpdate(ctx, ns, name)
+			case "pause":
+				err = client.PauseAutoUpdate(ctx, ns, name)
+			case "resume":
+				err = client.ResumeAutoUpdate(ctx, ns, name)
+			case "check-now":
+				err = client.RequestAutoUpdateCheck(ctx, ns, name)
+			default:
+				return fmt.Errorf("unknown action %q: must be one of enable, disable, pause, resume, check-now", action)
+			}
+			if err != nil {
+				return err
+			}
+
+			switch action {
+			case "enable":
+				fmt.Printf("Auto-update enabled for %s/%s.\n", ns, name)
+			case "disable":
+				fmt.Printf("Auto-update disabled for %s/%s.\n", ns, name)
+			case "pause":
+				fmt.Printf("Auto-update paused for %s/%s.\n", ns, name)
+			case "resume":
+				fmt.Printf("Auto-update resumed for %s/%s.\n", ns, name)
+			case "check-now":
+				fmt.Printf("Update check requested for %s/%s. This is only a request: an operator that\n", ns, name)
+				fmt.Printf("does not act on it checks at the next interval. Follow with:\n")
+				fmt.Printf("  kubectl openclaw autoupdate %s\n", name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.CheckInterval, "check-interval", "", "how often to check for new versions, e.g. 1h (with enable)")
+	cmd.Flags().StringVar(&opts.VersionConstraint, "constraint", "", `versions to update to, e.g. "~1.4" or ">=1.2 <2" (with enable)`)
+	cmd.AddCommand(newAutoUpdateListCmd())
+	return cmd
+}
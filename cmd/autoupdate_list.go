@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newAutoUpdateListCmd() *cobra.Command {
+	var (
+		allNamespaces bool
+		selector      string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show auto-update status of all instances",
+		Long: `List the auto-update settings and status of every OpenClawInstance: whether it
+is enabled or paused, the check interval and version constraint, the current,
+latest and pending versions, and the last update error.`,
+		Example: `  # Across all namespaces
+  kubectl openclaw autoupdate list -A
+
+  # Instances with a failed update, for scripting
+  kubectl openclaw autoupdate list -A -o json | jq '.items[] | select(.lastUpdateError)'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				ns = ""
+			}
+
+			list, err := client.ListInstances(context.TODO(), ns, openclaw.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return err
+			}
+
+			listing := &autoUpdateListing{Items: []autoUpdateEntry{}}
+			for i := range list.Items {
+				inst := &list.Items[i]
+				e := autoUpdateEntry{
+					Namespace:         inst.Namespace,
+					Name:              inst.Name,
+					Enabled:           inst.Spec.AutoUpdateEnabled(),
+					Paused:            openclaw.AutoUpdatePaused(inst),
+					CheckInterval:     inst.Spec.AutoUpdate.CheckInterval,
+					VersionConstraint: inst.Spec.AutoUpdate.VersionConstraint,
+				}
+				if au := inst.Status.AutoUpdate; au != nil {
+					e.CurrentVersion = au.CurrentVersion
+					e.LatestVersion = au.LatestVersion
+					e.PendingVersion = au.PendingVersion
+					e.UpdatePhase = au.UpdatePhase
+					e.LastUpdateError = au.LastUpdateError
+				}
+				listing.Items = append(listing.Items, e)
+			}
+
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, listing)
+			}
+			if len(listing.Items) == 0 {
+				fmt.Println("No OpenClaw instances found.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			header := "NAME\tAUTO-UPDATE\tINTERVAL\tCONSTRAINT\tCURRENT\tLATEST\tPENDING\tLAST ERROR"
+			if allNamespaces {
+				header = "NAMESPACE\t" + header
+			}
+			fmt.Fprintln(w, header)
+			for _, e := range listing.Items {
+				state := "disabled"
+				switch {
+				case e.Paused:
+					state = "paused"
+				case e.Enabled:
+					state = "enabled"
+				}
+				row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", e.Name, state,
+					valueOrNone(e.CheckInterval), valueOrNone(e.VersionConstraint),
+					valueOrNone(e.CurrentVersion), valueOrNone(e.LatestVersion),
+					valueOrNone(e.PendingVersion), valueOrNone(e.LastUpdateError))
+				if allNamespaces {
+					row = e.Namespace + "\t" + row
+				}
+				fmt.Fprintln(w, row)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list instances across all namespaces")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "only instances matching this label selector")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// autoUpdateListing is the data model behind "autoupdate list".
+type autoUpdateListing struct {
+	Items []autoUpdateEntry `json:"items"`
+}
+
+type autoUpdateEntry struct {
+	Namespace         string `json:"namespace"`
+	Name              string `json:"name"`
+	Enabled           bool   `json:"enabled"`
+	Paused            bool   `json:"paused,omitempty"`
+	CheckInterval     string `json:"checkInterval,omitempty"`
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	CurrentVersion    string `json:"currentVersion,omitempty"`
+	LatestVersion     string `json:"latestVersion,omitempty"`
+	PendingVersion    string `json:"pendingVersion,omitempty"`
+	UpdatePhase       string `json:"updatePhase,omitempty"`
+	LastUpdateError   string `json:"lastUpdateError,omitempty"`
+}
+
+func (l *autoUpdateListing) resourceNames() []string {
+	names := make([]string, len(l.Items))
+	for i, e := range l.Items {
+		names[i] = instanceResourceName(e.Name)
+	}
+	return names
+}
@@ -3,15 +3,16 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"time"
+	"io"
+	"os"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func newBackupCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "backup NAME",
 		Short: "Show backup status for an OpenClaw instance",
 		Long: `Display backup configuration and status for an OpenClawInstance including
@@ -21,106 +22,139 @@ and CronJob information.`,
   kubectl openclaw backup my-agent
 
   # View backup status in specific namespace
-  kubectl openclaw backup my-agent -n production`,
+  kubectl openclaw backup my-agent -n production
+
+  # Last backup path, for scripting
+  kubectl openclaw backup my-agent -o jsonpath='{.lastBackupPath}'
+
+  # Take a backup now
+  kubectl openclaw backup now my-agent
+
+  # List all backups in the object store
+  kubectl openclaw backup list my-agent`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
-			if err != nil {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
+			client, ns, err := newClient()
+			if err != nil {
+				return err
 			}
 
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			inst, err := client.GetInstance(context.TODO(), ns, name)
 			if err != nil {
-				return fmt.Errorf("failed to get instance %q: %w", name, err)
+				return err
 			}
+			st := backupStatusFor(inst)
 
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			status, _, _ := unstructuredNestedMap(obj.Object, "status")
-
-			fmt.Printf("Backup Status: %s/%s\n\n", ns, name)
-
-			// Schedule
-			schedule := getNestedString(spec, "backup", "schedule")
-			if schedule != "" {
-				fmt.Printf("Schedule:          %s\n", schedule)
-				historyLimit, ok := getNestedInt64(spec, "backup", "historyLimit")
-				if ok {
-					fmt.Printf("History Limit:     %d\n", historyLimit)
-				}
-				failedLimit, ok := getNestedInt64(spec, "backup", "failedHistoryLimit")
-				if ok {
-					fmt.Printf("Failed Limit:      %d\n", failedLimit)
-				}
-				timeout := getNestedString(spec, "backup", "timeout")
-				if timeout != "" {
-					fmt.Printf("Timeout:           %s\n", timeout)
-				}
-			} else {
-				fmt.Println("Schedule:          (none - periodic backups disabled)")
-			}
-			fmt.Println()
-
-			// Last backup
-			lastPath := getNestedString(status, "lastBackupPath")
-			lastTime := getNestedString(status, "lastBackupTime")
-			if lastPath != "" {
-				fmt.Println("Last Backup:")
-				fmt.Printf("  Path:  %s\n", lastPath)
-				if lastTime != "" {
-					t, err := time.Parse(time.RFC3339, lastTime)
-					if err == nil {
-						fmt.Printf("  Time:  %s (%s ago)\n", lastTime, formatAge(t))
-					} else {
-						fmt.Printf("  Time:  %s\n", lastTime)
-					}
-				}
-			} else {
-				fmt.Println("Last Backup:       (none)")
-			}
-			fmt.Println()
-
-			// Active jobs
-			backupJob := getNestedString(status, "backupJobName")
-			if backupJob != "" {
-				fmt.Printf("Active Backup Job: %s\n", backupJob)
-				backingSince := getNestedString(status, "backingUpSince")
-				if backingSince != "" {
-					fmt.Printf("  Started:         %s\n", backingSince)
-				}
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, st)
 			}
+			printBackupStatus(os.Stdout, st)
+			return nil
+		},
+	}
 
-			restoreJob := getNestedString(status, "restoreJobName")
-			if restoreJob != "" {
-				fmt.Printf("Active Restore Job: %s\n", restoreJob)
-			}
+	addOutputFlag(cmd)
+	cmd.AddCommand(newBackupNowCmd())
+	cmd.AddCommand(newBackupListCmd())
+	return cmd
+}
 
-			restoredFrom := getNestedString(status, "restoredFrom")
-			if restoredFrom != "" {
-				fmt.Printf("Restored From:     %s\n", restoredFrom)
-			}
+// backupStatus is the data model behind "backup".
+type backupStatus struct {
+	Namespace          string       `json:"namespace"`
+	Name               string       `json:"name"`
+	Schedule           string       `json:"schedule,omitempty"`
+	HistoryLimit       *int32       `json:"historyLimit,omitempty"`
+	FailedHistoryLimit *int32       `json:"failedHistoryLimit,omitempty"`
+	Timeout            string       `json:"timeout,omitempty"`
+	LastBackupPath     string       `json:"lastBackupPath,omitempty"`
+	LastBackupTime     *metav1.Time `json:"lastBackupTime,omitempty"`
+	ActiveBackupJob    string       `json:"activeBackupJob,omitempty"`
+	BackingUpSince     *metav1.Time `json:"backingUpSince,omitempty"`
+	ActiveRestoreJob   string       `json:"activeRestoreJob,omitempty"`
+	RestoredFrom       string       `json:"restoredFrom,omitempty"`
+	CronJob            string       `json:"cronJob,omitempty"`
+}
 
-			// CronJob
-			managed, ok, _ := unstructuredNestedMap(status, "managedResources")
-			if ok {
-				cronJob := getNestedString(managed, "backupCronJob")
-				if cronJob != "" {
-					fmt.Printf("\nCronJob:           %s\n", cronJob)
-				}
-			}
+func (b *backupStatus) resourceNames() []string {
+	return []string{instanceResourceName(b.Name)}
+}
 
-			return nil
-		},
+func backupStatusFor(inst *v1alpha1.OpenClawInstance) *backupStatus {
+	return &backupStatus{
+		Namespace:          inst.Namespace,
+		Name:               inst.Name,
+		Schedule:           inst.Spec.Backup.Schedule,
+		HistoryLimit:       inst.Spec.Backup.HistoryLimit,
+		FailedHistoryLimit: inst.Spec.Backup.FailedHistoryLimit,
+		Timeout:            inst.Spec.Backup.Timeout,
+		LastBackupPath:     inst.Status.LastBackupPath,
+		LastBackupTime:     inst.Status.LastBackupTime,
+		ActiveBackupJob:    inst.Status.BackupJobName,
+		BackingUpSince:     inst.Status.BackingUpSince,
+		ActiveRestoreJob:   inst.Status.RestoreJobName,
+		RestoredFrom:       inst.Status.RestoredFrom,
+		CronJob:            inst.Status.Managed().BackupCronJob,
+	}
+}
+
+func printBackupStatus(w io.Writer, st *backupStatus) {
+	fmt.Fprintf(w, "Backup Status: %s/%s\n\n", st.Namespace, st.Name)
+
+	// Schedule
+	if st.Schedule != "" {
+		fmt.Fprintf(w, "Schedule:          %s\n", st.Schedule)
+		if st.HistoryLimit != nil {
+			fmt.Fprintf(w, "History Limit:     %d\n", *st.HistoryLimit)
+		}
+		if st.FailedHistoryLimit != nil {
+			fmt.Fprintf(w, "Failed Limit:      %d\n", *st.FailedHistoryLimit)
+		}
+		if st.Timeout != "" {
+			fmt.Fprintf(w, "Timeout:           %s\n", st.Timeout)
+		}
+	} else {
+		fmt.Fprintln(w, "Schedule:          (none - periodic backups disabled)")
+	}
+	fmt.Fprintln(w)
+
+	// Last backup
+	if st.LastBackupPath != "" {
+		fmt.Fprintln(w, "Last Backup:")
+		fmt.Fprintf(w, "  Path:  %s\n", st.LastBackupPath)
+		if st.LastBackupTime != nil {
+			fmt.Fprintf(w, "  Time:  %s (%s ago)\n", formatTimestamp(*st.LastBackupTime), formatAge(st.LastBackupTime.Time))
+		}
+	} else {
+		fmt.Fprintln(w, "Last Backup:       (none)")
+	}
+	fmt.Fprintln(w)
+
+	// Active jobs
+	if st.ActiveBackupJob != "" {
+		fmt.Fprintf(w, "Active Backup Job: %s\n", st.ActiveBackupJob)
+		if st.BackingUpSince != nil {
+			fmt.Fprintf(w, "  Started:         %s\n", formatTimestamp(*st.BackingUpSince))
+		}
+	}
+
+	if st.ActiveRestoreJob != "" {
+		fmt.Fprintf(w, "Active Restore Job: %s\n", st.ActiveRestoreJob)
+	}
+
+	if st.RestoredFrom != "" {
+		fmt.Fprintf(w, "Restored From:     %s\n", st.RestoredFrom)
+	}
+
+	// CronJob
+	if st.CronJob != "" {
+		fmt.Fprintf(w, "\nCronJob:           %s\n", st.CronJob)
 	}
 }
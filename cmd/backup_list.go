@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newBackupListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list NAME",
+		Aliases: []string{"ls"},
+		Short:   "List all backups of an OpenClaw instance in the object store",
+		Long: `List the backups of an OpenClawInstance straight from the object store, newest
+first, with timestamp, size and object count.
+
+The store is found the way the operator finds it: endpoint, bucket and
+credentials come from the environment of the instance's backup CronJob, then
+from the s3-backup-credentials Secret in the operator namespace. Backups are
+listed next to status.lastBackupPath. Any S3-compatible store works (AWS S3,
+MinIO, Ceph, R2); use the flags to override what was discovered.`,
+		Example: `  # List backups, newest first
+  kubectl openclaw backup list my-agent
+
+  # Backup paths only, e.g. to pick a restore point
+  kubectl openclaw backup list my-agent -o name
+
+  # Against a local MinIO
+  kubectl openclaw backup list my-agent --endpoint http://localhost:9000 \
+    --bucket backups --prefix openclaw/my-agent --credentials-secret minio-creds`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.TODO()
+			store, err := client.BackupStore(ctx, ns, name, backupStoreOptions(cmd))
+			if err != nil {
+				return err
+			}
+			backups, err := store.List(ctx)
+			if err != nil {
+				return err
+			}
+
+			list := &backupList{
+				Namespace: ns,
+				Name:      name,
+				Location:  "s3://" + store.Bucket + "/" + store.Prefix,
+				Endpoint:  store.Client.Endpoint(),
+				Backups:   backups,
+			}
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, list)
+			}
+
+			if len(backups) == 0 {
+				fmt.Printf("No backups found in %s (%s)\n", list.Location, list.Endpoint)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			header := "BACKUP\tTIME\tAGE\tSIZE\tOBJECTS"
+			if output == outputWide {
+				header += "\tPATH"
+			}
+			fmt.Fprintln(w, header)
+			for _, b := range backups {
+				row := fmt.Sprintf("%s\t%s\t%s\t%s\t%d", b.Name, formatTimestamp(metav1.NewTime(b.Time)),
+					formatAge(b.Time), formatBytes(b.Size), b.Objects)
+				if output == outputWide {
+					row += "\t" + b.Path
+				}
+				fmt.Fprintln(w, row)
+			}
+			return w.Flush()
+		},
+	}
+
+	addOutputFlag(cmd)
+	addBackupStoreFlags(cmd)
+	return cmd
+}
+
+// backupList is the data model behind "backup list".
+type backupList struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Location  string            `json:"location"`
+	Endpoint  string            `json:"endpoint"`
+	Backups   []openclaw.Backup `json:"backups"`
+}
+
+// resourceNames returns the backup paths, which is what restore takes.
+func (l *backupList) resourceNames() []string {
+	paths := make([]string, len(l.Backups))
+	for i, b := range l.Backups {
+		paths[i] = b.Path
+	}
+	return paths
+}
+
+// addBackupStoreFlags adds flags overriding the discovered backup store.
+func addBackupStoreFlags(cmd *cobra.Command) {
+	cmd.Flags().String("endpoint", "", "S3 endpoint URL (default: from the backup CronJob or operator secret)")
+	cmd.Flags().String("bucket", "", "S3 bucket (default: from the last backup path)")
+	cmd.Flags().String("region", "", "S3 region used for request signing (default: us-east-1)")
+	cmd.Flags().String("prefix", "", "key prefix holding the instance's backups (default: parent of the last backup path)")
+	cmd.Flags().String("credentials-secret", "", "[NAMESPACE/]NAME of a Secret with S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY")
+}
+
+func backupStoreOptions(cmd *cobra.Command) openclaw.BackupStoreOptions {
+	var opts openclaw.BackupStoreOptions
+	opts.Endpoint, _ = cmd.Flags().GetString("endpoint")
+	opts.Bucket, _ = cmd.Flags().GetString("bucket")
+	opts.Region, _ = cmd.Flags().GetString("region")
+	opts.Prefix, _ = cmd.Flags().GetString("prefix")
+	opts.CredentialsSecret, _ = cmd.Flags().GetString("credentials-secret")
+	return opts
+}
+
+// formatBytes renders a size with binary units, e.g. "1.5 GiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
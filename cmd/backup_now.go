@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+const (
+	defaultBackupTimeout = 30 * time.Minute
+	// backupRecordTimeout bounds how long to wait for the operator to record
+	// the path of a finished backup in the instance status.
+	backupRecordTimeout = time.Minute
+)
+
+func newBackupNowCmd() *cobra.Command {
+	var (
+		timeout time.Duration
+		logs    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "now NAME",
+		Short: "Take a backup of an OpenClaw instance right now",
+		Long: `Create a one-off Job from the instance's backup CronJob (like
+"kubectl create job --from=cronjob"), stream its progress and logs, wait for
+it to complete and print the resulting backup path.
+
+Refuses to start while a backup of the instance is already running. The
+instance must have a backup schedule, since the operator only creates the
+backup CronJob then.`,
+		Example: `  # Back up before a risky change
+  kubectl openclaw backup now my-agent
+
+  # Without the job's logs
+  kubectl openclaw backup now my-agent --logs=false`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			_, err = runBackupNow(client, ns, name, timeout, logs)
+			return err
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultBackupTimeout, "how long to wait for the backup to complete")
+	cmd.Flags().BoolVar(&logs, "logs", true, "stream the backup job's logs")
+
+	return cmd
+}
+
+// runBackupNow takes a backup, streaming the job's progress and optionally its
+// logs, and returns the backup path. The path is empty when the operator did
+// not record it in time; that is reported but not an error.
+func runBackupNow(client *openclaw.Client, ns, name string, timeout time.Duration, logs bool) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	started := time.Now()
+	job, err := client.StartBackup(ctx, ns, name)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Backup job %s created for %s/%s\n", job.Name, ns, name)
+
+	stopLogs := make(chan struct{})
+	logsDone := make(chan error, 1)
+	if logs {
+		go func() {
+			logsDone <- client.FollowJobLogs(ctx, ns, job.Name, os.Stdout, stopLogs)
+		}()
+	} else {
+		logsDone <- nil
+	}
+
+	var lastProgress string
+	_, jobErr := client.WaitForJob(ctx, ns, job.Name, func(j *batchv1.Job) {
+		if p := jobProgress(j); p != lastProgress {
+			lastProgress = p
+			fmt.Printf("Backup job %s: %s\n", j.Name, p)
+		}
+	})
+	close(stopLogs)
+	if err := <-logsDone; err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if jobErr != nil {
+		return "", jobErr
+	}
+	fmt.Printf("Backup completed in %s.\n", time.Since(started).Round(time.Second))
+
+	recordCtx, recordCancel := context.WithTimeout(ctx, backupRecordTimeout)
+	defer recordCancel()
+	inst, err := client.WaitForBackupRecorded(recordCtx, ns, name, started)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		fmt.Printf("\nCheck the backup path later with:\n  kubectl openclaw backup %s\n", name)
+		return "", nil
+	}
+	fmt.Printf("Backup path: %s\n", inst.Status.LastBackupPath)
+	return inst.Status.LastBackupPath, nil
+}
+
+// jobProgress summarizes a job's pod counts, e.g. "running".
+func jobProgress(j *batchv1.Job) string {
+	switch {
+	case j.Status.Succeeded > 0:
+		return "succeeded"
+	case j.Status.Active > 0 && j.Status.Failed > 0:
+		return fmt.Sprintf("running (attempt %d)", j.Status.Failed+1)
+	case j.Status.Active > 0:
+		return "running"
+	case j.Status.Failed > 0:
+		return fmt.Sprintf("%d attempt(s) failed", j.Status.Failed)
+	}
+	return "pending"
+}
+
+// backupBeforeImageChange returns the backup "upgrade" and "rollback" take
+// just before they change the image, or nil with skip set. Instances without
+// a backup schedule are changed without one.
+func backupBeforeImageChange(client *openclaw.Client, skip bool) openclaw.BackupFunc {
+	if skip {
+		return nil
+	}
+	return func(ctx context.Context, inst *v1alpha1.OpenClawInstance) (string, error) {
+		if inst.Status.Managed().BackupCronJob == "" {
+			fmt.Printf("No backup schedule for %s/%s; continuing without a backup (rollback --restore-backup will not be possible).\n\n",
+				inst.Namespace, inst.Name)
+			return "", nil
+		}
+
+		fmt.Printf("Backing up %s/%s first (skip with --skip-backup)...\n", inst.Namespace, inst.Name)
+		path, err := runBackupNow(client, inst.Namespace, inst.Name, defaultBackupTimeout, false)
+		if err != nil {
+			return "", fmt.Errorf("backup failed, image not changed (use --skip-backup to go ahead anyway): %w", err)
+		}
+		if path == "" {
+			return "", fmt.Errorf("the backup's path was not recorded, image not changed (use --skip-backup to go ahead anyway)")
+		}
+		fmt.Println()
+		return path, nil
+	}
+}
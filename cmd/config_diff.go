@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/jsonschema"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	ansiGreen = "\033[32m"
+	ansiRed   = "\033[31m"
+)
+
+func newConfigDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff NAME FILE",
+		Short: "Compare an instance's configuration with a file",
+		Long: `Show what "config apply" would change: a structural diff between the
+instance's inline configuration (spec.config.raw) and FILE, key by key.
+Use "-" to read FILE from stdin.`,
+		Example: `  # Review a config change before applying it
+  claw config diff my-agent openclaw.json
+
+  # As JSON, e.g. for a PR comment bot
+  claw config diff my-agent openclaw.json -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			desired, err := readConfigFile(args[1], nil, false)
+			if err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			inst, err := client.GetInstance(context.TODO(), ns, name)
+			if err != nil {
+				return err
+			}
+			current, err := openclaw.InlineConfig(inst)
+			if err != nil {
+				return err
+			}
+
+			changes := openclaw.DiffConfig(current, desired)
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, &configDiff{Namespace: ns, Name: name, Changes: changes})
+			}
+			if len(changes) == 0 {
+				fmt.Println("No differences.")
+				return nil
+			}
+			printConfigDiff(os.Stdout, changes, term.IsTerminal(int(os.Stdout.Fd())))
+			return nil
+		},
+	}
+
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func newConfigApplyCmd() *cobra.Command {
+	var (
+		file        string
+		dryRun      string
+		schemaFlags configSchemaFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply NAME -f FILE",
+		Short: "Replace an instance's configuration with a file",
+		Long: `Replace the instance's inline configuration (spec.config.raw) with the JSON
+object in FILE and show the resulting changes. Keys not in FILE are removed.
+FILE is checked against the bundled JSON Schema for openclaw.json, or the
+one given with --schema, before anything is sent. Schema violations are
+reported as warnings, as the schema may lag behind OpenClaw; with
+--validate=strict they stop the apply, and --validate=ignore skips the check.
+
+With --dry-run=server the API server validates and admits the change,
+including the operator's webhook, without persisting it. With
+--dry-run=client, or a bare --dry-run, only the diff is shown. Always write
+the value with "=": in "--dry-run server", "server" is not taken as the value.`,
+		Example: `  # Validate in CI without changing anything
+  claw config apply my-agent -f openclaw.json --dry-run=server
+
+  # Apply and wait for the operator to reconcile
+  claw config apply my-agent -f openclaw.json --wait
+
+  # From stdin
+  cat openclaw.json | claw config apply my-agent -f -`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 2 && dryRun == "client" && (args[1] == "server" || args[1] == "client" || args[1] == "none") {
+				return fmt.Errorf("write --dry-run=%s with \"=\"; a bare --dry-run means --dry-run=client", args[1])
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			if file == "" {
+				return fmt.Errorf("provide the config file with -f")
+			}
+			switch dryRun {
+			case "none", "client", "server":
+			default:
+				return fmt.Errorf("invalid --dry-run %q: must be none, client or server", dryRun)
+			}
+
+			schema, strict, err := schemaFlags.load()
+			if err != nil {
+				return err
+			}
+			desired, err := readConfigFile(file, schema, strict)
+			if err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			color := term.IsTerminal(int(os.Stdout.Fd()))
+
+			if dryRun == "client" {
+				inst, err := client.GetInstance(context.TODO(), ns, name)
+				if err != nil {
+					return err
+				}
+				current, err := openclaw.InlineConfig(inst)
+				if err != nil {
+					return err
+				}
+				printAppliedConfig(os.Stdout, ns, name, openclaw.DiffConfig(current, desired), " (dry run)", color)
+				return nil
+			}
+
+			result, err := client.ApplyConfig(context.TODO(), ns, name, desired, openclaw.ApplyConfigOptions{DryRun: dryRun == "server"})
+			if err != nil {
+				return err
+			}
+			if dryRun == "server" {
+				printAppliedConfig(os.Stdout, ns, name, result.Changes, " (server dry run)", color)
+				return nil
+			}
+			printAppliedConfig(os.Stdout, ns, name, result.Changes, "", color)
+			if len(result.Changes) == 0 {
+				return nil
+			}
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "filename", "f", "", `file with the JSON config, or "-" for stdin`)
+	cmd.Flags().StringVar(&dryRun, "dry-run", "none", `--dry-run=server to validate on the API server, --dry-run or --dry-run=client to only show the diff`)
+	cmd.Flags().Lookup("dry-run").NoOptDefVal = "client"
+	addConfigSchemaFlags(cmd, &schemaFlags)
+	addWaitFlags(cmd)
+	return cmd
+}
+
+// configDiff is the data model behind "config diff".
+type configDiff struct {
+	Namespace string                  `json:"namespace"`
+	Name      string                  `json:"name"`
+	Changes   []openclaw.ConfigChange `json:"changes"`
+}
+
+func (d *configDiff) resourceNames() []string {
+	return []string{instanceResourceName(d.Name)}
+}
+
+// readConfigFile reads a JSON object from path, or from stdin for "-", and
+// validates it against schema unless that is nil. Schema violations are
+// errors if strict and warnings otherwise.
+func readConfigFile(path string, schema *jsonschema.Schema, strict bool) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	config, errs := openclaw.ValidateConfig(schema, data)
+	if len(errs) == 0 {
+		return config, nil
+	}
+	source := path
+	if path == "-" {
+		source = "<stdin>"
+	}
+	if config != nil && !strict {
+		printConfigWarnings(source, errs)
+		return config, nil
+	}
+	for _, e := range errs {
+		line := e.Line
+		e.Line = 0
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", source, line, e.Error())
+	}
+	return nil, fmt.Errorf("%s is not a valid configuration (%d error(s))", source, len(errs))
+}
+
+func printAppliedConfig(w io.Writer, ns, name string, changes []openclaw.ConfigChange, suffix string, color bool) {
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "Configuration of %s/%s unchanged%s.\n", ns, name, suffix)
+		return
+	}
+	printConfigDiff(w, changes, color)
+	fmt.Fprintf(w, "\nConfiguration updated for %s/%s%s.\n", ns, name, suffix)
+}
+
+// printConfigDiff prints one line per change: "+" added, "-" removed and
+// "~" changed, colored when color is set.
+func printConfigDiff(w io.Writer, changes []openclaw.ConfigChange, color bool) {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + ansiReset
+	}
+	for _, ch := range changes {
+		switch ch.Type {
+		case openclaw.ConfigAdded:
+			fmt.Fprintln(w, paint(ansiGreen, fmt.Sprintf("+ %s: %s", ch.Path, configValue(ch.New))))
+		case openclaw.ConfigRemoved:
+			fmt.Fprintln(w, paint(ansiRed, fmt.Sprintf("- %s: %s", ch.Path, configValue(ch.Old))))
+		default:
+			fmt.Fprintln(w, paint(ansiYellow, fmt.Sprintf("~ %s: %s -> %s", ch.Path, configValue(ch.Old), configValue(ch.New))))
+		}
+	}
+}
+
+// configValue renders a config value as compact JSON.
+func configValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
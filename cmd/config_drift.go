@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newConfigDriftCmd() *cobra.Command {
+	var exitCode bool
+
+	cmd := &cobra.Command{
+		Use:   "drift NAME",
+		Short: "Check whether the running config matches the instance spec",
+		Long: `Compare the three layers an instance's configuration passes through and
+report which one is out of sync:
+
+  1. spec.config.raw on the OpenClawInstance (what "config edit" changes)
+  2. openclaw.json in the ConfigMap the operator renders from it, with defaults
+  3. ` + openclaw.PodConfigPath + ` in each running pod, read via exec
+
+If the operator has not rendered the spec yet, wait for it to reconcile. If a
+pod's file differs from the ConfigMap, the agent still runs the old config
+and needs a restart. Only keys set in the spec are compared with the
+ConfigMap, since the operator adds defaults.
+
+The command exits zero whatever it finds. With --exit-code it exits non-zero
+unless all layers are in sync, or if a pod's file could not be read or there
+are no pods, since the pod layer is then unknown.`,
+		Example: `  # Is the agent running the config I applied?
+  claw config drift my-agent
+
+  # In a script: fail unless the agent runs the applied config
+  claw config drift my-agent --exit-code
+
+  # Details as JSON
+  claw config drift my-agent -o json | jq .restartRequired`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			drift, err := client.ConfigDrift(context.TODO(), ns, name)
+			if err != nil {
+				return err
+			}
+			report := &configDriftReport{Namespace: ns, Name: name, ConfigDrift: drift}
+			if isStructuredOutput(output) {
+				if err := printStructured(os.Stdout, output, report); err != nil {
+					return err
+				}
+			} else {
+				printConfigDrift(report, term.IsTerminal(int(os.Stdout.Fd())))
+			}
+			if !exitCode {
+				return nil
+			}
+			if !drift.InSync() {
+				return fmt.Errorf("the configuration of %s/%s has drifted", ns, name)
+			}
+			if len(drift.Pods) == 0 {
+				return fmt.Errorf("%s/%s has no pods to read the configuration of", ns, name)
+			}
+			if unread := drift.UnreadPods(); unread > 0 {
+				return fmt.Errorf("could not read the configuration of %d pod(s) of %s/%s", unread, ns, name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "exit non-zero unless all layers are in sync")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// configDriftReport is the data model behind "config drift".
+type configDriftReport struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	*openclaw.ConfigDrift
+}
+
+func (r *configDriftReport) resourceNames() []string {
+	return []string{instanceResourceName(r.Name)}
+}
+
+func printConfigDrift(r *configDriftReport, color bool) {
+	d := r.ConfigDrift
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAYER\tSOURCE\tSTATUS")
+
+	fmt.Fprintf(w, "spec.config.raw\topenclawinstance/%s (generation %d)\tsource of truth\n", r.Name, d.Generation)
+
+	cmStatus := "in sync"
+	switch {
+	case len(d.Pending) > 0:
+		cmStatus = fmt.Sprintf("out of sync: %d value(s) not rendered", len(d.Pending))
+	case d.ObservedGeneration < d.Generation:
+		cmStatus = fmt.Sprintf("reconcile pending (observed generation %d)", d.ObservedGeneration)
+	}
+	fmt.Fprintf(w, "ConfigMap\tconfigmap/%s\t%s\n", d.ConfigMap, cmStatus)
+
+	for _, p := range d.Pods {
+		status := "in sync"
+		switch {
+		case p.Error != "":
+			status = "unknown: " + p.Error
+		case len(p.Changes) > 0:
+			status = fmt.Sprintf("out of sync: %d difference(s)", len(p.Changes))
+		}
+		fmt.Fprintf(w, "pod file\t%s:%s\t%s\n", p.Pod, openclaw.PodConfigPath, status)
+	}
+	if len(d.Pods) == 0 {
+		fmt.Fprintln(w, "pod file\t<no pods>\t-")
+	}
+	w.Flush()
+
+	if len(d.Pending) > 0 {
+		fmt.Println("\nIn the spec but not in the ConfigMap (ConfigMap -> spec):")
+		printConfigDiff(os.Stdout, d.Pending, color)
+	}
+	for _, p := range d.Pods {
+		if len(p.Changes) > 0 {
+			fmt.Printf("\nPod %s differs from the ConfigMap (ConfigMap -> pod):\n", p.Pod)
+			printConfigDiff(os.Stdout, p.Changes, color)
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case d.InSync() && len(d.Pods) == 0:
+		fmt.Println("The spec and the ConfigMap are in sync. The pod layer is unknown: there are")
+		fmt.Println("no pods.")
+	case d.InSync() && d.UnreadPods() > 0:
+		fmt.Println("The spec and the ConfigMap are in sync, and so are the pod files that could")
+		fmt.Printf("be read. The pod layer is unknown for %d pod(s).\n", d.UnreadPods())
+	case d.InSync():
+		fmt.Println("All layers are in sync.")
+	case d.ObservedGeneration < d.Generation:
+		fmt.Println("The operator has not rendered the latest spec yet. Wait for it with:")
+		fmt.Printf("  kubectl openclaw wait %s --for=generation\n", r.Name)
+	case len(d.Pending) > 0:
+		fmt.Println("The operator has reconciled the spec but rendered different values; it")
+		fmt.Println("may manage these keys itself. Check the operator logs and events.")
+	}
+	if d.RestartRequired {
+		fmt.Println("Restart required: the agent is running an older config. Restart with:")
+		fmt.Printf("  kubectl openclaw restart %s\n", r.Name)
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newConfigGetCmd() *cobra.Command {
+	var effective bool
+
+	cmd := &cobra.Command{
+		Use:   "get NAME PATH",
+		Short: "Print one value of an instance's configuration",
+		Long: `Print the value at PATH in the instance's inline configuration
+(spec.config.raw). PATH is dotted (agents.defaults.model, models.0.name) or a
+JSON Pointer (/agents/defaults/model). Strings are printed as-is, other values
+as JSON. Exits non-zero if the path is not set.
+
+With --effective, the value is read from the configuration the operator
+rendered into the managed ConfigMap, including defaults.`,
+		Example: `  # Read the default model
+  claw config get my-agent agents.defaults.model
+
+  # A whole section, as JSON
+  claw config get my-agent agents.defaults
+
+  # Including operator defaults
+  claw config get my-agent gateway --effective`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			path, err := openclaw.ParseConfigPath(args[1])
+			if err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			var config interface{}
+			if effective {
+				cm, err := client.EffectiveConfigMap(context.TODO(), ns, name)
+				if err != nil {
+					return err
+				}
+				if err := json.Unmarshal([]byte(cm.Data[openclaw.ConfigKey]), &config); err != nil {
+					return fmt.Errorf("invalid %s in ConfigMap %s: %w", openclaw.ConfigKey, cm.Name, err)
+				}
+			} else {
+				inst, err := client.GetInstance(context.TODO(), ns, name)
+				if err != nil {
+					return err
+				}
+				if config, err = openclaw.InlineConfig(inst); err != nil {
+					return err
+				}
+			}
+
+			value, err := path.Lookup(config)
+			if err != nil {
+				return err
+			}
+			if s, ok := value.(string); ok {
+				fmt.Println(s)
+				return nil
+			}
+			pretty, err := json.MarshalIndent(value, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(pretty))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&effective, "effective", false, "read the rendered configuration, including operator defaults")
+	return cmd
+}
+
+func newConfigSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set NAME PATH=VALUE...",
+		Short: "Set values in an instance's configuration",
+		Long: `Set one or more values in the instance's inline configuration
+(spec.config.raw) with a merge patch; the rest of the configuration is left
+alone. PATH is dotted or a JSON Pointer, as for "config get".
+
+VALUE is parsed as JSON when it is valid JSON (numbers, true/false, objects,
+arrays, quoted strings) and taken as a string otherwise. Quote a value to
+force a string, e.g. 'version="2"'. A value replaces what is at PATH:
+objects are not merged, so keys of the old object missing from the new one
+are removed, and arrays are replaced as a whole. Keys containing dots are
+quoted in PATH, e.g. 'models."gpt-4.1".enabled=true'.`,
+		Example: `  # Change the default model
+  claw config set my-agent agents.defaults.model=anthropic/claude-sonnet-4
+
+  # Numbers, booleans and objects
+  claw config set my-agent gateway.port=18789 gateway.auth.enabled=true
+  claw config set my-agent 'agents.defaults.tools={"web":true}'
+
+  # Apply and wait for the operator to reconcile
+  claw config set my-agent agents.defaults.thinking=high --wait`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			var values []openclaw.ConfigValue
+			for _, arg := range args[1:] {
+				rawPath, rawValue, ok := strings.Cut(arg, "=")
+				if !ok {
+					return fmt.Errorf("invalid argument %q: expected PATH=VALUE", arg)
+				}
+				path, err := openclaw.ParseConfigPath(rawPath)
+				if err != nil {
+					return err
+				}
+				value := parseConfigValue(rawValue)
+				if value == nil {
+					return fmt.Errorf("cannot set %s to null; use \"config unset\" to remove it", path)
+				}
+				values = append(values, openclaw.ConfigValue{Path: path, Value: value})
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			if err := client.SetConfigValues(context.TODO(), ns, name, values); err != nil {
+				return err
+			}
+
+			for _, v := range values {
+				fmt.Printf("Set %s = %s\n", v.Path, configValue(v.Value))
+			}
+			fmt.Printf("Configuration updated for %s/%s.\n", ns, name)
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
+		},
+	}
+
+	addWaitFlags(cmd)
+	return cmd
+}
+
+func newConfigUnsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset NAME PATH...",
+		Short: "Remove values from an instance's configuration",
+		Long: `Remove keys from the instance's inline configuration (spec.config.raw) with a
+merge patch, so the operator's defaults apply again. PATH is dotted or a JSON
+Pointer, as for "config get".`,
+		Example: `  # Go back to the default model
+  claw config unset my-agent agents.defaults.model`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			var values []openclaw.ConfigValue
+			for _, arg := range args[1:] {
+				path, err := openclaw.ParseConfigPath(arg)
+				if err != nil {
+					return err
+				}
+				values = append(values, openclaw.ConfigValue{Path: path})
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			if err := client.SetConfigValues(context.TODO(), ns, name, values); err != nil {
+				return err
+			}
+
+			for _, v := range values {
+				fmt.Printf("Removed %s\n", v.Path)
+			}
+			fmt.Printf("Configuration updated for %s/%s.\n", ns, name)
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
+		},
+	}
+
+	addWaitFlags(cmd)
+	return cmd
+}
+
+// parseConfigValue decodes s as JSON if it is valid JSON, otherwise it is a
+// plain string.
+func parseConfigValue(s string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	return v
+}
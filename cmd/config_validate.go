@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/jsonschema"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+// Values of --validate, named as for kubectl.
+const (
+	validateStrict = "strict"
+	validateWarn   = "warn"
+	validateIgnore = "ignore"
+)
+
+type configSchemaFlags struct {
+	schema   string
+	validate string
+}
+
+func addConfigSchemaFlags(cmd *cobra.Command, f *configSchemaFlags) {
+	cmd.Flags().StringVar(&f.schema, "schema", "", "JSON Schema file to validate openclaw.json against instead of the bundled one")
+	cmd.Flags().StringVar(&f.validate, "validate", validateWarn,
+		`schema violations: "warn" to report them, "strict" (or a bare --validate) to refuse the config, "ignore" to skip validation`)
+	cmd.Flags().Lookup("validate").NoOptDefVal = validateStrict
+}
+
+// load returns the schema to validate with, or nil with --validate=ignore,
+// and whether schema violations are errors rather than warnings.
+func (f configSchemaFlags) load() (*jsonschema.Schema, bool, error) {
+	mode := f.validate
+	switch mode {
+	case "true":
+		mode = validateStrict
+	case "false":
+		mode = validateIgnore
+	}
+	switch mode {
+	case validateStrict, validateWarn:
+	case validateIgnore:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("invalid --validate %q: must be strict, warn or ignore", f.validate)
+	}
+	schema, err := openclaw.ConfigSchema(f.schema)
+	if err != nil {
+		return nil, false, err
+	}
+	return schema, mode == validateStrict, nil
+}
+
+// printConfigWarnings reports schema violations that do not stop the config
+// from being used.
+func printConfigWarnings(source string, errs []openclaw.ConfigError) {
+	for _, e := range errs {
+		line := e.Line
+		e.Line = 0
+		fmt.Fprintf(os.Stderr, "warning: %s:%d: %s\n", source, line, e.Error())
+	}
+}
+
+// editConfig opens data in the user's editor until the result is a valid
+// config, reopening it with the errors annotated as comments at the top, as
+// kubectl edit does. Unless strict, schema violations are only reported and
+// the editor is reopened for invalid JSON alone. It returns nil if the edit
+// was cancelled or nothing changed.
+func editConfig(name string, data []byte, schema *jsonschema.Schema, strict bool) (map[string]interface{}, error) {
+	tmpFile, err := os.CreateTemp("", fmt.Sprintf("openclaw-%s-*.json", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	original := string(data)
+	body, header, lastInvalid := original, "", ""
+	for {
+		if err := os.WriteFile(tmpPath, []byte(header+body), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := runEditor(tmpPath); err != nil {
+			return nil, err
+		}
+		newData, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read edited file: %w", err)
+		}
+
+		edited := stripConfigComments(string(newData))
+		if strings.TrimSpace(edited) == "" {
+			fmt.Println("Edit cancelled, saved file was empty.")
+			return nil, nil
+		}
+		if edited == original {
+			fmt.Println("No changes made.")
+			return nil, nil
+		}
+
+		config, errs := openclaw.ValidateConfig(schema, []byte(edited))
+		if len(errs) == 0 {
+			return config, nil
+		}
+		if config != nil && !strict {
+			printConfigWarnings(name, errs)
+			return config, nil
+		}
+		if edited == lastInvalid {
+			keep = true
+			for _, e := range errs {
+				fmt.Fprintf(os.Stderr, "error: %s\n", e.Error())
+			}
+			return nil, fmt.Errorf("configuration is still invalid, edit cancelled; your changes are in %s", tmpPath)
+		}
+		body, lastInvalid = edited, edited
+		header = configErrorHeader(name, errs)
+	}
+}
+
+// configErrorHeader renders errors as the comment block editConfig puts
+// above the config, with line numbers counted in the reopened file.
+func configErrorHeader(name string, errs []openclaw.ConfigError) string {
+	lines := []string{
+		"# Please edit the configuration below. Lines beginning with '#' are ignored,",
+		"# and an empty file cancels the edit.",
+		"#",
+		fmt.Sprintf("# The configuration of %q is not valid:", name),
+	}
+	offset := len(lines) + len(errs) + 1
+	for _, e := range errs {
+		if e.Line > 0 {
+			e.Line += offset
+		}
+		lines = append(lines, "# * "+e.Error())
+	}
+	lines = append(lines, "#")
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// stripConfigComments removes lines starting with '#'. JSON strings cannot
+// span lines, so such a line is never part of the config itself.
+func stripConfigComments(s string) string {
+	var kept []string
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "")
+}
+
+// runEditor opens path in $EDITOR, $VISUAL, or vi.
+func runEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	editorCmd := exec.Command(editor, path)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+	return nil
+}
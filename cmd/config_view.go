@@ -1,16 +1,17 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"strings"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"golang.org/x/term"
 )
 
 func newConfigCmd() *cobra.Command {
@@ -19,17 +20,35 @@ func newConfigCmd() *cobra.Command {
 		Short: "View or edit the configuration of an OpenClaw instance",
 		Long: `View the effective openclaw.json configuration from the managed ConfigMap.
 
-Use "config edit" to interactively edit the instance's inline configuration.`,
+Use "config edit" to interactively edit the instance's inline configuration,
+"config get", "config set" and "config unset" to script single values, or
+"config diff" and "config apply" to review and apply it from a file.`,
 		Example: `  # View the effective config
   claw config my-agent
 
   # Edit the config in your editor
-  claw config edit my-agent`,
+  claw config edit my-agent
+
+  # Change a single value
+  claw config set my-agent agents.defaults.model=anthropic/claude-sonnet-4
+
+  # Check that the running agent has picked up the config
+  claw config drift my-agent
+
+  # Review and apply a config file, e.g. from CI
+  claw config diff my-agent openclaw.json
+  claw config apply my-agent -f openclaw.json --dry-run=server`,
 		Args: cobra.ExactArgs(1),
 		RunE: configViewRunE,
 	}
 
 	cmd.AddCommand(newConfigEditCmd())
+	cmd.AddCommand(newConfigDiffCmd())
+	cmd.AddCommand(newConfigApplyCmd())
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigUnsetCmd())
+	cmd.AddCommand(newConfigDriftCmd())
 
 	return cmd
 }
@@ -37,39 +56,18 @@ Use "config edit" to interactively edit the instance's inline configuration.`,
 func configViewRunE(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	clients, err := kube.NewClients(kubeconfig)
+	client, ns, err := newClient()
 	if err != nil {
 		return err
 	}
 
-	ns := namespace
-	if ns == "" {
-		ns, err = resolveNamespace()
-		if err != nil {
-			return err
-		}
-	}
-
-	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
+	cm, err := client.EffectiveConfigMap(context.TODO(), ns, name)
 	if err != nil {
-		return fmt.Errorf("failed to get instance %q: %w", name, err)
-	}
-
-	status, _, _ := unstructuredNestedMap(obj.Object, "status")
-	managed, _, _ := unstructuredNestedMap(status, "managedResources")
-	cmName := getNestedString(managed, "configMap")
-	if cmName == "" {
-		cmName = name
-	}
-
-	cm, err := clients.Kube.CoreV1().ConfigMaps(ns).Get(context.TODO(), cmName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get ConfigMap %q: %w", cmName, err)
+		return err
 	}
+	cmName := cm.Name
 
-	if config, ok := cm.Data["openclaw.json"]; ok {
+	if config, ok := cm.Data[openclaw.ConfigKey]; ok {
 		var parsed interface{}
 		if err := json.Unmarshal([]byte(config), &parsed); err == nil {
 			pretty, _ := json.MarshalIndent(parsed, "", "  ")
@@ -92,140 +90,128 @@ func configViewRunE(cmd *cobra.Command, args []string) error {
 }
 
 func newConfigEditCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		yes         bool
+		schemaFlags configSchemaFlags
+	)
+
+	cmd := &cobra.Command{
 		Use:   "edit NAME",
 		Short: "Edit instance configuration in your editor",
 		Long: `Open the instance's inline configuration (spec.config.raw) in your editor.
-Changes are applied to the OpenClawInstance CR and the operator will reconcile.
+When the editor exits, the changes are shown as a structural diff and, once
+confirmed, applied to the OpenClawInstance CR; the operator will reconcile.
+Keys deleted in the editor are removed. If the config is changed while the
+editor is open, nothing is applied and the edited config is saved to a file
+to apply with "config apply -f FILE".
+
+The edited config is checked against a JSON Schema for openclaw.json,
+bundled with the plugin or given with --schema. If it is not valid JSON, the
+editor reopens with the error, its path and line number as a comment at the
+top. Schema violations, including keys the schema does not describe, which
+are often typos, are reported as warnings, as the schema may lag behind
+OpenClaw; with --validate=strict they reopen the editor too, and
+--validate=ignore skips the check.
 
 Uses $EDITOR, $VISUAL, or falls back to vi.`,
-		Example: `  claw config edit my-agent`,
-		Args:    cobra.ExactArgs(1),
+		Example: `  claw config edit my-agent
+
+  # Apply without asking
+  claw config edit my-agent --yes
+
+  # Check against a newer schema and refuse violations
+  claw config edit my-agent --schema openclaw.schema.json --validate=strict`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
+			schema, strict, err := schemaFlags.load()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			client, ns, err := newClient()
 			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			// Read current spec.config.raw
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			configSpec, _, _ := unstructuredNestedMap(spec, "config")
-
-			var configData []byte
-			if rawConfig, ok := configSpec["raw"]; ok {
-				configData, err = json.MarshalIndent(rawConfig, "", "  ")
-				if err != nil {
-					return fmt.Errorf("failed to marshal config: %w", err)
-				}
-			} else {
-				// Try to read from managed ConfigMap as starting point
-				status, _, _ := unstructuredNestedMap(obj.Object, "status")
-				managed, _, _ := unstructuredNestedMap(status, "managedResources")
-				cmName := getNestedString(managed, "configMap")
-				if cmName != "" {
-					cm, err := clients.Kube.CoreV1().ConfigMaps(ns).Get(context.TODO(), cmName, metav1.GetOptions{})
-					if err == nil {
-						if data, ok := cm.Data["openclaw.json"]; ok {
-							var parsed interface{}
-							if json.Unmarshal([]byte(data), &parsed) == nil {
-								configData, _ = json.MarshalIndent(parsed, "", "  ")
-							}
-						}
-					}
-				}
-				if configData == nil {
-					configData = []byte("{}\n")
-				}
+				return err
 			}
 
-			// Write to temp file
-			tmpFile, err := os.CreateTemp("", fmt.Sprintf("openclaw-%s-*.json", name))
+			// Read the instance first: the edit is applied only if its config
+			// is still the same, so a config change made while the editor is
+			// open makes it fail instead of being overwritten.
+			inst, err := client.GetInstance(context.TODO(), ns, name)
 			if err != nil {
-				return fmt.Errorf("failed to create temp file: %w", err)
-			}
-			tmpPath := tmpFile.Name()
-			defer os.Remove(tmpPath)
-
-			if _, err := tmpFile.Write(configData); err != nil {
-				tmpFile.Close()
-				return fmt.Errorf("failed to write temp file: %w", err)
-			}
-			tmpFile.Close()
-
-			// Open editor
-			editor := os.Getenv("EDITOR")
-			if editor == "" {
-				editor = os.Getenv("VISUAL")
+				return err
 			}
-			if editor == "" {
-				editor = "vi"
+			configData, err := client.EditableConfig(context.TODO(), ns, name)
+			if err != nil {
+				return err
 			}
 
-			editorCmd := exec.Command(editor, tmpPath)
-			editorCmd.Stdin = os.Stdin
-			editorCmd.Stdout = os.Stdout
-			editorCmd.Stderr = os.Stderr
-			if err := editorCmd.Run(); err != nil {
-				return fmt.Errorf("editor exited with error: %w", err)
+			parsed, err := editConfig(name, configData, schema, strict)
+			if err != nil || parsed == nil {
+				return err
 			}
 
-			// Read back
-			newData, err := os.ReadFile(tmpPath)
+			current, err := openclaw.InlineConfig(inst)
 			if err != nil {
-				return fmt.Errorf("failed to read edited file: %w", err)
+				return err
 			}
-
-			// Check if anything changed
-			if string(newData) == string(configData) {
+			changes := openclaw.DiffConfig(current, parsed)
+			if len(changes) == 0 {
 				fmt.Println("No changes made.")
 				return nil
 			}
 
-			// Validate JSON
-			var parsed interface{}
-			if err := json.Unmarshal(newData, &parsed); err != nil {
-				return fmt.Errorf("invalid JSON: %w", err)
+			color := term.IsTerminal(int(os.Stdout.Fd()))
+			if !yes {
+				printConfigDiff(os.Stdout, changes, color)
+				fmt.Printf("\nApply these changes to %s/%s? [y/N]: ", ns, name)
+				reader := bufio.NewReader(os.Stdin)
+				answer, _ := reader.ReadString('\n')
+				answer = strings.TrimSpace(strings.ToLower(answer))
+				if answer != "y" && answer != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
 			}
 
-			// Patch the CR
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"config": map[string]interface{}{
-						"raw": parsed,
-					},
-				},
+			_, err = client.ApplyConfig(context.TODO(), ns, name, parsed, openclaw.ApplyConfigOptions{
+				Base: current,
+			})
+			if errors.Is(err, openclaw.ErrConfigChanged) {
+				return saveRejectedConfig(name, parsed, err)
 			}
-			patchBytes, err := json.Marshal(patch)
 			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to apply config: %w", err)
+				return err
 			}
 
 			fmt.Printf("Configuration updated for %s/%s.\n", ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply without showing the diff and asking")
+	addConfigSchemaFlags(cmd, &schemaFlags)
+	addWaitFlags(cmd)
+	return cmd
+}
+
+// saveRejectedConfig keeps an edit that could not be applied because the
+// config changed in the meantime, so that it is not lost.
+func saveRejectedConfig(name string, config map[string]interface{}, cause error) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return cause
+	}
+	f, err := os.CreateTemp("", fmt.Sprintf("openclaw-%s-*.json", name))
+	if err != nil {
+		return cause
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return cause
+	}
+	return fmt.Errorf("%w; your changes were not applied and are in %s, review them with \"kubectl openclaw config diff %s %s\"",
+		cause, f.Name(), name, f.Name())
 }
@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func newCreateCmd() *cobra.Command {
@@ -51,129 +49,43 @@ Webhook defaults are applied for any values not explicitly set.`,
   claw create my-agent --ollama --ollama-models llama3,codellama
 
   # Create with runtime dependencies
-  claw create my-agent --pnpm --python`,
+  claw create my-agent --pnpm --python
+
+  # Create and block until the instance is running
+  claw create my-agent --wait --timeout 10m`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			spec := map[string]interface{}{}
-
-			if imageRepo != "" || imageTag != "" {
-				image := map[string]interface{}{}
-				if imageRepo != "" {
-					image["repository"] = imageRepo
-				}
-				if imageTag != "" {
-					image["tag"] = imageTag
-				}
-				spec["image"] = image
-			}
-
-			if len(skills) > 0 {
-				s := make([]interface{}, len(skills))
-				for i, sk := range skills {
-					s[i] = sk
-				}
-				spec["skills"] = s
-			}
-
-			if storageSize != "" || storageClass != "" {
-				persistence := map[string]interface{}{}
-				if storageSize != "" {
-					persistence["size"] = storageSize
-				}
-				if storageClass != "" {
-					persistence["storageClass"] = storageClass
-				}
-				spec["storage"] = map[string]interface{}{
-					"persistence": persistence,
-				}
-			}
-
-			if cpuRequest != "" || memRequest != "" {
-				requests := map[string]interface{}{}
-				if cpuRequest != "" {
-					requests["cpu"] = cpuRequest
-				}
-				if memRequest != "" {
-					requests["memory"] = memRequest
-				}
-				spec["resources"] = map[string]interface{}{
-					"requests": requests,
-				}
-			}
-
-			if chromium {
-				spec["chromium"] = map[string]interface{}{"enabled": true}
-			}
-
-			if ollama {
-				ollamaSpec := map[string]interface{}{"enabled": true}
-				if len(ollamaModels) > 0 {
-					models := make([]interface{}, len(ollamaModels))
-					for i, m := range ollamaModels {
-						models[i] = m
-					}
-					ollamaSpec["models"] = models
-				}
-				spec["ollama"] = ollamaSpec
-			}
-
-			if webTerminal {
-				spec["webTerminal"] = map[string]interface{}{"enabled": true}
-			}
-
-			if pnpm || python {
-				deps := map[string]interface{}{}
-				if pnpm {
-					deps["pnpm"] = true
-				}
-				if python {
-					deps["python"] = true
-				}
-				spec["runtimeDeps"] = deps
-			}
-
-			if selfConfigure {
-				spec["selfConfigure"] = map[string]interface{}{
-					"enabled": true,
-					"allowedActions": []interface{}{"skills", "config", "workspaceFiles", "envVars"},
-				}
-			}
-
-			instance := &unstructured.Unstructured{
-				Object: map[string]interface{}{
-					"apiVersion": "openclaw.rocks/v1alpha1",
-					"kind":       "OpenClawInstance",
-					"metadata": map[string]interface{}{
-						"name":      name,
-						"namespace": ns,
-					},
-					"spec": spec,
-				},
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Create(
-				context.TODO(), instance, metav1.CreateOptions{},
-			)
+			_, err = client.CreateInstance(context.TODO(), ns, name, openclaw.CreateOptions{
+				ImageRepository: imageRepo,
+				ImageTag:        imageTag,
+				Skills:          skills,
+				StorageSize:     storageSize,
+				StorageClass:    storageClass,
+				CPURequest:      cpuRequest,
+				MemoryRequest:   memRequest,
+				Chromium:        chromium,
+				Ollama:          ollama,
+				OllamaModels:    ollamaModels,
+				WebTerminal:     webTerminal,
+				Pnpm:            pnpm,
+				Python:          python,
+				SelfConfigure:   selfConfigure,
+			})
 			if err != nil {
-				return fmt.Errorf("failed to create instance: %w", err)
+				return err
 			}
 
 			fmt.Printf("OpenClawInstance %s/%s created.\n", ns, name)
+			if waited, err := waitIfRequested(cmd, client, ns, name); waited {
+				return err
+			}
 			fmt.Printf("\nMonitor provisioning:\n")
 			fmt.Printf("  kubectl openclaw status %s\n", name)
 			fmt.Printf("  kubectl openclaw events %s\n", name)
@@ -195,6 +107,7 @@ Webhook defaults are applied for any values not explicitly set.`,
 	cmd.Flags().BoolVar(&pnpm, "pnpm", false, "install pnpm runtime dependency")
 	cmd.Flags().BoolVar(&python, "python", false, "install Python 3.12 + uv runtime dependency")
 	cmd.Flags().BoolVar(&selfConfigure, "self-configure", false, "allow the agent to modify its own configuration")
+	addWaitFlags(cmd)
 
 	return cmd
 }
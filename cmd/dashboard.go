@@ -0,0 +1,580 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ansiAltScreenOn  = "\033[?1049h"
+	ansiAltScreenOff = "\033[?1049l"
+	ansiHideCursor   = "\033[?25l"
+	ansiShowCursor   = "\033[?25h"
+	ansiHome         = "\033[H"
+	ansiClearLine    = "\033[K"
+	ansiClearBelow   = "\033[J"
+	ansiReverse      = "\033[7m"
+	ansiBold         = "\033[1m"
+
+	// dashboardLogLines is how many log lines the logs pane keeps.
+	dashboardLogLines = 2000
+	// dashboardActionTimeout bounds restart and upgrade calls from the dashboard.
+	dashboardActionTimeout = 30 * time.Second
+)
+
+func newDashboardCmd() *cobra.Command {
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Interactive terminal dashboard for OpenClaw instances",
+		Long: `Open a full-screen terminal UI showing every OpenClawInstance in the namespace
+(or cluster, with -A). The view is kept up to date from watches on instances,
+pods and events; nothing is polled.
+
+Keys:
+  up/down, j/k   select an instance (or scroll a pane)
+  enter          status pane for the selected instance
+  l              logs pane, streaming from the instance's pod
+  esc, left      back to the fleet table
+  r              restart the selected instance
+  u              upgrade the selected instance to a new tag
+  x              open a shell in the selected instance's pod
+  q, ctrl-c      quit`,
+		Example: `  # Instances in the current namespace
+  kubectl openclaw dashboard
+
+  # Every instance in the cluster
+  kubectl openclaw dashboard -A`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+				return fmt.Errorf("dashboard needs an interactive terminal; use \"list\" or \"status -w\" instead")
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				ns = ""
+			}
+			return runDashboard(client, ns)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "show instances across all namespaces")
+	return cmd
+}
+
+type dashboardView int
+
+const (
+	viewFleet dashboardView = iota
+	viewStatus
+	viewLogs
+)
+
+// dashboardPrompt is a one-line question shown in place of the footer.
+type dashboardPrompt struct {
+	label string
+	value string
+	// confirm prompts accept a single y/n key instead of a line of text.
+	confirm  bool
+	onSubmit func(value string)
+}
+
+type dashboard struct {
+	client    *openclaw.Client
+	fleet     *openclaw.FleetCache
+	namespace string
+
+	out      *bufio.Writer
+	rawState *term.State
+	input    chan []byte
+	// pending holds input bytes read but not yet consumed by an exec session.
+	pending []byte
+	redraw  chan struct{}
+
+	width, height int
+
+	view        dashboardView
+	selectedKey string
+	scroll      int
+	message     string
+	prompt      *dashboardPrompt
+
+	logs *logTail
+}
+
+func runDashboard(client *openclaw.Client, ns string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fleet, changed, err := client.WatchFleet(ctx, ns)
+	if err != nil {
+		return err
+	}
+
+	d := &dashboard{
+		client:    client,
+		fleet:     fleet,
+		namespace: ns,
+		out:       bufio.NewWriter(os.Stdout),
+		input:     make(chan []byte, 16),
+		redraw:    make(chan struct{}, 1),
+	}
+
+	if err := d.enterScreen(); err != nil {
+		return err
+	}
+	defer d.leaveScreen()
+
+	go d.readInput()
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+
+	// Ages and "last backup" go stale without any watch event.
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		d.draw()
+
+		select {
+		case <-changed:
+		case <-d.redraw:
+		case <-winch:
+		case <-ticker.C:
+		case b, ok := <-d.input:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseKeys(b) {
+				if quit := d.handleKey(key); quit {
+					d.stopLogs()
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (d *dashboard) enterScreen() error {
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to set terminal raw mode: %w", err)
+	}
+	d.rawState = state
+	d.out.WriteString(ansiAltScreenOn + ansiHideCursor)
+	return d.out.Flush()
+}
+
+func (d *dashboard) leaveScreen() {
+	d.out.WriteString(ansiShowCursor + ansiAltScreenOff)
+	d.out.Flush()
+	if d.rawState != nil {
+		term.Restore(int(os.Stdin.Fd()), d.rawState)
+		d.rawState = nil
+	}
+}
+
+// readInput forwards raw stdin to d.input. It is the only reader of stdin,
+// so exec sessions started from the dashboard read through it as well.
+func (d *dashboard) readInput() {
+	buf := make([]byte, 256)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n > 0 {
+			b := make([]byte, n)
+			copy(b, buf[:n])
+			d.input <- b
+		}
+		if err != nil {
+			close(d.input)
+			return
+		}
+	}
+}
+
+func (d *dashboard) requestRedraw() {
+	select {
+	case d.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// parseKeys splits a chunk of raw input into key names. Arrow and paging keys
+// arrive as escape sequences; a lone ESC is the escape key.
+func parseKeys(b []byte) []string {
+	var keys []string
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == 0x1b && i+2 < len(b) && b[i+1] == '[':
+			seq := b[i+2]
+			i += 2
+			switch seq {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			case 'C':
+				keys = append(keys, "right")
+			case 'D':
+				keys = append(keys, "left")
+			case '5', '6':
+				if i+1 < len(b) && b[i+1] == '~' {
+					i++
+				}
+				if seq == '5' {
+					keys = append(keys, "pgup")
+				} else {
+					keys = append(keys, "pgdown")
+				}
+			}
+		case c == 0x1b:
+			keys = append(keys, "esc")
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, "backspace")
+		case c == 0x03:
+			keys = append(keys, "ctrl-c")
+		case c >= 0x20 && c < 0x7f:
+			keys = append(keys, string(c))
+		}
+	}
+	return keys
+}
+
+// handleKey applies a key press and reports whether the dashboard should exit.
+func (d *dashboard) handleKey(key string) bool {
+	if key == "ctrl-c" {
+		return true
+	}
+	if d.prompt != nil {
+		d.handlePromptKey(key)
+		return false
+	}
+
+	switch key {
+	case "q":
+		if d.view == viewFleet {
+			return true
+		}
+		d.back()
+	case "esc", "left":
+		d.back()
+	case "up", "k":
+		d.move(-1)
+	case "down", "j":
+		d.move(1)
+	case "pgup":
+		d.move(-(d.height - 4))
+	case "pgdown":
+		d.move(d.height - 4)
+	case "enter", "right":
+		if d.selected() != nil {
+			d.view = viewStatus
+			d.scroll = 0
+		}
+	case "l":
+		d.openLogs()
+	case "r":
+		d.confirmRestart()
+	case "u":
+		d.promptUpgrade()
+	case "x":
+		d.execShell()
+	}
+	return false
+}
+
+func (d *dashboard) handlePromptKey(key string) {
+	p := d.prompt
+	switch {
+	case key == "esc":
+		d.prompt = nil
+		d.message = "Cancelled."
+	case p.confirm:
+		d.prompt = nil
+		if key == "y" || key == "Y" {
+			p.onSubmit("y")
+		} else {
+			d.message = "Cancelled."
+		}
+	case key == "enter":
+		d.prompt = nil
+		p.onSubmit(strings.TrimSpace(p.value))
+	case key == "backspace":
+		if len(p.value) > 0 {
+			p.value = p.value[:len(p.value)-1]
+		}
+	case len(key) == 1:
+		p.value += key
+	}
+}
+
+func (d *dashboard) back() {
+	if d.view == viewLogs {
+		d.stopLogs()
+	}
+	d.view = viewFleet
+	d.scroll = 0
+}
+
+func (d *dashboard) move(delta int) {
+	if d.view != viewFleet {
+		// The status pane scrolls from the top, the logs pane from the bottom.
+		if d.view == viewStatus {
+			d.scroll += delta
+		} else {
+			d.scroll -= delta
+		}
+		if d.scroll < 0 {
+			d.scroll = 0
+		}
+		return
+	}
+
+	rows := d.fleet.Instances()
+	if len(rows) == 0 {
+		return
+	}
+	i := d.selectedIndex(rows) + delta
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(rows) {
+		i = len(rows) - 1
+	}
+	d.selectedKey = instanceKey(rows[i])
+}
+
+func instanceKey(inst *v1alpha1.OpenClawInstance) string {
+	return inst.Namespace + "/" + inst.Name
+}
+
+// selectedIndex finds the selected instance in rows, falling back to the
+// first row when it is gone (e.g. deleted).
+func (d *dashboard) selectedIndex(rows []*v1alpha1.OpenClawInstance) int {
+	for i, inst := range rows {
+		if instanceKey(inst) == d.selectedKey {
+			return i
+		}
+	}
+	return 0
+}
+
+func (d *dashboard) selected() *v1alpha1.OpenClawInstance {
+	rows := d.fleet.Instances()
+	if len(rows) == 0 {
+		return nil
+	}
+	inst := rows[d.selectedIndex(rows)]
+	d.selectedKey = instanceKey(inst)
+	return inst
+}
+
+func (d *dashboard) confirmRestart() {
+	inst := d.selected()
+	if inst == nil {
+		return
+	}
+	d.prompt = &dashboardPrompt{
+		label:   fmt.Sprintf("Restart %s? [y/N] ", instanceKey(inst)),
+		confirm: true,
+		onSubmit: func(string) {
+			ctx, cancel := context.WithTimeout(context.Background(), dashboardActionTimeout)
+			defer cancel()
+			result, err := d.client.Restart(ctx, inst.Namespace, inst.Name)
+			switch {
+			case err != nil:
+				d.message = "Restart failed: " + err.Error()
+			case len(result.Failed) > 0:
+				d.message = fmt.Sprintf("Restarted %s; failed to delete %d pod(s)", instanceKey(inst), len(result.Failed))
+			case len(result.Deleted) == 0:
+				d.message = fmt.Sprintf("No pods found for %s — nothing to restart.", instanceKey(inst))
+			default:
+				d.message = fmt.Sprintf("Restarting %s: deleted %s", instanceKey(inst), strings.Join(result.Deleted, ", "))
+			}
+		},
+	}
+}
+
+func (d *dashboard) promptUpgrade() {
+	inst := d.selected()
+	if inst == nil {
+		return
+	}
+	d.prompt = &dashboardPrompt{
+		label: fmt.Sprintf("Upgrade %s (now %s) to tag: ", instanceKey(inst), inst.Spec.ImageReference()),
+		onSubmit: func(tag string) {
+			if tag == "" {
+				d.message = "Cancelled."
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), dashboardActionTimeout)
+			defer cancel()
+			result, err := d.client.Upgrade(ctx, inst.Namespace, inst.Name, openclaw.UpgradeOptions{Tag: tag})
+			if err != nil {
+				d.message = "Upgrade failed: " + err.Error()
+				return
+			}
+			from := result.Previous.Tag
+			if from == "" {
+				from = "(default)"
+			}
+			d.message = fmt.Sprintf("Upgrading %s: %s -> %s", instanceKey(inst), from, tag)
+		},
+	}
+}
+
+// execShell suspends the dashboard, runs an interactive shell in the selected
+// instance's pod through the same code path as "exec", and resumes.
+func (d *dashboard) execShell() {
+	inst := d.selected()
+	if inst == nil {
+		return
+	}
+
+	d.leaveScreen()
+	fmt.Printf("Opening a shell in %s (exit to return to the dashboard)...\r\n", instanceKey(inst))
+
+	stdin := &dashboardStdin{d: d, done: make(chan struct{})}
+	err := execInInstance(d.client, inst.Namespace, inst.Name, "", []string{"/bin/sh"}, true,
+		stdin, os.Stdout, os.Stderr, io.Discard)
+	close(stdin.done)
+
+	if enterErr := d.enterScreen(); enterErr != nil {
+		d.message = "Failed to restore the dashboard: " + enterErr.Error()
+		return
+	}
+	if err != nil {
+		d.message = "Exec failed: " + err.Error()
+	} else {
+		d.message = "Shell in " + instanceKey(inst) + " exited."
+	}
+}
+
+// dashboardStdin feeds an exec session from the dashboard's input channel,
+// and reports EOF once the session is over so no keystrokes are lost to it.
+type dashboardStdin struct {
+	d    *dashboard
+	done chan struct{}
+}
+
+func (s *dashboardStdin) Read(p []byte) (int, error) {
+	if len(s.d.pending) == 0 {
+		select {
+		case <-s.done:
+			return 0, io.EOF
+		case b, ok := <-s.d.input:
+			if !ok {
+				return 0, io.EOF
+			}
+			s.d.pending = b
+		}
+	}
+	n := copy(p, s.d.pending)
+	s.d.pending = s.d.pending[n:]
+	return n, nil
+}
+
+// logTail streams a pod's logs into a bounded buffer for the logs pane.
+type logTail struct {
+	pod    string
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	lines []string
+	done  string
+}
+
+func (d *dashboard) openLogs() {
+	inst := d.selected()
+	if inst == nil {
+		return
+	}
+	pods := d.fleet.Pods(inst.Namespace, inst.Name)
+	if len(pods) == 0 {
+		d.message = fmt.Sprintf("No pods found for %s.", instanceKey(inst))
+		return
+	}
+
+	d.stopLogs()
+	ctx, cancel := context.WithCancel(context.Background())
+	tail := &logTail{pod: pods[0].Name, cancel: cancel}
+	d.logs = tail
+	d.view = viewLogs
+	d.scroll = 0
+
+	go func() {
+		tailLines := int64(200)
+		stream, err := d.client.LogStream(ctx, inst.Namespace, tail.pod, &corev1.PodLogOptions{
+			Follow:    true,
+			TailLines: &tailLines,
+		})
+		if err != nil {
+			tail.finish(err.Error())
+			d.requestRedraw()
+			return
+		}
+		defer stream.Close()
+
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			tail.append(scanner.Text())
+			d.requestRedraw()
+		}
+		if ctx.Err() == nil {
+			tail.finish("log stream ended")
+			d.requestRedraw()
+		}
+	}()
+}
+
+func (d *dashboard) stopLogs() {
+	if d.logs != nil {
+		d.logs.cancel()
+		d.logs = nil
+	}
+}
+
+func (t *logTail) append(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, line)
+	if len(t.lines) > dashboardLogLines {
+		t.lines = t.lines[len(t.lines)-dashboardLogLines:]
+	}
+}
+
+func (t *logTail) finish(reason string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done = reason
+}
+
+func (t *logTail) snapshot() ([]string, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.lines...), t.done
+}
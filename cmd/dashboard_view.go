@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"golang.org/x/term"
+)
+
+// dashboardEventCount is how many recent events the status pane shows.
+const dashboardEventCount = 10
+
+// draw renders the current view. Every frame is drawn in full from the
+// informer caches, so there is no incremental state to get out of sync.
+func (d *dashboard) draw() {
+	d.width, d.height = 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		d.width, d.height = w, h
+	}
+
+	// Header and the two footer lines take three rows.
+	bodyHeight := d.height - 3
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+
+	var title string
+	var body []string
+	switch d.view {
+	case viewStatus:
+		title, body = d.statusPane(bodyHeight)
+	case viewLogs:
+		title, body = d.logsPane(bodyHeight)
+	default:
+		title, body = d.fleetPane(bodyHeight)
+	}
+
+	d.out.WriteString(ansiHome)
+	d.writeLine(ansiReverse+ansiBold, padRight(title, d.width))
+	for i := 0; i < bodyHeight; i++ {
+		line := ""
+		if i < len(body) {
+			line = body[i]
+		}
+		if strings.HasPrefix(line, ansiReverse) {
+			d.writeLine(ansiReverse, padRight(strings.TrimPrefix(line, ansiReverse), d.width))
+		} else {
+			d.writeLine("", line)
+		}
+	}
+
+	if d.prompt != nil {
+		d.writeLine(ansiBold, d.prompt.label+d.prompt.value+"_")
+	} else {
+		d.writeLine(ansiYellow, d.message)
+	}
+	d.out.WriteString(truncateLine(d.keyHelp(), d.width) + ansiClearLine + ansiClearBelow)
+	d.out.Flush()
+}
+
+func (d *dashboard) writeLine(style, line string) {
+	line = truncateLine(line, d.width)
+	if style != "" {
+		line = style + line + ansiReset
+	}
+	d.out.WriteString(line + ansiClearLine + "\r\n")
+}
+
+func (d *dashboard) keyHelp() string {
+	switch d.view {
+	case viewStatus:
+		return "up/down scroll  l logs  r restart  u upgrade  x shell  esc back  q quit"
+	case viewLogs:
+		return "up/down scroll  enter status  r restart  u upgrade  x shell  esc back  q quit"
+	}
+	return "up/down select  enter status  l logs  r restart  u upgrade  x shell  q quit"
+}
+
+// fleetPane renders the instance table, scrolled so the selection is visible.
+// The selected row is marked with a leading ansiReverse.
+func (d *dashboard) fleetPane(height int) (string, []string) {
+	scope := "namespace " + d.namespace
+	if d.namespace == "" {
+		scope = "all namespaces"
+	}
+	rows := d.fleet.Instances()
+	title := fmt.Sprintf(" OpenClaw dashboard: %s, %d instance(s)   %s", scope, len(rows), time.Now().Format("15:04:05"))
+
+	if len(rows) == 0 {
+		return title, []string{"", "  No OpenClaw instances found."}
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	header := "NAME\tPHASE\tREADY\tIMAGE\tRESTARTS\tLAST BACKUP\tAGE"
+	if d.namespace == "" {
+		header = "NAMESPACE\t" + header
+	}
+	fmt.Fprintln(tw, header)
+	for _, inst := range rows {
+		var restarts int32
+		for _, pod := range d.fleet.Pods(inst.Namespace, inst.Name) {
+			for _, cs := range pod.Status.ContainerStatuses {
+				restarts += cs.RestartCount
+			}
+		}
+		lastBackup := "<none>"
+		if inst.Status.LastBackupTime != nil {
+			lastBackup = formatAge(inst.Status.LastBackupTime.Time) + " ago"
+		}
+
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%d\t%s\t%s", inst.Name, inst.Status.CurrentPhase(),
+			inst.Status.ConditionStatus("Ready"), inst.Spec.ImageReference(), restarts, lastBackup,
+			formatAge(inst.CreationTimestamp.Time))
+		if d.namespace == "" {
+			row = inst.Namespace + "\t" + row
+		}
+		fmt.Fprintln(tw, row)
+	}
+	tw.Flush()
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	selected := d.selectedIndex(rows)
+	d.selectedKey = instanceKey(rows[selected])
+
+	body := []string{" " + lines[0]}
+	tableRows := lines[1:]
+	visible := height - 1
+	first := 0
+	if selected >= visible {
+		first = selected - visible + 1
+	}
+	for i := first; i < len(tableRows) && i < first+visible; i++ {
+		if i == selected {
+			body = append(body, ansiReverse+" "+tableRows[i])
+		} else {
+			body = append(body, " "+tableRows[i])
+		}
+	}
+	return title, body
+}
+
+// statusPane renders the same screen as "status", plus recent events.
+func (d *dashboard) statusPane(height int) (string, []string) {
+	inst := d.selected()
+	if inst == nil {
+		d.view = viewFleet
+		return d.fleetPane(height)
+	}
+	title := fmt.Sprintf(" Status: %s   %s", instanceKey(inst), time.Now().Format("15:04:05"))
+
+	var st *instanceStatus
+	var err error
+	for _, obj := range d.fleet.InstanceObjects() {
+		if obj.GetNamespace() == inst.Namespace && obj.GetName() == inst.Name {
+			st, err = newInstanceStatus(obj, d.fleet.Pods(inst.Namespace, inst.Name), nil)
+			break
+		}
+	}
+	if err != nil {
+		return title, []string{"", "  " + err.Error()}
+	}
+	if st == nil {
+		return title, []string{"", "  Instance is gone."}
+	}
+
+	var buf bytes.Buffer
+	printInstanceStatus(&buf, st)
+
+	events := d.fleet.Events(inst.Namespace, inst.Name)
+	if len(events) > dashboardEventCount {
+		events = events[len(events)-dashboardEventCount:]
+	}
+	if len(events) > 0 {
+		fmt.Fprintln(&buf, "\nRecent Events:")
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		for _, e := range events {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s/%s\t%s\n", formatAge(openclaw.EventTime(e)), e.Type, e.Reason,
+				e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Message)
+		}
+		tw.Flush()
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	maxScroll := len(lines) - height
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if d.scroll > maxScroll {
+		d.scroll = maxScroll
+	}
+	return title, indent(lines[d.scroll:])
+}
+
+// logsPane shows the tail of the log stream, or older lines when scrolled up.
+func (d *dashboard) logsPane(height int) (string, []string) {
+	if d.logs == nil {
+		d.view = viewFleet
+		return d.fleetPane(height)
+	}
+	lines, done := d.logs.snapshot()
+	title := fmt.Sprintf(" Logs: %s   %s", d.logs.pod, time.Now().Format("15:04:05"))
+	if d.scroll > 0 {
+		title += fmt.Sprintf("   (scrolled back %d lines)", d.scroll)
+	}
+	if done != "" {
+		lines = append(lines, "-- "+done+" --")
+	}
+
+	maxScroll := len(lines) - height
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if d.scroll > maxScroll {
+		d.scroll = maxScroll
+	}
+	end := len(lines) - d.scroll
+	start := end - height
+	if start < 0 {
+		start = 0
+	}
+
+	out := make([]string, 0, end-start)
+	for _, l := range lines[start:end] {
+		out = append(out, sanitizeLine(l))
+	}
+	return title, out
+}
+
+func indent(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = " " + l
+	}
+	return out
+}
+
+// sanitizeLine drops colour escapes and other control characters from log
+// output so it cannot move the cursor or corrupt the frame.
+func sanitizeLine(s string) string {
+	var b strings.Builder
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			// CSI sequences end with a letter.
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		case r == 0x1b:
+			inEscape = true
+		case r == '\t':
+			b.WriteString("    ")
+		case r < 0x20 || r == 0x7f:
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func truncateLine(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return s
+}
+
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -3,15 +3,12 @@ package cmd
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newDeleteCmd() *cobra.Command {
@@ -35,34 +32,25 @@ Use --skip-backup to skip the pre-deletion backup.`,
   claw delete my-agent --yes
 
   # Delete and skip the backup
-  claw delete my-agent --skip-backup --yes`,
+  claw delete my-agent --skip-backup --yes
+
+  # Delete and wait for the pre-deletion backup and cleanup to finish
+  claw delete my-agent --yes --wait`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
 			// Verify instance exists and show info
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			inst, err := client.GetInstance(context.TODO(), ns, name)
 			if err != nil {
-				return fmt.Errorf("instance %q not found in namespace %q: %w", name, ns, err)
+				return err
 			}
-
-			status, _, _ := unstructuredNestedMap(obj.Object, "status")
-			phase := getNestedString(status, "phase")
+			phase := inst.Status.CurrentPhase()
 
 			if !yes {
 				fmt.Printf("Instance:  %s/%s\n", ns, name)
@@ -83,32 +71,17 @@ Use --skip-backup to skip the pre-deletion backup.`,
 				}
 			}
 
-			// Add skip-backup annotation if requested
-			if skipBackup {
-				patch := map[string]interface{}{
-					"metadata": map[string]interface{}{
-						"annotations": map[string]interface{}{
-							"openclaw.rocks/skip-backup": "true",
-						},
-					},
-				}
-				patchBytes, err := json.Marshal(patch)
-				if err != nil {
-					return fmt.Errorf("failed to create patch: %w", err)
-				}
-				_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-					context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-				)
-				if err != nil {
-					return fmt.Errorf("failed to set skip-backup annotation: %w", err)
-				}
+			err = client.DeleteInstance(context.TODO(), ns, name, openclaw.DeleteOptions{SkipBackup: skipBackup})
+			if err != nil {
+				return err
 			}
 
-			err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Delete(
-				context.TODO(), name, metav1.DeleteOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to delete instance: %w", err)
+			if ctx, cancel, ok := waitContext(cmd); ok {
+				defer cancel()
+				fmt.Printf("Waiting for %s/%s to be removed...\n", ns, name)
+				if err := client.WaitForDeletion(ctx, ns, name); err != nil {
+					return err
+				}
 			}
 
 			fmt.Printf("OpenClawInstance %s/%s deleted.\n", ns, name)
@@ -118,6 +91,8 @@ Use --skip-backup to skip the pre-deletion backup.`,
 
 	cmd.Flags().BoolVar(&yes, "yes", false, "skip confirmation prompt")
 	cmd.Flags().BoolVar(&skipBackup, "skip-backup", false, "skip pre-deletion backup")
+	cmd.Flags().Bool("wait", false, "wait until the instance and its finalizers are gone")
+	cmd.Flags().Duration("timeout", defaultWaitTimeout, "how long to wait with --wait")
 
 	return cmd
 }
@@ -2,17 +2,14 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newDisableCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "disable NAME SIDECAR",
 		Short: "Disable a sidecar on an OpenClaw instance",
 		Long: `Disable a sidecar container on an OpenClawInstance.
@@ -27,55 +24,26 @@ Available sidecars: chromium, tailscale, ollama, web-terminal`,
 			name := args[0]
 			sidecar := args[1]
 
-			clients, err := kube.NewClients(kubeconfig)
+			kind, err := openclaw.ParseSidecar(sidecar)
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			var specKey string
-			switch sidecar {
-			case "chromium", "browser":
-				specKey = "chromium"
-			case "tailscale":
-				specKey = "tailscale"
-			case "ollama":
-				specKey = "ollama"
-			case "web-terminal", "terminal", "ttyd":
-				specKey = "webTerminal"
-			default:
-				return fmt.Errorf("unknown sidecar %q — available: chromium, tailscale, ollama, web-terminal", sidecar)
-			}
-
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					specKey: map[string]interface{}{
-						"enabled": false,
-					},
-				},
-			}
-
-			patchBytes, err := json.Marshal(patch)
+			client, ns, err := newClient()
 			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
+				return err
 			}
 
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to disable %s: %w", sidecar, err)
+			if err := client.DisableSidecar(context.TODO(), ns, name, kind); err != nil {
+				return err
 			}
 
 			fmt.Printf("Disabled %s on %s/%s.\n", sidecar, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
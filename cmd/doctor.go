@@ -3,35 +3,188 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
 	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// checkSeverity says how serious a check that did not pass is.
+type checkSeverity string
+
+const (
+	severityInfo  checkSeverity = "info"
+	severityWarn  checkSeverity = "warn"
+	severityError checkSeverity = "error"
+)
+
+// Check categories, used to group results and as JUnit test suites.
+const (
+	categoryCluster    = "cluster"
+	categoryInstance   = "instance"
+	categoryWorkload   = "workload"
+	categoryStorage    = "storage"
+	categoryConditions = "conditions"
+	categorySecrets    = "secrets"
+)
+
+func (s checkSeverity) rank() int {
+	switch s {
+	case severityInfo:
+		return 1
+	case severityWarn:
+		return 2
+	case severityError:
+		return 3
+	}
+	return 0
+}
+
+func parseSeverity(s string) (checkSeverity, error) {
+	switch sev := checkSeverity(s); sev {
+	case severityInfo, severityWarn, severityError:
+		return sev, nil
+	}
+	return "", fmt.Errorf("invalid severity %q: must be info, warn or error", s)
+}
+
 type checkResult struct {
-	Name    string
-	Passed  bool
-	Message string
+	// ID identifies the check across runs, e.g. for suppressing it in CI.
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Passed   bool   `json:"passed"`
+	// Severity is set on checks that did not pass.
+	Severity    checkSeverity `json:"severity,omitempty"`
+	Message     string        `json:"message,omitempty"`
+	Remediation []string      `json:"remediation,omitempty"`
+}
+
+// failing reports whether a result counts as a failure at the given
+// --fail-on threshold.
+func (r checkResult) failing(threshold checkSeverity) bool {
+	return !r.Passed && r.Severity.rank() >= threshold.rank()
+}
+
+// doctorReport is the data model behind "doctor -o json|yaml".
+type doctorReport struct {
+	Namespace string        `json:"namespace,omitempty"`
+	Instance  string        `json:"instance,omitempty"`
+	Results   []checkResult `json:"results"`
+	Passed    int           `json:"passed"`
+	Info      int           `json:"info"`
+	Warnings  int           `json:"warnings"`
+	Failed    int           `json:"failed"`
+}
+
+func newDoctorReport(instance string, results []checkResult) doctorReport {
+	report := doctorReport{Instance: instance, Results: results}
+	for _, r := range results {
+		switch {
+		case r.Passed:
+			report.Passed++
+		case r.Severity == severityInfo:
+			report.Info++
+		case r.Severity == severityWarn:
+			report.Warnings++
+		default:
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// failures counts the results at or above threshold.
+func (r doctorReport) failures(threshold checkSeverity) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.failing(threshold) {
+			n++
+		}
+	}
+	return n
 }
 
 func newDoctorCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		failOn        string
+		all           bool
+		allNamespaces bool
+		selector      string
+		parallel      int
+		checkTimeout  time.Duration
+	)
+
+	cmd := &cobra.Command{
 		Use:   "doctor [NAME]",
 		Short: "Run diagnostics on the OpenClaw setup",
 		Long: `Run a series of diagnostic checks to verify that the OpenClaw operator
 and instances are properly configured and healthy.
 
 Without a NAME argument, checks the operator installation.
-With a NAME argument, also checks the specific instance.`,
+With a NAME argument, also checks the specific instance: its phase, pod,
+storage and conditions, that every Secret and ConfigMap its environment
+refers to exists and holds the referenced keys, and that no credentials
+(names like *_API_KEY or *_TOKEN) are set as plain-text env values.
+With --all, also checks every instance in the namespace (with -A, in every
+namespace; with -l, those matching the selector) and prints a matrix of
+instance against check, followed by the details of anything that did not
+pass. Instances are checked --parallel at a time.
+
+Every check has a stable ID, a category and, when it does not pass, a
+severity (info, warn or error) and steps to fix it. A check that takes
+longer than --check-timeout fails. The command exits non-zero when a check
+at or above --fail-on does not pass; lower-severity findings are reported
+without failing.
+
+"-o junit" writes JUnit XML for CI dashboards, with one test suite per
+category, or per instance with --all; findings that fail the run are test
+failures.`,
 		Example: `  # Check operator health
   kubectl openclaw doctor
 
   # Check operator + specific instance
-  kubectl openclaw doctor my-agent`,
+  kubectl openclaw doctor my-agent
+
+  # Check every instance in every namespace
+  kubectl openclaw doctor --all -A
+
+  # Only the production agents
+  kubectl openclaw doctor -l env=production
+
+  # Machine-readable results for CI
+  kubectl openclaw doctor my-agent -o json
+
+  # JUnit report, failing the build on warnings too
+  kubectl openclaw doctor my-agent -o junit --fail-on=warn > doctor.xml`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			if output != outputJUnit {
+				if err := validateOutputFormat(output); err != nil {
+					return err
+				}
+			}
+			threshold, err := parseSeverity(failOn)
+			if err != nil {
+				return fmt.Errorf("invalid --fail-on: %w", err)
+			}
+			if parallel < 1 {
+				return fmt.Errorf("--parallel must be at least 1")
+			}
+			if checkTimeout <= 0 {
+				return fmt.Errorf("--check-timeout must be positive")
+			}
+			fleet := all || allNamespaces || selector != ""
+			if fleet && len(args) > 0 {
+				return fmt.Errorf("NAME cannot be combined with --all, --all-namespaces or --selector")
+			}
+
 			clients, err := kube.NewClients(kubeconfig)
 			if err != nil {
 				return err
@@ -44,69 +197,147 @@ With a NAME argument, also checks the specific instance.`,
 					return err
 				}
 			}
+			ctx := context.TODO()
 
 			var results []checkResult
-
-			fmt.Println("=== Cluster Checks ===")
 			results = append(results, checkCRDInstalled(clients))
 			results = append(results, checkOperatorRunning(clients))
 			results = append(results, checkWebhooks(clients))
 
+			if fleet {
+				if allNamespaces {
+					ns = ""
+				}
+				return runFleetDoctor(ctx, clients, ns, selector, results, fleetOptions{
+					output:    output,
+					threshold: threshold,
+					parallel:  parallel,
+					timeout:   checkTimeout,
+				})
+			}
+
+			instance := ""
 			if len(args) > 0 {
-				name := args[0]
-				fmt.Printf("\n=== Instance Checks: %s ===\n", name)
-				results = append(results, checkInstanceExists(clients, ns, name))
-				results = append(results, checkInstancePhase(clients, ns, name))
-				results = append(results, checkInstancePod(clients, ns, name))
-				results = append(results, checkInstanceStorage(clients, ns, name))
-				results = append(results, checkInstanceConditions(clients, ns, name)...)
+				instance = args[0]
+				inst, exists := checkInstanceExists(ctx, clients, ns, instance)
+				results = append(results, exists)
+				if inst != nil {
+					results = append(results, runInstanceChecks(ctx, clients,
+						[]*v1alpha1.OpenClawInstance{inst}, parallel, checkTimeout)[0]...)
+				}
+			}
+
+			report := newDoctorReport(instance, results)
+			if instance != "" {
+				report.Namespace = ns
 			}
 
-			fmt.Println()
-			passed := 0
-			failed := 0
-			for _, r := range results {
-				if r.Passed {
-					fmt.Printf("  [PASS]  %s\n", r.Name)
-					passed++
-				} else {
-					fmt.Printf("  [FAIL]  %s\n", r.Name)
-					failed++
+			switch {
+			case output == outputJUnit:
+				if err := writeDoctorJUnit(os.Stdout, report, threshold); err != nil {
+					return err
 				}
-				if r.Message != "" {
-					fmt.Printf("          %s\n", r.Message)
+			case isStructuredOutput(output):
+				if err := printStructured(os.Stdout, output, report); err != nil {
+					return err
 				}
+			default:
+				printDoctorReport(os.Stdout, report)
 			}
 
-			fmt.Println()
-			fmt.Printf("Results: %d passed, %d failed\n", passed, failed)
-
-			if failed > 0 {
-				return fmt.Errorf("%d check(s) failed", failed)
+			if n := report.failures(threshold); n > 0 {
+				return fmt.Errorf("%d check(s) failed at severity %s or above", n, threshold)
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&failOn, "fail-on", string(severityError), "lowest severity that makes the command fail: info, warn or error")
+	cmd.Flags().BoolVar(&all, "all", false, "check every instance in the namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "check instances across all namespaces (implies --all)")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "only check instances matching this label selector (implies --all)")
+	cmd.Flags().IntVar(&parallel, "parallel", 10, "maximum number of instances checked at once")
+	cmd.Flags().DurationVar(&checkTimeout, "check-timeout", 10*time.Second, "time limit for each instance check")
+	cmd.Flags().StringP("output", "o", "",
+		"output format: json, yaml, junit, name, jsonpath=TEMPLATE, go-template=TEMPLATE")
+	return cmd
+}
+
+func printDoctorReport(w io.Writer, report doctorReport) {
+	printSection := func(title string, cluster bool) {
+		fmt.Fprintf(w, "=== %s ===\n", title)
+		for _, r := range report.Results {
+			if (r.Category == categoryCluster) != cluster {
+				continue
+			}
+			printCheckResult(w, r)
+		}
+	}
+
+	printSection("Cluster Checks", true)
+	if report.Instance != "" {
+		fmt.Fprintln(w)
+		printSection("Instance Checks: "+report.Instance, false)
+	}
+
+	fmt.Fprintln(w)
+	printDoctorTotals(w, report)
+}
+
+func printCheckResult(w io.Writer, r checkResult) {
+	fmt.Fprintf(w, "  [%s]  %s\n", resultLabel(r), r.Name)
+	if r.Message != "" {
+		fmt.Fprintf(w, "          %s\n", r.Message)
+	}
+	if !r.Passed {
+		for _, step := range r.Remediation {
+			fmt.Fprintf(w, "          -> %s\n", step)
+		}
+	}
+}
+
+func resultLabel(r checkResult) string {
+	switch {
+	case r.Passed:
+		return "PASS"
+	case r.Severity == severityInfo:
+		return "INFO"
+	case r.Severity == severityWarn:
+		return "WARN"
+	}
+	return "FAIL"
+}
+
+func printDoctorTotals(w io.Writer, report doctorReport) {
+	fmt.Fprintf(w, "Results: %d passed, %d failed, %d warning(s)", report.Passed, report.Failed, report.Warnings)
+	if report.Info > 0 {
+		fmt.Fprintf(w, ", %d info", report.Info)
+	}
+	fmt.Fprintln(w)
 }
 
 func checkCRDInstalled(clients *kube.Clients) checkResult {
+	r := checkResult{
+		ID:       "crd-installed",
+		Name:     "OpenClawInstance CRD installed",
+		Category: categoryCluster,
+	}
 	_, err := clients.Dynamic.Resource(kube.OpenClawGVR).List(
 		context.TODO(), metav1.ListOptions{Limit: 1},
 	)
 	if err != nil {
-		return checkResult{
-			Name:    "OpenClawInstance CRD installed",
-			Passed:  false,
-			Message: fmt.Sprintf("CRD not found: %v\n          Install with: helm install openclaw-operator oci://ghcr.io/openclaw-rocks/charts/openclaw-operator", err),
-		}
-	}
-	return checkResult{
-		Name:   "OpenClawInstance CRD installed",
-		Passed: true,
+		return r.fail(severityError, fmt.Sprintf("CRD not found: %v", err),
+			"Install the operator: helm install openclaw-operator oci://ghcr.io/openclaw-rocks/charts/openclaw-operator")
 	}
+	return r.pass("")
 }
 
 func checkOperatorRunning(clients *kube.Clients) checkResult {
+	r := checkResult{
+		ID:       "operator-running",
+		Name:     "OpenClaw operator running",
+		Category: categoryCluster,
+	}
 	operatorNamespaces := []string{
 		"openclaw-operator-system",
 		"openclaw-system",
@@ -121,32 +352,27 @@ func checkOperatorRunning(clients *kube.Clients) checkResult {
 		}
 		for _, pod := range pods.Items {
 			if pod.Status.Phase == "Running" {
-				return checkResult{
-					Name:    "OpenClaw operator running",
-					Passed:  true,
-					Message: fmt.Sprintf("Found in %s/%s", ns, pod.Name),
-				}
+				return r.pass(fmt.Sprintf("Found in %s/%s", ns, pod.Name))
 			}
 		}
 	}
 
-	return checkResult{
-		Name:    "OpenClaw operator running",
-		Passed:  false,
-		Message: "No running operator pod found in openclaw-operator-system or openclaw-system",
-	}
+	return r.fail(severityError, "No running operator pod found in openclaw-operator-system or openclaw-system",
+		"Check the operator pods: kubectl get pods -n openclaw-operator-system -l control-plane=controller-manager",
+		"Install the operator if missing: helm install openclaw-operator oci://ghcr.io/openclaw-rocks/charts/openclaw-operator")
 }
 
 func checkWebhooks(clients *kube.Clients) checkResult {
+	r := checkResult{
+		ID:       "webhooks-configured",
+		Name:     "Webhooks configured",
+		Category: categoryCluster,
+	}
 	vwcs, err := clients.Kube.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(
 		context.TODO(), metav1.ListOptions{},
 	)
 	if err != nil {
-		return checkResult{
-			Name:    "Webhooks configured",
-			Passed:  false,
-			Message: fmt.Sprintf("Failed to list webhooks: %v", err),
-		}
+		return r.fail(severityWarn, fmt.Sprintf("Failed to list webhooks: %v", err))
 	}
 
 	for _, vwc := range vwcs.Items {
@@ -154,159 +380,224 @@ func checkWebhooks(clients *kube.Clients) checkResult {
 			for _, rule := range wh.Rules {
 				for _, group := range rule.APIGroups {
 					if group == "openclaw.rocks" {
-						return checkResult{
-							Name:    "Webhooks configured",
-							Passed:  true,
-							Message: fmt.Sprintf("Validating webhook: %s", vwc.Name),
-						}
+						return r.pass(fmt.Sprintf("Validating webhook: %s", vwc.Name))
 					}
 				}
 			}
 		}
 	}
 
-	return checkResult{
-		Name:    "Webhooks configured",
-		Passed:  false,
-		Message: "No validating webhooks found for openclaw.rocks API group",
+	// The operator works without its webhook, but invalid specs are then
+	// only reported after the fact, in status conditions.
+	return r.fail(severityWarn, "No validating webhooks found for openclaw.rocks API group",
+		"Enable the webhook in the operator's Helm values (webhook.enabled=true) and upgrade the release")
+}
+
+// instanceCheck is a check run against an instance that has already been
+// fetched. Column heads it in the fleet matrix.
+type instanceCheck struct {
+	ID     string
+	Column string
+	Run    func(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance, pods *instancePods) []checkResult
+}
+
+// instanceChecks are run, in this order, for every instance doctor checks.
+var instanceChecks = []instanceCheck{
+	{ID: "instance-phase", Column: "PHASE", Run: oneCheck(checkInstancePhase)},
+	{ID: "pod-healthy", Column: "POD", Run: podCheck(checkInstancePod)},
+	{ID: "pod-restarts", Column: "RESTARTS", Run: podCheck(checkInstanceRestarts)},
+	{ID: "storage-ready", Column: "STORAGE", Run: oneCheck(checkInstanceStorage)},
+	{ID: "condition", Column: "CONDITIONS", Run: checkInstanceConditions},
+	{ID: "env-from-exists", Column: "ENVFROM", Run: oneCheck(checkEnvFromSources)},
+	{ID: "env-refs-resolve", Column: "ENV REFS", Run: oneCheck(checkEnvValueRefs)},
+	{ID: "env-plaintext-credentials", Column: "CREDENTIALS", Run: oneCheck(checkEnvPlaintextCredentials)},
+}
+
+func oneCheck(f func(context.Context, *kube.Clients, *v1alpha1.OpenClawInstance) checkResult) func(context.Context, *kube.Clients, *v1alpha1.OpenClawInstance, *instancePods) []checkResult {
+	return func(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance, _ *instancePods) []checkResult {
+		return []checkResult{f(ctx, clients, inst)}
+	}
+}
+
+// podCheck adapts a check that looks at the pods of the instance, which are
+// listed once and shared between such checks.
+func podCheck(f func(*v1alpha1.OpenClawInstance, []corev1.Pod, error) checkResult) func(context.Context, *kube.Clients, *v1alpha1.OpenClawInstance, *instancePods) []checkResult {
+	return func(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance, pods *instancePods) []checkResult {
+		list, err := pods.get(ctx, clients, inst)
+		return []checkResult{f(inst, list, err)}
 	}
 }
 
-func checkInstanceExists(clients *kube.Clients, ns, name string) checkResult {
-	_, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
+// instancePods holds the pods of one instance, listed on first use. A list
+// that ran out of the check's time is not kept, so the next check tries again
+// with its own timeout.
+type instancePods struct {
+	listed bool
+	items  []corev1.Pod
+	err    error
+}
+
+func (p *instancePods) get(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance) ([]corev1.Pod, error) {
+	if p.listed {
+		return p.items, p.err
+	}
+	list, err := clients.Kube.CoreV1().Pods(inst.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: podLabelSelector(inst.Name),
+	})
 	if err != nil {
-		return checkResult{
-			Name:    fmt.Sprintf("Instance %q exists", name),
-			Passed:  false,
-			Message: err.Error(),
+		if ctx.Err() == nil {
+			p.listed, p.err = true, err
 		}
+		return nil, err
 	}
-	return checkResult{
-		Name:   fmt.Sprintf("Instance %q exists", name),
-		Passed: true,
+	p.listed, p.items = true, list.Items
+	return p.items, nil
+}
+
+// runInstanceCheck runs one check with its own timeout. A check that runs
+// out of time fails with a timeout message instead of the API error it got.
+func runInstanceCheck(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance, pods *instancePods, check instanceCheck, timeout time.Duration) []checkResult {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	results := check.Run(checkCtx, clients, inst, pods)
+	if checkCtx.Err() == context.DeadlineExceeded {
+		for i := range results {
+			if !results[i].Passed {
+				results[i].Message = fmt.Sprintf("Check timed out after %s", timeout)
+			}
+		}
 	}
+	return results
 }
 
-func checkInstancePhase(clients *kube.Clients, ns, name string) checkResult {
-	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
+func checkInstanceExists(ctx context.Context, clients *kube.Clients, ns, name string) (*v1alpha1.OpenClawInstance, checkResult) {
+	r := checkResult{
+		ID:       "instance-exists",
+		Name:     fmt.Sprintf("Instance %q exists", name),
+		Category: categoryInstance,
+	}
+	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return checkResult{
-			Name:    fmt.Sprintf("Instance %q phase is Running", name),
-			Passed:  false,
-			Message: err.Error(),
-		}
+		return nil, r.fail(severityError, err.Error(),
+			"List the instances in the namespace: kubectl openclaw list",
+			"Check the namespace: kubectl openclaw doctor "+name+" -n NAMESPACE")
+	}
+	inst, err := v1alpha1.FromUnstructured(obj)
+	if err != nil {
+		return nil, r.fail(severityError, err.Error())
 	}
+	return inst, r.pass("")
+}
 
-	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
-	if phase == "Running" {
-		return checkResult{
-			Name:   fmt.Sprintf("Instance %q phase is Running", name),
-			Passed: true,
-		}
+// transitionalPhases are phases an instance passes through on its way back
+// to Running; being in one is worth a warning, not a failure.
+var transitionalPhases = map[string]bool{
+	v1alpha1.PhasePending:      true,
+	v1alpha1.PhaseProvisioning: true,
+	v1alpha1.PhaseBackingUp:    true,
+	v1alpha1.PhaseRestoring:    true,
+	v1alpha1.PhaseUpdating:     true,
+}
+
+func checkInstancePhase(_ context.Context, _ *kube.Clients, inst *v1alpha1.OpenClawInstance) checkResult {
+	name := inst.Name
+	r := checkResult{
+		ID:       "instance-phase",
+		Name:     fmt.Sprintf("Instance %q phase is Running", name),
+		Category: categoryInstance,
 	}
-	return checkResult{
-		Name:    fmt.Sprintf("Instance %q phase is Running", name),
-		Passed:  false,
-		Message: fmt.Sprintf("Current phase: %s", phase),
+
+	phase := inst.Status.Phase
+	if phase == v1alpha1.PhaseRunning {
+		return r.pass("")
+	}
+	severity := severityError
+	if transitionalPhases[inst.Status.CurrentPhase()] {
+		severity = severityWarn
 	}
+	return r.fail(severity, fmt.Sprintf("Current phase: %s", phase),
+		"Show conditions and pods: kubectl openclaw status "+name,
+		"Show recent events: kubectl openclaw events "+name)
 }
 
-func checkInstancePod(clients *kube.Clients, ns, name string) checkResult {
-	pods, err := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: podLabelSelector(name),
-	})
+func checkInstancePod(inst *v1alpha1.OpenClawInstance, pods []corev1.Pod, err error) checkResult {
+	name := inst.Name
+	r := checkResult{
+		ID:       "pod-healthy",
+		Name:     fmt.Sprintf("Pod for %q is healthy", name),
+		Category: categoryWorkload,
+	}
 	if err != nil {
-		return checkResult{
-			Name:    fmt.Sprintf("Pod for %q is healthy", name),
-			Passed:  false,
-			Message: err.Error(),
-		}
+		return r.fail(severityError, err.Error())
 	}
-	if len(pods.Items) == 0 {
-		return checkResult{
-			Name:    fmt.Sprintf("Pod for %q is healthy", name),
-			Passed:  false,
-			Message: "No pods found. Run: kubectl openclaw events " + name,
-		}
+	if len(pods) == 0 {
+		return r.fail(severityError, "No pods found",
+			"Show why the pod was not created: kubectl openclaw events "+name)
 	}
 
-	pod := pods.Items[0]
+	pod := pods[0]
 	if pod.Status.Phase != "Running" {
-		return checkResult{
-			Name:    fmt.Sprintf("Pod for %q is healthy", name),
-			Passed:  false,
-			Message: fmt.Sprintf("Pod %s is in phase %s", pod.Name, pod.Status.Phase),
-		}
+		return r.fail(severityError, fmt.Sprintf("Pod %s is in phase %s", pod.Name, pod.Status.Phase),
+			"Show scheduling and image pull events: kubectl openclaw events "+name)
 	}
 
 	for _, cs := range pod.Status.ContainerStatuses {
 		if !cs.Ready {
-			return checkResult{
-				Name:    fmt.Sprintf("Pod for %q is healthy", name),
-				Passed:  false,
-				Message: fmt.Sprintf("Container %s is not ready", cs.Name),
-			}
-		}
-		if cs.RestartCount > 5 {
-			return checkResult{
-				Name:    fmt.Sprintf("Pod for %q is healthy", name),
-				Passed:  false,
-				Message: fmt.Sprintf("Container %s has %d restarts (possible crash loop)", cs.Name, cs.RestartCount),
-			}
+			return r.fail(severityError, fmt.Sprintf("Container %s is not ready", cs.Name),
+				fmt.Sprintf("Read its logs: kubectl openclaw logs %s -c %s", name, cs.Name))
 		}
 	}
 
-	return checkResult{
-		Name:   fmt.Sprintf("Pod for %q is healthy", name),
-		Passed: true,
-	}
+	return r.pass("")
 }
 
-func checkInstanceStorage(clients *kube.Clients, ns, name string) checkResult {
-	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
+// restartWarningThreshold is the container restart count above which doctor
+// suspects a crash loop.
+const restartWarningThreshold = 5
+
+func checkInstanceRestarts(inst *v1alpha1.OpenClawInstance, pods []corev1.Pod, err error) checkResult {
+	name := inst.Name
+	r := checkResult{
+		ID:       "pod-restarts",
+		Name:     fmt.Sprintf("Pod for %q is not restarting", name),
+		Category: categoryWorkload,
+	}
 	if err != nil {
-		return checkResult{
-			Name:    fmt.Sprintf("Storage for %q is ready", name),
-			Passed:  false,
-			Message: err.Error(),
+		return r.fail(severityWarn, err.Error())
+	}
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.RestartCount > restartWarningThreshold {
+				return r.fail(severityWarn,
+					fmt.Sprintf("Container %s has %d restarts (possible crash loop)", cs.Name, cs.RestartCount),
+					fmt.Sprintf("Read the logs of the last crash: kubectl openclaw logs %s -c %s --previous", name, cs.Name))
+			}
 		}
 	}
+	return r.pass("")
+}
 
-	status, _, _ := unstructuredNestedMap(obj.Object, "status")
-	managed, _, _ := unstructuredNestedMap(status, "managedResources")
-	pvcName := getNestedString(managed, "pvc")
+func checkInstanceStorage(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance) checkResult {
+	name, ns := inst.Name, inst.Namespace
+	r := checkResult{
+		ID:       "storage-ready",
+		Name:     fmt.Sprintf("Storage for %q is ready", name),
+		Category: categoryStorage,
+	}
+
+	pvcName := inst.Status.Managed().PVC
 	if pvcName == "" {
-		spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-		enabled, ok := getNestedBool(spec, "storage", "persistence", "enabled")
-		if ok && !enabled {
-			return checkResult{
-				Name:    fmt.Sprintf("Storage for %q is ready", name),
-				Passed:  true,
-				Message: "Persistence disabled",
-			}
-		}
-		return checkResult{
-			Name:    fmt.Sprintf("Storage for %q is ready", name),
-			Passed:  false,
-			Message: "No PVC found in managed resources",
+		if !inst.Spec.PersistenceEnabled() {
+			return r.pass("Persistence disabled")
 		}
+		return r.fail(severityError, "No PVC found in managed resources",
+			"Show what the operator reports: kubectl openclaw status "+name)
 	}
 
-	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims(ns).Get(
-		context.TODO(), pvcName, metav1.GetOptions{},
-	)
+	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims(ns).Get(ctx, pvcName, metav1.GetOptions{})
 	if err != nil {
-		return checkResult{
-			Name:    fmt.Sprintf("Storage for %q is ready", name),
-			Passed:  false,
-			Message: fmt.Sprintf("PVC %s not found: %v", pvcName, err),
-		}
+		return r.fail(severityError, fmt.Sprintf("PVC %s not found: %v", pvcName, err),
+			"Show what the operator reports: kubectl openclaw status "+name)
 	}
 
 	if pvc.Status.Phase == "Bound" {
@@ -314,57 +605,47 @@ func checkInstanceStorage(clients *kube.Clients, ns, name string) checkResult {
 		if qty, ok := pvc.Spec.Resources.Requests["storage"]; ok {
 			size = qty.String()
 		}
-		return checkResult{
-			Name:    fmt.Sprintf("Storage for %q is ready", name),
-			Passed:  true,
-			Message: fmt.Sprintf("PVC %s bound (%s)", pvcName, size),
-		}
+		return r.pass(fmt.Sprintf("PVC %s bound (%s)", pvcName, size))
 	}
 
-	return checkResult{
-		Name:    fmt.Sprintf("Storage for %q is ready", name),
-		Passed:  false,
-		Message: fmt.Sprintf("PVC %s is in phase %s", pvcName, pvc.Status.Phase),
-	}
+	return r.fail(severityError, fmt.Sprintf("PVC %s is in phase %s", pvcName, pvc.Status.Phase),
+		fmt.Sprintf("Check the storage class and provisioner: kubectl describe pvc %s -n %s", pvcName, ns))
 }
 
-func checkInstanceConditions(clients *kube.Clients, ns, name string) []checkResult {
-	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
-	if err != nil {
-		return nil
-	}
-
-	status, _, _ := unstructuredNestedMap(obj.Object, "status")
-	conditions, ok := status["conditions"]
-	if !ok {
-		return nil
-	}
-	condList, ok := conditions.([]interface{})
-	if !ok {
-		return nil
-	}
-
+func checkInstanceConditions(_ context.Context, _ *kube.Clients, inst *v1alpha1.OpenClawInstance, _ *instancePods) []checkResult {
+	name := inst.Name
 	var results []checkResult
-	for _, c := range condList {
-		cond, ok := c.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		condType := getNestedString(cond, "type")
-		condStatus := getNestedString(cond, "status")
-		message := getNestedString(cond, "message")
-
-		passed := condStatus == "True"
+	for _, cond := range inst.Status.Conditions {
 		r := checkResult{
-			Name:   fmt.Sprintf("Condition %s", condType),
-			Passed: passed,
+			ID:       "condition-" + cond.Type,
+			Name:     fmt.Sprintf("Condition %s", cond.Type),
+			Category: categoryConditions,
 		}
-		if !passed && message != "" {
-			r.Message = message
+		switch cond.Status {
+		case metav1.ConditionTrue:
+			results = append(results, r.pass(""))
+		case metav1.ConditionFalse:
+			results = append(results, r.fail(severityError, cond.Message,
+				"Show recent events: kubectl openclaw events "+name))
+		default:
+			// Unknown: the operator has not been able to tell yet.
+			results = append(results, r.fail(severityWarn, cond.Message,
+				"Check again once the operator has reconciled: kubectl openclaw wait "+name+" --for=generation"))
 		}
-		results = append(results, r)
 	}
 	return results
 }
+
+func (r checkResult) pass(message string) checkResult {
+	r.Passed = true
+	r.Message = message
+	return r
+}
+
+func (r checkResult) fail(severity checkSeverity, message string, remediation ...string) checkResult {
+	r.Passed = false
+	r.Severity = severity
+	r.Message = message
+	r.Remediation = remediation
+	return r
+}
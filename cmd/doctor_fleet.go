@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fleetOptions holds the flags of "doctor --all".
+type fleetOptions struct {
+	output    string
+	threshold checkSeverity
+	parallel  int
+	timeout   time.Duration
+}
+
+// fleetDoctorReport is the data model behind "doctor --all -o json|yaml".
+type fleetDoctorReport struct {
+	Cluster   []checkResult  `json:"cluster"`
+	Instances []doctorReport `json:"instances"`
+	Passed    int            `json:"passed"`
+	Info      int            `json:"info"`
+	Warnings  int            `json:"warnings"`
+	Failed    int            `json:"failed"`
+}
+
+func (r fleetDoctorReport) total() doctorReport {
+	all := append([]checkResult{}, r.Cluster...)
+	for _, inst := range r.Instances {
+		all = append(all, inst.Results...)
+	}
+	return newDoctorReport("", all)
+}
+
+func (r *fleetDoctorReport) resourceNames() []string {
+	names := make([]string, len(r.Instances))
+	for i, inst := range r.Instances {
+		names[i] = instanceResourceName(inst.Instance)
+	}
+	return names
+}
+
+// runFleetDoctor checks every instance in ns (all namespaces if empty) that
+// matches selector, fetching each one once, and reports the results together
+// with the cluster checks already run.
+func runFleetDoctor(ctx context.Context, clients *kube.Clients, ns, selector string, cluster []checkResult, opts fleetOptions) error {
+	list, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list instances: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		a, b := list.Items[i], list.Items[j]
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+
+	report := fleetDoctorReport{Cluster: cluster, Instances: []doctorReport{}}
+	var (
+		insts []*v1alpha1.OpenClawInstance
+		slots []int
+	)
+	for i := range list.Items {
+		obj := &list.Items[i]
+		report.Instances = append(report.Instances, doctorReport{
+			Namespace: obj.GetNamespace(),
+			Instance:  obj.GetName(),
+		})
+		inst, err := v1alpha1.FromUnstructured(obj)
+		if err != nil {
+			r := checkResult{
+				ID:       "instance-exists",
+				Name:     fmt.Sprintf("Instance %q exists", obj.GetName()),
+				Category: categoryInstance,
+			}
+			report.Instances[i] = newDoctorReport(obj.GetName(), []checkResult{r.fail(severityError, err.Error())})
+			report.Instances[i].Namespace = obj.GetNamespace()
+			continue
+		}
+		insts = append(insts, inst)
+		slots = append(slots, i)
+	}
+
+	for i, results := range runInstanceChecks(ctx, clients, insts, opts.parallel, opts.timeout) {
+		slot := slots[i]
+		report.Instances[slot] = newDoctorReport(report.Instances[slot].Instance, results)
+		report.Instances[slot].Namespace = insts[i].Namespace
+	}
+
+	total := report.total()
+	report.Passed, report.Info, report.Warnings, report.Failed = total.Passed, total.Info, total.Warnings, total.Failed
+
+	switch {
+	case opts.output == outputJUnit:
+		if err := writeFleetDoctorJUnit(os.Stdout, report, opts.threshold); err != nil {
+			return err
+		}
+	case isStructuredOutput(opts.output):
+		if err := printStructured(os.Stdout, opts.output, &report); err != nil {
+			return err
+		}
+	default:
+		printFleetDoctorReport(os.Stdout, report, opts.threshold)
+	}
+
+	if n := total.failures(opts.threshold); n > 0 {
+		return fmt.Errorf("%d check(s) failed at severity %s or above", n, opts.threshold)
+	}
+	return nil
+}
+
+// runInstanceChecks runs instanceChecks against every instance, at most
+// parallel instances at a time, each check with its own timeout. The pods of
+// an instance are listed once, by the first check that needs them. The results
+// come back per instance, in the order of instanceChecks.
+func runInstanceChecks(ctx context.Context, clients *kube.Clients, insts []*v1alpha1.OpenClawInstance, parallel int, timeout time.Duration) [][]checkResult {
+	slots := make([][][]checkResult, len(insts))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, inst := range insts {
+		slots[i] = make([][]checkResult, len(instanceChecks))
+		wg.Add(1)
+		go func(i int, inst *v1alpha1.OpenClawInstance) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			pods := &instancePods{}
+			for j, check := range instanceChecks {
+				slots[i][j] = runInstanceCheck(ctx, clients, inst, pods, check, timeout)
+			}
+		}(i, inst)
+	}
+	wg.Wait()
+
+	results := make([][]checkResult, len(insts))
+	for i := range slots {
+		for _, rs := range slots[i] {
+			results[i] = append(results[i], rs...)
+		}
+	}
+	return results
+}
+
+func printFleetDoctorReport(w io.Writer, report fleetDoctorReport, threshold checkSeverity) {
+	fmt.Fprintln(w, "=== Cluster Checks ===")
+	for _, r := range report.Cluster {
+		printCheckResult(w, r)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "=== Instances (%d) ===\n", len(report.Instances))
+	if len(report.Instances) == 0 {
+		fmt.Fprintln(w, "  No instances found.")
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprint(tw, "  NAMESPACE\tNAME")
+		for _, check := range instanceChecks {
+			fmt.Fprintf(tw, "\t%s", check.Column)
+		}
+		fmt.Fprintln(tw)
+		for _, inst := range report.Instances {
+			fmt.Fprintf(tw, "  %s\t%s", inst.Namespace, inst.Instance)
+			for _, check := range instanceChecks {
+				fmt.Fprintf(tw, "\t%s", matrixCell(inst.Results, check))
+			}
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+	}
+
+	healthy := 0
+	for _, inst := range report.Instances {
+		if inst.failures(threshold) == 0 {
+			healthy++
+		}
+		var findings []checkResult
+		for _, r := range inst.Results {
+			if !r.Passed {
+				findings = append(findings, r)
+			}
+		}
+		if len(findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n=== Instance Checks: %s/%s ===\n", inst.Namespace, inst.Instance)
+		for _, r := range findings {
+			printCheckResult(w, r)
+		}
+	}
+
+	fmt.Fprintln(w)
+	printDoctorTotals(w, report.total())
+	fmt.Fprintf(w, "%d of %d instance(s) healthy\n", healthy, len(report.Instances))
+}
+
+// matrixCell summarizes the results of one check on one instance by the
+// worst of them: "ok" if all passed, "-" if the check did not run.
+func matrixCell(results []checkResult, check instanceCheck) string {
+	cell := "-"
+	worst := -1
+	for _, r := range results {
+		if !checkProduced(check, r) {
+			continue
+		}
+		rank := 0
+		if !r.Passed {
+			rank = r.Severity.rank()
+		}
+		if rank > worst {
+			worst = rank
+			cell = resultLabel(r)
+		}
+	}
+	if cell == "PASS" {
+		return "ok"
+	}
+	return cell
+}
+
+// checkProduced reports whether r came from check. Checks that produce
+// several results, like conditions, suffix their ID.
+func checkProduced(check instanceCheck, r checkResult) bool {
+	return r.ID == check.ID || strings.HasPrefix(r.ID, check.ID+"-")
+}
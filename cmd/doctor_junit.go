@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// outputJUnit is the doctor-only output format for CI test reports.
+const outputJUnit = "junit"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeDoctorJUnit writes a doctor report as JUnit XML, one test suite per
+// category and one test case per check. Checks that fail at threshold are
+// failures; findings below it pass, with the finding in system-out, so the
+// report agrees with the exit code.
+func writeDoctorJUnit(w io.Writer, report doctorReport, threshold checkSeverity) error {
+	suites := junitTestSuites{Name: "claw doctor"}
+	if report.Instance != "" {
+		suites.Name += " " + report.Instance
+	}
+	index := map[string]int{}
+	for _, r := range report.Results {
+		addJUnitCase(&suites, index, r.Category, "doctor."+r.Category+"."+r.ID, r, threshold)
+	}
+	return encodeJUnit(w, suites)
+}
+
+// writeFleetDoctorJUnit writes a "doctor --all" report as JUnit XML, with
+// the cluster checks in one test suite and one test suite per instance.
+func writeFleetDoctorJUnit(w io.Writer, report fleetDoctorReport, threshold checkSeverity) error {
+	suites := junitTestSuites{Name: "claw doctor"}
+	index := map[string]int{}
+	for _, r := range report.Cluster {
+		addJUnitCase(&suites, index, r.Category, "doctor."+r.Category+"."+r.ID, r, threshold)
+	}
+	for _, inst := range report.Instances {
+		suite := inst.Namespace + "/" + inst.Instance
+		for _, r := range inst.Results {
+			addJUnitCase(&suites, index, suite,
+				"doctor."+inst.Namespace+"."+inst.Instance+"."+r.Category+"."+r.ID, r, threshold)
+		}
+	}
+	return encodeJUnit(w, suites)
+}
+
+// addJUnitCase adds r to the named suite, creating it on first use.
+func addJUnitCase(suites *junitTestSuites, index map[string]int, suiteName, classname string, r checkResult, threshold checkSeverity) {
+	i, ok := index[suiteName]
+	if !ok {
+		i = len(suites.Suites)
+		index[suiteName] = i
+		suites.Suites = append(suites.Suites, junitTestSuite{Name: suiteName})
+	}
+	suite := &suites.Suites[i]
+
+	tc := junitTestCase{Name: r.Name, Classname: classname}
+	if !r.Passed {
+		details := r.Message
+		if len(r.Remediation) > 0 {
+			details += "\n\nRemediation:\n  " + strings.Join(r.Remediation, "\n  ")
+		}
+		if r.failing(threshold) {
+			tc.Failure = &junitFailure{Message: r.Message, Type: string(r.Severity), Text: details}
+			suite.Failures++
+			suites.Failures++
+		} else {
+			tc.SystemOut = strings.ToUpper(string(r.Severity)) + ": " + details
+		}
+	}
+	suite.Cases = append(suite.Cases, tc)
+	suite.Tests++
+	suites.Tests++
+}
+
+func encodeJUnit(w io.Writer, suites junitTestSuites) error {
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit XML: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, data)
+	return err
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// credentialSuffixes are env var name endings that suggest the value is a
+// credential and belongs in a Secret.
+var credentialSuffixes = []string{"API_KEY", "TOKEN", "SECRET", "PASSWORD"}
+
+// envSources looks up the Secrets and ConfigMaps an instance's environment
+// refers to, each at most once.
+type envSources struct {
+	ctx     context.Context
+	clients *kube.Clients
+	ns      string
+	keys    map[string]map[string]bool
+	errs    map[string]error
+}
+
+func newEnvSources(ctx context.Context, clients *kube.Clients, ns string) *envSources {
+	return &envSources{
+		ctx:     ctx,
+		clients: clients,
+		ns:      ns,
+		keys:    map[string]map[string]bool{},
+		errs:    map[string]error{},
+	}
+}
+
+// lookup returns the keys held by the Secret or ConfigMap (kind) name.
+func (s *envSources) lookup(kind, name string) (map[string]bool, error) {
+	id := kind + "/" + name
+	if keys, ok := s.keys[id]; ok {
+		return keys, s.errs[id]
+	}
+
+	keys := map[string]bool{}
+	var err error
+	if kind == "Secret" {
+		var secret *corev1.Secret
+		secret, err = s.clients.Kube.CoreV1().Secrets(s.ns).Get(s.ctx, name, metav1.GetOptions{})
+		if err == nil {
+			for k := range secret.Data {
+				keys[k] = true
+			}
+		}
+	} else {
+		var cm *corev1.ConfigMap
+		cm, err = s.clients.Kube.CoreV1().ConfigMaps(s.ns).Get(s.ctx, name, metav1.GetOptions{})
+		if err == nil {
+			for k := range cm.Data {
+				keys[k] = true
+			}
+			for k := range cm.BinaryData {
+				keys[k] = true
+			}
+		}
+	}
+	s.keys[id], s.errs[id] = keys, err
+	return keys, err
+}
+
+// envProblems collects what is wrong with an instance's env sources. The
+// result fails at the most severe problem found.
+type envProblems struct {
+	severity    checkSeverity
+	messages    []string
+	remediation []string
+}
+
+func (p *envProblems) add(sev checkSeverity, msg string, remediation ...string) {
+	if sev.rank() > p.severity.rank() {
+		p.severity = sev
+	}
+	p.messages = append(p.messages, msg)
+next:
+	for _, step := range remediation {
+		for _, have := range p.remediation {
+			if have == step {
+				continue next
+			}
+		}
+		p.remediation = append(p.remediation, step)
+	}
+}
+
+// addLookupError records a Secret or ConfigMap that could not be read.
+// Missing optional sources are only worth a note; missing required ones
+// keep the pod from starting.
+func (p *envProblems) addLookupError(inst *v1alpha1.OpenClawInstance, kind, name, what string, optional bool, err error) {
+	switch {
+	case apierrors.IsNotFound(err) && optional:
+		p.add(severityInfo, fmt.Sprintf("%s: optional %s %q not found", what, kind, name))
+	case apierrors.IsNotFound(err):
+		p.add(severityError, fmt.Sprintf("%s: %s %q not found", what, kind, name),
+			createSourceHint(inst.Namespace, kind, name))
+	case apierrors.IsForbidden(err):
+		p.add(severityWarn, fmt.Sprintf("%s: not allowed to read %s %q", what, kind, name),
+			fmt.Sprintf("Check your access: kubectl auth can-i get %ss -n %s", strings.ToLower(kind), inst.Namespace))
+	default:
+		p.add(severityWarn, fmt.Sprintf("%s: failed to read %s %q: %v", what, kind, name, err))
+	}
+}
+
+func (p *envProblems) result(r checkResult, passMsg string) checkResult {
+	if len(p.messages) == 0 {
+		return r.pass(passMsg)
+	}
+	return r.fail(p.severity, strings.Join(p.messages, "; "), p.remediation...)
+}
+
+func createSourceHint(ns, kind, name string) string {
+	if kind == "Secret" {
+		return fmt.Sprintf("Create it: kubectl create secret generic %s -n %s --from-literal=KEY=VALUE", name, ns)
+	}
+	return fmt.Sprintf("Create it: kubectl create configmap %s -n %s --from-literal=KEY=VALUE", name, ns)
+}
+
+func checkEnvFromSources(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance) checkResult {
+	r := checkResult{
+		ID:       "env-from-exists",
+		Name:     fmt.Sprintf("envFrom sources for %q exist", inst.Name),
+		Category: categorySecrets,
+	}
+	if len(inst.Spec.EnvFrom) == 0 {
+		return r.pass("No envFrom sources")
+	}
+
+	sources := newEnvSources(ctx, clients, inst.Namespace)
+	var problems envProblems
+	for _, src := range inst.Spec.EnvFrom {
+		kind, name, optional := "", "", false
+		switch {
+		case src.SecretRef != nil:
+			kind, name = "Secret", src.SecretRef.Name
+			optional = src.SecretRef.Optional != nil && *src.SecretRef.Optional
+		case src.ConfigMapRef != nil:
+			kind, name = "ConfigMap", src.ConfigMapRef.Name
+			optional = src.ConfigMapRef.Optional != nil && *src.ConfigMapRef.Optional
+		default:
+			continue
+		}
+		keys, err := sources.lookup(kind, name)
+		if err != nil {
+			problems.addLookupError(inst, kind, name, "envFrom", optional, err)
+			continue
+		}
+		if len(keys) == 0 {
+			problems.add(severityWarn, fmt.Sprintf("envFrom: %s %q is empty", kind, name),
+				fmt.Sprintf("Check its contents: kubectl describe %s %s -n %s", strings.ToLower(kind), name, inst.Namespace))
+		}
+	}
+	return problems.result(r, fmt.Sprintf("%d source(s) found", len(inst.Spec.EnvFrom)))
+}
+
+func checkEnvValueRefs(ctx context.Context, clients *kube.Clients, inst *v1alpha1.OpenClawInstance) checkResult {
+	r := checkResult{
+		ID:       "env-refs-resolve",
+		Name:     fmt.Sprintf("Env var references for %q resolve", inst.Name),
+		Category: categorySecrets,
+	}
+
+	sources := newEnvSources(ctx, clients, inst.Namespace)
+	var problems envProblems
+	refs := 0
+	for _, env := range inst.Spec.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		kind, name, key, optional := "", "", "", false
+		switch {
+		case env.ValueFrom.SecretKeyRef != nil:
+			ref := env.ValueFrom.SecretKeyRef
+			kind, name, key = "Secret", ref.Name, ref.Key
+			optional = ref.Optional != nil && *ref.Optional
+		case env.ValueFrom.ConfigMapKeyRef != nil:
+			ref := env.ValueFrom.ConfigMapKeyRef
+			kind, name, key = "ConfigMap", ref.Name, ref.Key
+			optional = ref.Optional != nil && *ref.Optional
+		default:
+			// Field and resource references are resolved by the kubelet.
+			continue
+		}
+		refs++
+
+		keys, err := sources.lookup(kind, name)
+		if err != nil {
+			problems.addLookupError(inst, kind, name, env.Name, optional, err)
+			continue
+		}
+		if keys[key] {
+			continue
+		}
+		sev := severityError
+		if optional {
+			sev = severityInfo
+		}
+		problems.add(sev, fmt.Sprintf("%s: %s %q has no key %q", env.Name, kind, name, key),
+			fmt.Sprintf("List its keys: kubectl describe %s %s -n %s", strings.ToLower(kind), name, inst.Namespace))
+	}
+	if refs == 0 {
+		return r.pass("No Secret or ConfigMap references")
+	}
+	return problems.result(r, fmt.Sprintf("%d reference(s) resolved", refs))
+}
+
+func checkEnvPlaintextCredentials(_ context.Context, _ *kube.Clients, inst *v1alpha1.OpenClawInstance) checkResult {
+	r := checkResult{
+		ID:       "env-plaintext-credentials",
+		Name:     fmt.Sprintf("No plain-text credentials in env of %q", inst.Name),
+		Category: categorySecrets,
+	}
+
+	var names []string
+	for _, env := range inst.Spec.Env {
+		if env.ValueFrom == nil && env.Value != "" && looksLikeCredential(env.Name) {
+			names = append(names, env.Name)
+		}
+	}
+	if len(names) == 0 {
+		return r.pass("")
+	}
+
+	secret := inst.Name + "-credentials"
+	return r.fail(severityWarn,
+		fmt.Sprintf("Plain-text value(s) in spec.env, readable by anyone who can read the instance: %s", strings.Join(names, ", ")),
+		fmt.Sprintf("Move them into a Secret: kubectl create secret generic %s -n %s --from-literal=%s=...", secret, inst.Namespace, names[0]),
+		fmt.Sprintf("Then use it instead: kubectl openclaw env add-secret %s %s && kubectl openclaw env unset %s %s",
+			inst.Name, secret, inst.Name, strings.Join(names, " ")))
+}
+
+// looksLikeCredential reports whether an env var name suggests its value is
+// a credential, e.g. OPENAI_API_KEY or GITHUB_TOKEN.
+func looksLikeCredential(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, suffix := range credentialSuffixes {
+		if upper == suffix || strings.HasSuffix(upper, "_"+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,13 +2,10 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newEnableCmd() *cobra.Command {
@@ -46,99 +43,36 @@ func newEnableCmd() *cobra.Command {
 			name := args[0]
 			sidecar := args[1]
 
-			clients, err := kube.NewClients(kubeconfig)
+			kind, err := openclaw.ParseSidecar(sidecar)
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
+			opts := openclaw.SidecarOptions{}
+			opts.Persistence, _ = cmd.Flags().GetBool("persistence")
+			opts.AuthSecret, _ = cmd.Flags().GetString("auth-secret")
+			opts.Mode, _ = cmd.Flags().GetString("mode")
+			opts.Hostname, _ = cmd.Flags().GetString("hostname")
+			opts.Models, _ = cmd.Flags().GetStringSlice("models")
+			opts.GPU, _ = cmd.Flags().GetInt("gpu")
+			opts.ReadOnly, _ = cmd.Flags().GetBool("read-only")
+
+			if kind == openclaw.SidecarTailscale && opts.AuthSecret == "" {
+				return fmt.Errorf("--auth-secret is required for tailscale (Secret containing the auth key)")
 			}
 
-			var patch map[string]interface{}
-
-			switch sidecar {
-			case "chromium", "browser":
-				sidecarSpec := map[string]interface{}{"enabled": true}
-				persistence, _ := cmd.Flags().GetBool("persistence")
-				if persistence {
-					sidecarSpec["persistence"] = map[string]interface{}{"enabled": true}
-				}
-				patch = map[string]interface{}{
-					"spec": map[string]interface{}{"chromium": sidecarSpec},
-				}
-
-			case "tailscale":
-				authSecret, _ := cmd.Flags().GetString("auth-secret")
-				if authSecret == "" {
-					return fmt.Errorf("--auth-secret is required for tailscale (Secret containing the auth key)")
-				}
-				sidecarSpec := map[string]interface{}{
-					"enabled":          true,
-					"authKeySecretRef": map[string]interface{}{"name": authSecret},
-				}
-				mode, _ := cmd.Flags().GetString("mode")
-				if mode != "" {
-					sidecarSpec["mode"] = mode
-				}
-				hostname, _ := cmd.Flags().GetString("hostname")
-				if hostname != "" {
-					sidecarSpec["hostname"] = hostname
-				}
-				patch = map[string]interface{}{
-					"spec": map[string]interface{}{"tailscale": sidecarSpec},
-				}
-
-			case "ollama":
-				sidecarSpec := map[string]interface{}{"enabled": true}
-				models, _ := cmd.Flags().GetStringSlice("models")
-				if len(models) > 0 {
-					m := make([]interface{}, len(models))
-					for i, model := range models {
-						m[i] = model
-					}
-					sidecarSpec["models"] = m
-				}
-				gpu, _ := cmd.Flags().GetInt("gpu")
-				if gpu > 0 {
-					sidecarSpec["gpu"] = gpu
-				}
-				patch = map[string]interface{}{
-					"spec": map[string]interface{}{"ollama": sidecarSpec},
-				}
-
-			case "web-terminal", "terminal", "ttyd":
-				sidecarSpec := map[string]interface{}{"enabled": true}
-				readOnly, _ := cmd.Flags().GetBool("read-only")
-				if readOnly {
-					sidecarSpec["readOnly"] = true
-				}
-				patch = map[string]interface{}{
-					"spec": map[string]interface{}{"webTerminal": sidecarSpec},
-				}
-
-			default:
-				return fmt.Errorf("unknown sidecar %q — available: chromium, tailscale, ollama, web-terminal", sidecar)
-			}
-
-			patchBytes, err := json.Marshal(patch)
+			client, ns, err := newClient()
 			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
+				return err
 			}
 
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to enable %s: %w", sidecar, err)
+			if err := client.EnableSidecar(context.TODO(), ns, name, kind, opts); err != nil {
+				return err
 			}
 
 			fmt.Printf("Enabled %s on %s/%s.\n", sidecar, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
 
@@ -157,5 +91,7 @@ func newEnableCmd() *cobra.Command {
 	// Web terminal flags
 	cmd.Flags().Bool("read-only", false, "make web terminal read-only")
 
+	addWaitFlags(cmd)
+
 	return cmd
 }
@@ -2,16 +2,14 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newEnvCmd() *cobra.Command {
@@ -39,11 +37,16 @@ referenced via envFrom instead of plain-text env vars.`,
   claw env add-secret my-agent my-api-keys
 
   # Remove an environment source
-  claw env remove-secret my-agent my-api-keys`,
+  claw env remove-secret my-agent my-api-keys
+
+  # Variables and sources as JSON
+  claw env my-agent -o json`,
 		Args: cobra.ExactArgs(1),
 		RunE: envListRunE,
 	}
 
+	addOutputFlag(cmd)
+
 	cmd.AddCommand(newEnvSetCmd())
 	cmd.AddCommand(newEnvUnsetCmd())
 	cmd.AddCommand(newEnvAddSecretCmd())
@@ -55,80 +58,113 @@ referenced via envFrom instead of plain-text env vars.`,
 func envListRunE(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	clients, err := kube.NewClients(kubeconfig)
-	if err != nil {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output); err != nil {
 		return err
 	}
 
-	ns := namespace
-	if ns == "" {
-		ns, err = resolveNamespace()
-		if err != nil {
-			return err
-		}
+	client, ns, err := newClient()
+	if err != nil {
+		return err
 	}
 
-	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
+	inst, err := client.GetInstance(context.TODO(), ns, name)
 	if err != nil {
-		return fmt.Errorf("instance %q not found: %w", name, err)
+		return err
+	}
+	listing := envListingFor(inst)
+
+	if isStructuredOutput(output) {
+		return printStructured(os.Stdout, output, listing)
+	}
+	printEnvListing(os.Stdout, listing)
+	return nil
+}
+
+// envListing is the data model behind "env".
+type envListing struct {
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Env       []envVarView    `json:"env"`
+	EnvFrom   []envSourceView `json:"envFrom"`
+}
+
+type envVarView struct {
+	Name      string `json:"name"`
+	Value     string `json:"value,omitempty"`
+	ValueFrom bool   `json:"valueFrom,omitempty"`
+}
+
+type envSourceView struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+func (l *envListing) resourceNames() []string {
+	return []string{instanceResourceName(l.Name)}
+}
+
+func envListingFor(inst *v1alpha1.OpenClawInstance) *envListing {
+	listing := &envListing{
+		Namespace: inst.Namespace,
+		Name:      inst.Name,
+		Env:       []envVarView{},
+		EnvFrom:   []envSourceView{},
 	}
 
-	spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
+	for _, e := range inst.Spec.Env {
+		listing.Env = append(listing.Env, envVarView{
+			Name:      e.Name,
+			Value:     e.Value,
+			ValueFrom: e.ValueFrom != nil,
+		})
+	}
 
+	for _, ef := range inst.Spec.EnvFrom {
+		if ef.SecretRef != nil {
+			listing.EnvFrom = append(listing.EnvFrom, envSourceView{Kind: "Secret", Name: ef.SecretRef.Name})
+		}
+		if ef.ConfigMapRef != nil {
+			listing.EnvFrom = append(listing.EnvFrom, envSourceView{Kind: "ConfigMap", Name: ef.ConfigMapRef.Name})
+		}
+	}
+	return listing
+}
+
+func printEnvListing(w io.Writer, listing *envListing) {
 	// Show env vars
-	envVars, envOk := getNestedSlice(spec, "env")
-	if envOk && len(envVars) > 0 {
-		fmt.Printf("Environment Variables (%s/%s):\n", ns, name)
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  NAME\tVALUE")
-		for _, e := range envVars {
-			em, ok := e.(map[string]interface{})
-			if !ok {
-				continue
-			}
-			eName := getNestedString(em, "name")
-			eValue := getNestedString(em, "value")
-			// Check for valueFrom
-			if _, hasValueFrom := em["valueFrom"]; hasValueFrom {
-				eValue = "(from secret/configmap)"
-			}
-			fmt.Fprintf(w, "  %s\t%s\n", eName, eValue)
+	if len(listing.Env) > 0 {
+		fmt.Fprintf(w, "Environment Variables (%s/%s):\n", listing.Namespace, listing.Name)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  NAME\tVALUE")
+		for _, e := range listing.Env {
+			value := e.Value
+			if e.ValueFrom {
+				value = "(from secret/configmap)"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\n", e.Name, value)
 		}
-		w.Flush()
+		tw.Flush()
 	} else {
-		fmt.Printf("No environment variables set on %q.\n", name)
+		fmt.Fprintf(w, "No environment variables set on %q.\n", listing.Name)
 	}
 
 	// Show envFrom sources
-	envFrom, fromOk := getNestedSlice(spec, "envFrom")
-	if fromOk && len(envFrom) > 0 {
-		fmt.Println("\nEnvironment Sources:")
-		for _, ef := range envFrom {
-			efm, ok := ef.(map[string]interface{})
-			if !ok {
-				continue
-			}
-			if secretRef, ok := efm["secretRef"].(map[string]interface{}); ok {
-				fmt.Printf("  Secret/%s\n", getNestedString(secretRef, "name"))
-			}
-			if cmRef, ok := efm["configMapRef"].(map[string]interface{}); ok {
-				fmt.Printf("  ConfigMap/%s\n", getNestedString(cmRef, "name"))
-			}
+	if len(listing.EnvFrom) > 0 {
+		fmt.Fprintln(w, "\nEnvironment Sources:")
+		for _, src := range listing.EnvFrom {
+			fmt.Fprintf(w, "  %s/%s\n", src.Kind, src.Name)
 		}
 	}
 
-	if (!envOk || len(envVars) == 0) && (!fromOk || len(envFrom) == 0) {
-		fmt.Printf("\nSet variables with:\n  kubectl openclaw env set %s KEY=VALUE\n", name)
-		fmt.Printf("\nOr reference a Secret:\n  kubectl openclaw env add-secret %s SECRET_NAME\n", name)
+	if len(listing.Env) == 0 && len(listing.EnvFrom) == 0 {
+		fmt.Fprintf(w, "\nSet variables with:\n  kubectl openclaw env set %s KEY=VALUE\n", listing.Name)
+		fmt.Fprintf(w, "\nOr reference a Secret:\n  kubectl openclaw env add-secret %s SECRET_NAME\n", listing.Name)
 	}
-
-	return nil
 }
 
 func newEnvSetCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "set NAME KEY=VALUE [KEY=VALUE...]",
 		Short: "Set environment variables on an OpenClaw instance",
 		Args:  cobra.MinimumNArgs(2),
@@ -146,124 +182,41 @@ func newEnvSetCmd() *cobra.Command {
 				newVars[parts[0]] = parts[1]
 			}
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			existing, _ := getNestedSlice(spec, "env")
-
-			// Update existing or build new list
-			updated := make(map[string]bool)
-			var envList []interface{}
-			for _, e := range existing {
-				em, ok := e.(map[string]interface{})
-				if !ok {
-					envList = append(envList, e)
-					continue
-				}
-				eName := getNestedString(em, "name")
-				if val, found := newVars[eName]; found {
-					envList = append(envList, map[string]interface{}{"name": eName, "value": val})
-					updated[eName] = true
-				} else {
-					envList = append(envList, e)
-				}
-			}
-
-			// Add new vars that weren't updates
-			for k, v := range newVars {
-				if !updated[k] {
-					envList = append(envList, map[string]interface{}{"name": k, "value": v})
-				}
-			}
-
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"env": envList,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to update env vars: %w", err)
+			if err := client.SetEnv(context.TODO(), ns, name, newVars); err != nil {
+				return err
 			}
 
 			fmt.Printf("Set %d environment variable(s) on %s/%s.\n", len(newVars), ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
 
 func newEnvUnsetCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "unset NAME KEY [KEY...]",
 		Short: "Remove environment variables from an OpenClaw instance",
 		Args:  cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
-			toRemove := make(map[string]bool)
-			for _, k := range args[1:] {
-				toRemove[k] = true
-			}
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			removed, err := client.UnsetEnv(context.TODO(), ns, name, args[1:]...)
 			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			existing, _ := getNestedSlice(spec, "env")
-
-			var filtered []interface{}
-			removed := 0
-			for _, e := range existing {
-				em, ok := e.(map[string]interface{})
-				if ok {
-					eName := getNestedString(em, "name")
-					if toRemove[eName] {
-						removed++
-						continue
-					}
-				}
-				filtered = append(filtered, e)
+				return err
 			}
 
 			if removed == 0 {
@@ -271,35 +224,18 @@ func newEnvUnsetCmd() *cobra.Command {
 				return nil
 			}
 
-			if filtered == nil {
-				filtered = []interface{}{}
-			}
-
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"env": filtered,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to update env vars: %w", err)
-			}
-
 			fmt.Printf("Removed %d environment variable(s) from %s/%s.\n", removed, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
 
 func newEnvAddSecretCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "add-secret NAME SECRET_NAME",
 		Short: "Add a Secret as an environment source",
 		Args:  cobra.ExactArgs(2),
@@ -307,75 +243,33 @@ func newEnvAddSecretCmd() *cobra.Command {
 			name := args[0]
 			secretName := args[1]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			existing, _ := getNestedSlice(spec, "envFrom")
-
-			// Check if already referenced
-			for _, ef := range existing {
-				efm, ok := ef.(map[string]interface{})
-				if !ok {
-					continue
-				}
-				if secretRef, ok := efm["secretRef"].(map[string]interface{}); ok {
-					if getNestedString(secretRef, "name") == secretName {
-						fmt.Printf("Secret %q is already referenced.\n", secretName)
-						return nil
-					}
-				}
-			}
-
-			newRef := map[string]interface{}{
-				"secretRef": map[string]interface{}{
-					"name": secretName,
-				},
-			}
-			existing = append(existing, newRef)
-
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"envFrom": existing,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
+			added, err := client.AddEnvSecret(context.TODO(), ns, name, secretName)
 			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
+				return err
 			}
 
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to update envFrom: %w", err)
+			if !added {
+				fmt.Printf("Secret %q is already referenced.\n", secretName)
+				return nil
 			}
 
 			fmt.Printf("Added Secret/%s as environment source on %s/%s.\n", secretName, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
 
 func newEnvRemoveSecretCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "remove-secret NAME SECRET_NAME",
 		Short: "Remove a Secret from environment sources",
 		Args:  cobra.ExactArgs(2),
@@ -383,72 +277,27 @@ func newEnvRemoveSecretCmd() *cobra.Command {
 			name := args[0]
 			secretName := args[1]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			removed, err := client.RemoveEnvSecret(context.TODO(), ns, name, secretName)
 			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			existing, _ := getNestedSlice(spec, "envFrom")
-
-			var filtered []interface{}
-			found := false
-			for _, ef := range existing {
-				efm, ok := ef.(map[string]interface{})
-				if ok {
-					if secretRef, ok := efm["secretRef"].(map[string]interface{}); ok {
-						if getNestedString(secretRef, "name") == secretName {
-							found = true
-							continue
-						}
-					}
-				}
-				filtered = append(filtered, ef)
+				return err
 			}
 
-			if !found {
+			if !removed {
 				fmt.Printf("Secret %q not found in environment sources.\n", secretName)
 				return nil
 			}
 
-			if filtered == nil {
-				filtered = []interface{}{}
-			}
-
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"envFrom": filtered,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to update envFrom: %w", err)
-			}
-
 			fmt.Printf("Removed Secret/%s from environment sources on %s/%s.\n", secretName, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
@@ -4,16 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
 	"text/tabwriter"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func newEventsCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "events NAME",
 		Short: "Show events for an OpenClaw instance",
 		Long: `Display Kubernetes events related to an OpenClawInstance and its managed pods.
@@ -22,101 +19,41 @@ Useful for debugging provisioning failures, crash loops, and reconciliation issu
   kubectl openclaw events my-agent
 
   # Show events in a specific namespace
-  kubectl openclaw events my-agent -n production`,
+  kubectl openclaw events my-agent -n production
+
+  # Events as YAML
+  kubectl openclaw events my-agent -o yaml`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
-			if err != nil {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
+			client, ns, err := newClient()
+			if err != nil {
+				return err
 			}
 
-			events, err := clients.Kube.CoreV1().Events(ns).List(context.TODO(), metav1.ListOptions{
-				FieldSelector: fmt.Sprintf("involvedObject.name=%s", name),
-			})
+			deduped, err := client.Events(context.TODO(), ns, name)
 			if err != nil {
-				return fmt.Errorf("failed to list events: %w", err)
+				return err
 			}
 
-			pods, _ := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-				LabelSelector: podLabelSelector(name),
-			})
-			if pods != nil {
-				for _, pod := range pods.Items {
-					podEvents, err := clients.Kube.CoreV1().Events(ns).List(context.TODO(), metav1.ListOptions{
-						FieldSelector: fmt.Sprintf("involvedObject.name=%s", pod.Name),
-					})
-					if err == nil {
-						events.Items = append(events.Items, podEvents.Items...)
-					}
-				}
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, deduped)
 			}
 
-			// Get events for managed StatefulSet/Service
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
-			if err == nil {
-				status, _, _ := unstructuredNestedMap(obj.Object, "status")
-				managed, ok, _ := unstructuredNestedMap(status, "managedResources")
-				if ok {
-					for _, key := range []string{"statefulSet", "deployment"} {
-						resName := getNestedString(managed, key)
-						if resName != "" {
-							resEvents, err := clients.Kube.CoreV1().Events(ns).List(context.TODO(), metav1.ListOptions{
-								FieldSelector: fmt.Sprintf("involvedObject.name=%s", resName),
-							})
-							if err == nil {
-								events.Items = append(events.Items, resEvents.Items...)
-							}
-						}
-					}
-				}
-			}
-
-			if len(events.Items) == 0 {
+			if len(deduped.Items) == 0 {
 				fmt.Printf("No events found for instance %q in namespace %q.\n", name, ns)
 				return nil
 			}
 
-			// Deduplicate by UID
-			seen := make(map[string]bool)
-			var deduped []int
-			for i, e := range events.Items {
-				uid := string(e.UID)
-				if !seen[uid] {
-					seen[uid] = true
-					deduped = append(deduped, i)
-				}
-			}
-
-			sort.Slice(deduped, func(i, j int) bool {
-				ei := events.Items[deduped[i]]
-				ej := events.Items[deduped[j]]
-				ti := ei.LastTimestamp.Time
-				tj := ej.LastTimestamp.Time
-				if ti.IsZero() {
-					ti = ei.FirstTimestamp.Time
-				}
-				if tj.IsZero() {
-					tj = ej.FirstTimestamp.Time
-				}
-				return ti.Before(tj)
-			})
-
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 			fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
-			for _, idx := range deduped {
-				e := events.Items[idx]
+			for _, e := range deduped.Items {
 				age := formatAge(e.LastTimestamp.Time)
 				if e.LastTimestamp.IsZero() {
 					if !e.FirstTimestamp.IsZero() {
@@ -135,4 +72,7 @@ Useful for debugging provisioning failures, crash loops, and reconciliation issu
 			return w.Flush()
 		},
 	}
+
+	addOutputFlag(cmd)
+	return cmd
 }
@@ -3,17 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/remotecommand"
 	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 type sizeQueue struct {
@@ -60,104 +58,72 @@ By default opens an interactive shell (/bin/sh). Use -- to pass a custom command
 				}
 			}
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
+			return execInInstance(client, ns, name, container, execCmd, useTTY,
+				os.Stdin, os.Stdout, os.Stderr, cmd.ErrOrStderr())
+		},
+	}
 
-			pods, err := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-				LabelSelector: podLabelSelector(name),
-			})
-			if err != nil {
-				return fmt.Errorf("failed to list pods: %w", err)
-			}
-			if len(pods.Items) == 0 {
-				return fmt.Errorf("no pods found for instance %q in namespace %q", name, ns)
-			}
+	cmd.Flags().StringVarP(&container, "container", "c", "", "container name (default: main openclaw container)")
+	cmd.Flags().BoolVarP(&useTTY, "tty", "t", true, "allocate a pseudo-TTY")
 
-			pod := pods.Items[0]
-			if len(pods.Items) > 1 {
-				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: multiple pods found, using %s\n", pod.Name)
-			}
+	return cmd
+}
 
-			execOpts := &corev1.PodExecOptions{
-				Command: execCmd,
-				Stdin:   true,
-				Stdout:  true,
-				Stderr:  !useTTY,
-				TTY:     useTTY,
-			}
-			if container != "" {
-				execOpts.Container = container
-			}
+// execInInstance runs command in the instance's pod. With useTTY, the local
+// terminal is put into raw mode and resizes are forwarded. The dashboard
+// reuses it with its own stdin.
+func execInInstance(client *openclaw.Client, ns, name, container string, command []string, useTTY bool,
+	stdin io.Reader, stdout, stderr, warn io.Writer) error {
+	pod, err := instancePod(client, ns, name, warn)
+	if err != nil {
+		return err
+	}
 
-			req := clients.Kube.CoreV1().RESTClient().Post().
-				Resource("pods").
-				Name(pod.Name).
-				Namespace(ns).
-				SubResource("exec").
-				VersionedParams(execOpts, scheme.ParameterCodec)
+	opts := openclaw.ExecOptions{
+		Container: container,
+		Command:   command,
+		Stdin:     stdin,
+		Stdout:    stdout,
+		Stderr:    stderr,
+		TTY:       useTTY,
+	}
 
-			exec, err := remotecommand.NewSPDYExecutor(clients.Config, "POST", req.URL())
+	if useTTY {
+		fd := int(os.Stdin.Fd())
+		if term.IsTerminal(fd) {
+			oldState, err := term.MakeRaw(fd)
 			if err != nil {
-				return fmt.Errorf("failed to create executor: %w", err)
+				return fmt.Errorf("failed to set terminal raw mode: %w", err)
 			}
+			defer term.Restore(fd, oldState)
+		}
 
-			if useTTY {
-				fd := int(os.Stdin.Fd())
-				if term.IsTerminal(fd) {
-					oldState, err := term.MakeRaw(fd)
-					if err != nil {
-						return fmt.Errorf("failed to set terminal raw mode: %w", err)
-					}
-					defer term.Restore(fd, oldState)
-				}
+		sq := &sizeQueue{ch: make(chan remotecommand.TerminalSize, 1)}
 
-				sq := &sizeQueue{ch: make(chan remotecommand.TerminalSize, 1)}
+		width, height, err := term.GetSize(fd)
+		if err == nil {
+			sq.ch <- remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+		}
 
-				width, height, err := term.GetSize(fd)
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGWINCH)
+		go func() {
+			for range sigCh {
+				w, h, err := term.GetSize(fd)
 				if err == nil {
-					sq.ch <- remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+					sq.ch <- remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
 				}
-
-				sigCh := make(chan os.Signal, 1)
-				signal.Notify(sigCh, syscall.SIGWINCH)
-				go func() {
-					for range sigCh {
-						w, h, err := term.GetSize(fd)
-						if err == nil {
-							sq.ch <- remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
-						}
-					}
-				}()
-				defer signal.Stop(sigCh)
-
-				return exec.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
-					Stdin:             os.Stdin,
-					Stdout:            os.Stdout,
-					Tty:               true,
-					TerminalSizeQueue: sq,
-				})
 			}
+		}()
+		defer signal.Stop(sigCh)
 
-			return exec.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
-				Stdin:  os.Stdin,
-				Stdout: os.Stdout,
-				Stderr: os.Stderr,
-			})
-		},
+		opts.TerminalSizeQueue = sq
 	}
 
-	cmd.Flags().StringVarP(&container, "container", "c", "", "container name (default: main openclaw container)")
-	cmd.Flags().BoolVarP(&useTTY, "tty", "t", true, "allocate a pseudo-TTY")
-
-	return cmd
+	return client.Exec(context.TODO(), ns, pod.Name, opts)
 }
@@ -1,12 +1,35 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// newClient builds an SDK client from the global flags and returns it along
+// with the namespace commands should operate in.
+func newClient() (*openclaw.Client, string, error) {
+	client, err := openclaw.NewClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ns := namespace
+	if ns == "" {
+		ns, err = resolveNamespace()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return client, ns, nil
+}
+
 func resolveNamespace() (string, error) {
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
 	if kubeconfig != "" {
@@ -36,109 +59,36 @@ func formatAge(t time.Time) string {
 	}
 }
 
-func getNestedString(obj map[string]interface{}, keys ...string) string {
-	current := obj
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			val, _ := current[key].(string)
-			return val
-		}
-		next, ok := current[key].(map[string]interface{})
-		if !ok {
-			return ""
-		}
-		current = next
-	}
-	return ""
-}
-
-func getNestedBool(obj map[string]interface{}, keys ...string) (bool, bool) {
-	current := obj
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			val, ok := current[key].(bool)
-			return val, ok
-		}
-		next, ok := current[key].(map[string]interface{})
-		if !ok {
-			return false, false
-		}
-		current = next
-	}
-	return false, false
+func formatTimestamp(t metav1.Time) string {
+	return t.UTC().Format(time.RFC3339)
 }
 
-func getNestedInt64(obj map[string]interface{}, keys ...string) (int64, bool) {
-	current := obj
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			switch v := current[key].(type) {
-			case int64:
-				return v, true
-			case float64:
-				return int64(v), true
-			default:
-				return 0, false
-			}
-		}
-		next, ok := current[key].(map[string]interface{})
-		if !ok {
-			return 0, false
-		}
-		current = next
+// valueOrNone renders an empty table cell as <none>, like kubectl.
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
 	}
-	return 0, false
+	return s
 }
 
-func getNestedSlice(obj map[string]interface{}, keys ...string) ([]interface{}, bool) {
-	current := obj
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			val, ok := current[key].([]interface{})
-			return val, ok
-		}
-		next, ok := current[key].(map[string]interface{})
-		if !ok {
-			return nil, false
-		}
-		current = next
+// instancePod returns the pod to exec into or read logs from, warning on warn
+// when the instance has more than one.
+func instancePod(client *openclaw.Client, ns, name string, warn io.Writer) (*corev1.Pod, error) {
+	pods, err := client.Pods(context.TODO(), ns, name)
+	if err != nil {
+		return nil, err
 	}
-	return nil, false
-}
-
-func unstructuredNestedMap(obj map[string]interface{}, fields ...string) (map[string]interface{}, bool, error) {
-	current := obj
-	for _, field := range fields {
-		next, ok := current[field].(map[string]interface{})
-		if !ok {
-			return nil, false, nil
-		}
-		current = next
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no pods found for instance %q in namespace %q", name, ns)
 	}
-	return current, true, nil
-}
 
-func getConditionStatus(status map[string]interface{}, condType string) string {
-	conditions, ok := status["conditions"]
-	if !ok {
-		return "Unknown"
-	}
-	condList, ok := conditions.([]interface{})
-	if !ok {
-		return "Unknown"
-	}
-	for _, c := range condList {
-		cond, ok := c.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		if getNestedString(cond, "type") == condType {
-			return getNestedString(cond, "status")
-		}
+	pod := pods[0]
+	if len(pods) > 1 {
+		fmt.Fprintf(warn, "Warning: multiple pods found, using %s\n", pod.Name)
 	}
-	return "Unknown"
+	return &pod, nil
 }
 
 func podLabelSelector(instanceName string) string {
-	return fmt.Sprintf("app.kubernetes.io/name=openclaw,app.kubernetes.io/instance=%s", instanceName)
+	return openclaw.PodLabelSelector(instanceName)
 }
@@ -6,7 +6,9 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
 	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -23,28 +25,34 @@ func newListCmd() *cobra.Command {
   kubectl openclaw list
 
   # List instances across all namespaces
-  kubectl openclaw list -A`,
+  kubectl openclaw list -A
+
+  # Include image, restarts and last backup
+  kubectl openclaw list -o wide
+
+  # Names only, for scripting
+  kubectl openclaw list -o name`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clients, err := kube.NewClients(kubeconfig)
-			if err != nil {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
 				return err
 			}
 
-			ns := namespace
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
 			if allNamespaces {
 				ns = ""
-			} else if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
 			}
 
-			list, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).List(
-				context.TODO(), metav1.ListOptions{},
-			)
+			list, err := client.ListInstanceObjects(context.TODO(), ns, openclaw.ListOptions{})
 			if err != nil {
-				return fmt.Errorf("failed to list OpenClawInstances: %w", err)
+				return err
+			}
+
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, list)
 			}
 
 			if len(list.Items) == 0 {
@@ -56,30 +64,43 @@ func newListCmd() *cobra.Command {
 				return nil
 			}
 
+			wide := output == outputWide
+			var restarts map[string]int32
+			if wide {
+				restarts = instanceRestarts(client.Clients(), ns)
+			}
+
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			header := "NAME\tPHASE\tREADY\tGATEWAY\tAGE"
 			if allNamespaces {
-				fmt.Fprintln(w, "NAMESPACE\tNAME\tPHASE\tREADY\tGATEWAY\tAGE")
-			} else {
-				fmt.Fprintln(w, "NAME\tPHASE\tREADY\tGATEWAY\tAGE")
+				header = "NAMESPACE\t" + header
+			}
+			if wide {
+				header += "\tIMAGE\tRESTARTS\tLAST BACKUP"
 			}
+			fmt.Fprintln(w, header)
 
-			for _, item := range list.Items {
-				status, _, _ := unstructuredNestedMap(item.Object, "status")
-				phase := getNestedString(status, "phase")
-				if phase == "" {
-					phase = "Pending"
+			for i := range list.Items {
+				inst, err := v1alpha1.FromUnstructured(&list.Items[i])
+				if err != nil {
+					return err
 				}
-				gateway := getNestedString(status, "gatewayEndpoint")
-				ready := getConditionStatus(status, "Ready")
-				age := formatAge(item.GetCreationTimestamp().Time)
+				age := formatAge(inst.CreationTimestamp.Time)
 
+				row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", inst.Name, inst.Status.CurrentPhase(),
+					inst.Status.ConditionStatus("Ready"), inst.Status.GatewayEndpoint, age)
 				if allNamespaces {
-					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-						item.GetNamespace(), item.GetName(), phase, ready, gateway, age)
-				} else {
-					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-						item.GetName(), phase, ready, gateway, age)
+					row = inst.Namespace + "\t" + row
 				}
+				if wide {
+					lastBackup := "<none>"
+					if inst.Status.LastBackupTime != nil {
+						lastBackup = formatTimestamp(*inst.Status.LastBackupTime)
+					}
+					row += fmt.Sprintf("\t%s\t%d\t%s",
+						inst.Spec.ImageReference(), restarts[inst.Namespace+"/"+inst.Name], lastBackup)
+				}
+				fmt.Fprintln(w, row)
 			}
 
 			return w.Flush()
@@ -87,5 +108,25 @@ func newListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list instances across all namespaces")
+	addOutputFlag(cmd)
 	return cmd
 }
+
+// instanceRestarts sums container restarts per instance ("namespace/name") for
+// all OpenClaw pods in ns. Errors are ignored; the column simply shows 0.
+func instanceRestarts(clients *kube.Clients, ns string) map[string]int32 {
+	restarts := make(map[string]int32)
+	pods, err := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=openclaw",
+	})
+	if err != nil {
+		return restarts
+	}
+	for _, pod := range pods.Items {
+		key := pod.Namespace + "/" + pod.Labels["app.kubernetes.io/instance"]
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts[key] += cs.RestartCount
+		}
+	}
+	return restarts
+}
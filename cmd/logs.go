@@ -7,10 +7,8 @@ import (
 	"io"
 	"time"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func newLogsCmd() *cobra.Command {
@@ -61,32 +59,14 @@ Automatically resolves the pod name from the instance name using label selectors
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			pods, err := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-				LabelSelector: podLabelSelector(name),
-			})
+			pod, err := instancePod(client, ns, name, cmd.ErrOrStderr())
 			if err != nil {
-				return fmt.Errorf("failed to list pods: %w", err)
-			}
-			if len(pods.Items) == 0 {
-				return fmt.Errorf("no pods found for OpenClawInstance %q in namespace %q", name, ns)
-			}
-
-			pod := pods.Items[0]
-			if len(pods.Items) > 1 {
-				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: multiple pods found, using %s\n", pod.Name)
+				return err
 			}
 
 			opts := &corev1.PodLogOptions{
@@ -109,10 +89,9 @@ Automatically resolves the pod name from the instance name using label selectors
 				opts.SinceSeconds = &sinceSeconds
 			}
 
-			req := clients.Kube.CoreV1().Pods(ns).GetLogs(pod.Name, opts)
-			stream, err := req.Stream(context.TODO())
+			stream, err := client.LogStream(context.TODO(), ns, pod.Name, opts)
 			if err != nil {
-				return fmt.Errorf("failed to stream logs from pod %s: %w", pod.Name, err)
+				return err
 			}
 			defer stream.Close()
 
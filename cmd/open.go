@@ -6,7 +6,6 @@ import (
 	"os/exec"
 	"runtime"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -28,50 +27,30 @@ Detects the URL from ingress configuration, LoadBalancer, or suggests port-forwa
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			inst, err := client.GetInstance(context.TODO(), ns, name)
 			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
+				return err
 			}
 
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			status, _, _ := unstructuredNestedMap(obj.Object, "status")
-
 			// Try ingress hosts first
-			ingressEnabled, iOk := getNestedBool(spec, "networking", "ingress", "enabled")
-			if iOk && ingressEnabled {
-				hosts, hOk := getNestedSlice(spec, "networking", "ingress", "hosts")
-				if hOk && len(hosts) > 0 {
-					if hm, ok := hosts[0].(map[string]interface{}); ok {
-						host := getNestedString(hm, "host")
-						if host != "" {
-							url := "https://" + host
-							fmt.Printf("Opening %s\n", url)
-							return openBrowser(url)
-						}
-					}
+			ingressSpec := inst.Spec.Networking.Ingress
+			if ingressSpec.Enabled != nil && *ingressSpec.Enabled && len(ingressSpec.Hosts) > 0 {
+				if host := ingressSpec.Hosts[0].Host; host != "" {
+					url := "https://" + host
+					fmt.Printf("Opening %s\n", url)
+					return openBrowser(url)
 				}
 			}
 
 			// Try LoadBalancer service
-			managed, _, _ := unstructuredNestedMap(status, "managedResources")
-			svcName := getNestedString(managed, "service")
+			svcName := inst.Status.Managed().Service
 			if svcName != "" {
-				svc, err := clients.Kube.CoreV1().Services(ns).Get(context.TODO(), svcName, metav1.GetOptions{})
+				svc, err := client.Clients().Kube.CoreV1().Services(ns).Get(context.TODO(), svcName, metav1.GetOptions{})
 				if err == nil && svc.Spec.Type == "LoadBalancer" {
 					for _, ingress := range svc.Status.LoadBalancer.Ingress {
 						host := ingress.Hostname
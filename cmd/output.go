@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputWide           = "wide"
+	outputJSON           = "json"
+	outputYAML           = "yaml"
+	outputName           = "name"
+	outputJSONPath       = "jsonpath="
+	outputGoTemplate     = "go-template="
+	instanceResourceID   = "openclawinstance.openclaw.rocks"
+	selfConfigResourceID = "openclawselfconfig.openclaw.rocks"
+)
+
+// namedOutput is implemented by views that know which resources they describe,
+// so that -o name can print them the way kubectl does.
+type namedOutput interface {
+	resourceNames() []string
+}
+
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", "",
+		"output format: json, yaml, wide, name, jsonpath=TEMPLATE, go-template=TEMPLATE")
+}
+
+func validateOutputFormat(format string) error {
+	switch {
+	case format == "", format == outputWide, format == outputJSON, format == outputYAML, format == outputName:
+		return nil
+	case strings.HasPrefix(format, outputJSONPath), strings.HasPrefix(format, outputGoTemplate):
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q — use json, yaml, wide, name, jsonpath=... or go-template=...", format)
+	}
+}
+
+// isStructuredOutput reports whether the format replaces the human-readable text
+// printers. "wide" is still text, just with more columns.
+func isStructuredOutput(format string) bool {
+	return format != "" && format != outputWide
+}
+
+// printStructured writes obj in one of the machine-readable output formats.
+// obj must serialize to JSON; views are expected to carry json tags.
+func printStructured(w io.Writer, format string, obj interface{}) error {
+	switch {
+	case format == outputJSON:
+		data, err := json.MarshalIndent(obj, "", "    ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+
+	case format == outputYAML:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+
+	case format == outputName:
+		for _, n := range resourceNamesOf(obj) {
+			if _, err := fmt.Fprintln(w, n); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case strings.HasPrefix(format, outputJSONPath):
+		data, err := toGeneric(obj)
+		if err != nil {
+			return err
+		}
+		jp := jsonpath.New("output")
+		jp.AllowMissingKeys(true)
+		if err := jp.Parse(relaxedJSONPath(strings.TrimPrefix(format, outputJSONPath))); err != nil {
+			return fmt.Errorf("invalid jsonpath template: %w", err)
+		}
+		return jp.Execute(w, data)
+
+	case strings.HasPrefix(format, outputGoTemplate):
+		data, err := toGeneric(obj)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New("output").Parse(strings.TrimPrefix(format, outputGoTemplate))
+		if err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+		return tmpl.Execute(w, data)
+
+	default:
+		return validateOutputFormat(format)
+	}
+}
+
+// toGeneric round-trips obj through JSON so that jsonpath and go-template see
+// the same field names as -o json, not Go struct field names.
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode output: %w", err)
+	}
+	return out, nil
+}
+
+// relaxedJSONPath accepts both "{.metadata.name}" and ".metadata.name", like kubectl.
+func relaxedJSONPath(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+func resourceNamesOf(obj interface{}) []string {
+	switch o := obj.(type) {
+	case namedOutput:
+		return o.resourceNames()
+	case *unstructured.Unstructured:
+		return []string{instanceResourceName(o.GetName())}
+	case *unstructured.UnstructuredList:
+		names := make([]string, 0, len(o.Items))
+		for _, item := range o.Items {
+			names = append(names, instanceResourceName(item.GetName()))
+		}
+		return names
+	case *corev1.EventList:
+		names := make([]string, 0, len(o.Items))
+		for _, item := range o.Items {
+			names = append(names, "event/"+item.Name)
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+func instanceResourceName(name string) string {
+	return instanceResourceID + "/" + name
+}
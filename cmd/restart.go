@@ -3,64 +3,57 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func newRestartCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "restart NAME",
 		Short: "Restart an OpenClaw instance",
 		Long: `Restart an OpenClawInstance by deleting its pods. The StatefulSet controller
 will automatically recreate them, triggering a fresh start.`,
 		Example: `  claw restart my-agent
-  claw restart my-agent -n production`,
+  claw restart my-agent -n production
+
+  # Restart and wait for the new pods to become ready
+  claw restart my-agent --wait`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			// Verify instance exists
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			pods, err := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-				LabelSelector: podLabelSelector(name),
-			})
+			started := time.Now()
+			result, err := client.Restart(context.TODO(), ns, name)
 			if err != nil {
-				return fmt.Errorf("failed to list pods: %w", err)
+				return err
 			}
 
-			if len(pods.Items) == 0 {
+			if len(result.Deleted) == 0 && len(result.Failed) == 0 {
 				fmt.Printf("No pods found for instance %q — nothing to restart.\n", name)
 				return nil
 			}
 
-			for _, pod := range pods.Items {
-				err := clients.Kube.CoreV1().Pods(ns).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
-				if err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to delete pod %s: %v\n", pod.Name, err)
-				} else {
-					fmt.Printf("Deleted pod %s\n", pod.Name)
+			for _, pod := range result.Deleted {
+				fmt.Printf("Deleted pod %s\n", pod)
+			}
+			for pod, err := range result.Failed {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to delete pod %s: %v\n", pod, err)
+			}
+
+			if ctx, cancel, ok := waitContext(cmd); ok {
+				defer cancel()
+				fmt.Printf("Waiting for pods of %s/%s to become ready...\n", ns, name)
+				if err := client.WaitForPodsReady(ctx, ns, name, started, len(result.Deleted)); err != nil {
+					return err
 				}
+				fmt.Printf("Instance %s/%s restarted.\n", ns, name)
+				return nil
 			}
 
 			fmt.Printf("\nInstance %q is restarting. Monitor with:\n", name)
@@ -68,4 +61,7 @@ will automatically recreate them, triggering a fresh start.`,
 			return nil
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
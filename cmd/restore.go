@@ -1,28 +1,57 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newRestoreCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		into         string
+		yes          bool
+		safetyBackup bool
+		skipVerify   bool
+	)
+
+	cmd := &cobra.Command{
 		Use:   "restore NAME PATH",
 		Short: "Restore an OpenClaw instance from a backup",
 		Long: `Trigger a restore of an OpenClawInstance by setting the restoreFrom field
 to the specified S3 backup path. The operator will handle the restore process.
 
 The instance will enter the "Restoring" phase while the restore is in progress.
-The restoreFrom field is automatically cleared after a successful restore.`,
+The restoreFrom field is automatically cleared after a successful restore.
+
+Before anything is changed, the backup path is checked in the object store
+(see "backup list" for how the store is found; --skip-verify turns this off),
+and you are shown what will be overwritten and asked to confirm unless --yes
+is given. --safety-backup takes a backup of the current data first. Restores
+are refused while the instance is BackingUp or Restoring.
+
+With --into, the instance is left alone: a new instance is created with a copy
+of its spec and restoreFrom set, to inspect an old state side by side or to
+fork an agent. Ingress hosts, an existing PVC claim, the Tailscale hostname
+and the upgrade history are not copied, since they belong to the source
+instance.`,
 		Example: `  # Restore from a specific backup path
   kubectl openclaw restore my-agent s3://my-bucket/backups/my-agent/2024-01-15T020000Z
 
+  # Back up the current data first, then restore without prompting
+  kubectl openclaw restore my-agent s3://my-bucket/backups/my-agent/2024-01-15T020000Z --safety-backup --yes
+
+  # Restore and block until the instance is running again
+  kubectl openclaw restore my-agent s3://my-bucket/backups/my-agent/2024-01-15T020000Z --wait
+
+  # Restore into a new instance, leaving my-agent untouched
+  kubectl openclaw restore my-agent s3://my-bucket/backups/my-agent/2024-01-15T020000Z --into my-agent-jan15
+
   # Check restore progress
   kubectl openclaw status my-agent
 
@@ -33,47 +62,147 @@ The restoreFrom field is automatically cleared after a successful restore.`,
 			name := args[0]
 			path := args[1]
 
-			clients, err := kube.NewClients(kubeconfig)
+			if into == name {
+				return fmt.Errorf("--into must name a new instance, not %q itself", name)
+			}
+
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
+			ctx := context.TODO()
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
+			inst, err := client.GetInstance(ctx, ns, name)
+			if err != nil {
+				return err
+			}
+			if into == "" {
+				if err := openclaw.CheckRestorable(inst); err != nil {
 					return err
 				}
 			}
 
-			// Verify instance exists
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("instance %q not found in namespace %q: %w", name, ns, err)
+			if !skipVerify {
+				if err := verifyBackupPath(ctx, cmd, client, ns, name, path); err != nil {
+					return err
+				}
 			}
 
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"restoreFrom": path,
-				},
+			if into != "" {
+				if _, err := client.CloneFromBackup(ctx, ns, name, into, path); err != nil {
+					return err
+				}
+				fmt.Printf("Created %s/%s from %s/%s, restoring from:\n  %s\n", ns, into, ns, name, path)
+				if waited, err := waitIfRequested(cmd, client, ns, into); waited {
+					return err
+				}
+				fmt.Printf("\nMonitor progress with:\n  kubectl openclaw status %s\n", into)
+				return nil
 			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
+
+			if !yes {
+				printRestorePreview(ctx, client, inst, path, safetyBackup)
+				fmt.Printf("Overwrite the data of this instance? [y/N]: ")
+				reader := bufio.NewReader(os.Stdin)
+				answer, _ := reader.ReadString('\n')
+				answer = strings.TrimSpace(strings.ToLower(answer))
+				if answer != "y" && answer != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
 			}
 
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to trigger restore: %w", err)
+			if safetyBackup {
+				fmt.Println("Taking a safety backup first...")
+				safetyPath, err := runBackupNow(client, ns, name, defaultBackupTimeout, false)
+				if err != nil {
+					return fmt.Errorf("safety backup failed, restore not started: %w", err)
+				}
+				if safetyPath == "" {
+					// Without the path there is no way back from the restore.
+					return fmt.Errorf("the safety backup's path was not recorded, restore not started; "+
+						"find it with \"kubectl openclaw backup list %s\" and run the restore again without --safety-backup", name)
+				}
+				fmt.Printf("To undo the restore later:\n  kubectl openclaw restore %s %s\n\n", name, safetyPath)
+
+				// The operator may still report BackingUp for a moment, which
+				// TriggerRestore would refuse.
+				waitCtx, cancel := context.WithTimeout(ctx, backupRecordTimeout)
+				err = client.WaitUntilRestorable(waitCtx, ns, name)
+				cancel()
+				if err != nil {
+					return fmt.Errorf("restore not started: %w; the safety backup is at %s", err, safetyPath)
+				}
+			}
+
+			if err := client.TriggerRestore(ctx, ns, name, path); err != nil {
+				return err
 			}
 
 			fmt.Printf("Restore triggered for %s/%s from:\n  %s\n", ns, name, path)
+			if waited, err := waitIfRequested(cmd, client, ns, name); waited {
+				return err
+			}
 			fmt.Printf("\nMonitor progress with:\n  kubectl openclaw status %s\n", name)
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&into, "into", "", "create a new instance with this name from the backup instead of overwriting NAME")
+	cmd.Flags().BoolVar(&yes, "yes", false, "skip confirmation prompt")
+	cmd.Flags().BoolVar(&safetyBackup, "safety-backup", false, "back up the current data before restoring")
+	cmd.Flags().BoolVar(&skipVerify, "skip-verify", false, "do not check that PATH exists in the object store")
+	addBackupStoreFlags(cmd)
+	addWaitFlags(cmd)
+	return cmd
+}
+
+// verifyBackupPath fails unless path exists in the instance's backup store.
+func verifyBackupPath(ctx context.Context, cmd *cobra.Command, client *openclaw.Client, ns, name, path string) error {
+	store, err := client.BackupStore(ctx, ns, name, backupStoreOptions(cmd))
+	if err != nil {
+		return fmt.Errorf("cannot verify backup path (use --skip-verify to restore anyway): %w", err)
+	}
+	ok, err := store.Exists(ctx, path)
+	if err != nil {
+		return fmt.Errorf("cannot verify backup path (use --skip-verify to restore anyway): %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("backup %s not found at %s; list available backups with:\n  kubectl openclaw backup list %s", path, store.Client.Endpoint(), name)
+	}
+	return nil
+}
+
+// printRestorePreview shows what an in-place restore will overwrite.
+func printRestorePreview(ctx context.Context, client *openclaw.Client, inst *v1alpha1.OpenClawInstance, path string, safetyBackup bool) {
+	fmt.Printf("Instance:     %s/%s\n", inst.Namespace, inst.Name)
+	fmt.Printf("Phase:        %s\n", inst.Status.CurrentPhase())
+	fmt.Printf("Image:        %s\n", inst.Spec.ImageReference())
+	if t := inst.Status.LastBackupTime; t != nil {
+		fmt.Printf("Last Backup:  %s (%s ago)\n", formatTimestamp(*t), formatAge(t.Time))
+	} else {
+		fmt.Println("Last Backup:  (none)")
+	}
+
+	pvc, err := client.DataClaim(ctx, inst)
+	switch {
+	case err != nil:
+		fmt.Printf("Data Volume:  unknown (%v)\n", err)
+	case pvc == nil:
+		fmt.Println("Data Volume:  (none)")
+	default:
+		size := pvc.Status.Capacity.Storage()
+		if size.IsZero() {
+			size = pvc.Spec.Resources.Requests.Storage()
+		}
+		fmt.Printf("Data Volume:  PVC %s, %s\n", pvc.Name, size.String())
+	}
+
+	fmt.Printf("Restore From: %s\n", path)
+	if safetyBackup {
+		fmt.Println("Safety:       a backup of the current data is taken first")
+	} else {
+		fmt.Println("Safety:       none; current data is lost unless backed up (--safety-backup)")
+	}
+	fmt.Println()
 }
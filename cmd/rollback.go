@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newRollbackCmd() *cobra.Command {
+	var (
+		toRevision    int
+		restoreBackup bool
+		skipBackup    bool
+		yes           bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rollback NAME",
+		Short: "Roll an OpenClaw instance back to a previous image",
+		Long: `Restore the image an OpenClawInstance ran before its last upgrade, or an older
+one with --to-revision (see "upgrade history"). Like upgrade, it refuses while
+auto-update is active.
+
+With --restore-backup, the data is also restored from the backup taken just
+before that image was replaced, for upgrades that migrated data in a way the
+old version cannot read. This overwrites current data and asks for
+confirmation unless --yes is given. Only upgrades that took a backup (see
+"upgrade --skip-backup") can be rolled back this way.
+
+The rollback is recorded in the history like an upgrade, so it can itself be
+rolled back. As with upgrade, a backup of the current data is taken first
+if the instance has a backup schedule, unless --skip-backup is given.`,
+		Example: `  # Undo the last upgrade
+  claw rollback my-agent
+
+  # Go back to a specific revision
+  claw upgrade history my-agent
+  claw rollback my-agent --to-revision 3
+
+  # Also restore the data from before that upgrade, and wait for it
+  claw rollback my-agent --restore-backup --wait`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			opts := openclaw.RollbackOptions{
+				ToRevision:    toRevision,
+				RestoreBackup: restoreBackup,
+				Backup:        backupBeforeImageChange(client, skipBackup),
+			}
+
+			inst, err := client.GetInstance(context.TODO(), ns, name)
+			if err != nil {
+				return err
+			}
+			rev, err := openclaw.PlanRollback(inst, opts)
+			if err != nil {
+				return err
+			}
+
+			if restoreBackup && !yes {
+				fmt.Printf("This rolls %s/%s back to revision %d (%s) and restores its data from\n",
+					ns, name, rev.Revision, formatImageSpec(rev.Image))
+				fmt.Printf("  %s\n", rev.Backup)
+				fmt.Printf("taken %s ago, overwriting everything written since. Continue? [y/N]: ", formatAge(rev.ReplacedAt.Time))
+				reader := bufio.NewReader(os.Stdin)
+				answer, _ := reader.ReadString('\n')
+				answer = strings.TrimSpace(strings.ToLower(answer))
+				if answer != "y" && answer != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			result, err := client.Rollback(context.TODO(), ns, name, opts)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Rolling back %s/%s to revision %d:\n", ns, name, result.Revision.Revision)
+			fmt.Printf("  %s -> %s\n", formatImageSpec(result.Previous), formatImageSpec(result.Current))
+			if result.RestoreFrom != "" {
+				fmt.Printf("Restoring data from:\n  %s\n", result.RestoreFrom)
+			}
+
+			if waited, err := waitForRollout(cmd, client, ns, name); waited {
+				return err
+			}
+			fmt.Printf("\nMonitor progress with:\n  kubectl openclaw status %s\n", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&toRevision, "to-revision", 0, "revision to roll back to (default: the image before the current one)")
+	cmd.Flags().BoolVar(&restoreBackup, "restore-backup", false, "also restore the backup taken before that revision was replaced")
+	cmd.Flags().BoolVar(&skipBackup, "skip-backup", false, "do not back up the current data before changing the image")
+	cmd.Flags().BoolVar(&yes, "yes", false, "skip confirmation prompt")
+	addWaitFlags(cmd)
+
+	return cmd
+}
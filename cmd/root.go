@@ -23,10 +23,14 @@ Lifecycle:
   delete         Delete an instance
   restart        Restart an instance
   upgrade        Upgrade to a new version
+  rollback       Roll back to a previous image
+  versions       List available image versions
+  wait           Wait for a phase or condition
 
 Inspection:
   list           List all instances
   status         Detailed instance status
+  dashboard      Interactive terminal dashboard
   logs           Stream pod logs
   events         Show related Kubernetes events
   config         View or edit the configuration
@@ -41,9 +45,11 @@ Configuration:
   env            Manage environment variables
   enable         Enable a sidecar (chromium, tailscale, ollama, web-terminal)
   disable        Disable a sidecar
+  autoupdate     Manage auto-update
+  selfconfig     Review changes agents request to themselves
 
 Operations:
-  backup         View backup status
+  backup         View backup status, list backups, or take one now
   restore        Restore from a backup
   doctor         Run diagnostic checks`,
 		SilenceUsage: true,
@@ -57,10 +63,14 @@ Operations:
 	cmd.AddCommand(newDeleteCmd())
 	cmd.AddCommand(newRestartCmd())
 	cmd.AddCommand(newUpgradeCmd())
+	cmd.AddCommand(newRollbackCmd())
+	cmd.AddCommand(newVersionsCmd())
+	cmd.AddCommand(newWaitCmd())
 
 	// Inspection
 	cmd.AddCommand(newListCmd())
 	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newDashboardCmd())
 	cmd.AddCommand(newLogsCmd())
 	cmd.AddCommand(newEventsCmd())
 	cmd.AddCommand(newConfigCmd())
@@ -75,6 +85,8 @@ Operations:
 	cmd.AddCommand(newEnvCmd())
 	cmd.AddCommand(newEnableCmd())
 	cmd.AddCommand(newDisableCmd())
+	cmd.AddCommand(newAutoUpdateCmd())
+	cmd.AddCommand(newSelfConfigCmd())
 
 	// Operations
 	cmd.AddCommand(newBackupCmd())
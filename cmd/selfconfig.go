@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newSelfConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "selfconfig",
+		Aliases: []string{"selfconfigs", "sc"},
+		Short:   "Review the changes agents request to their own instances",
+		Long: `An agent created with --self-configure asks to change its own skills, config,
+workspace files and environment variables by filing OpenClawSelfConfig
+requests. List them, review each one as a diff against the instance, and
+approve or reject it. "selfconfig policy" controls what an agent may ask
+for.
+
+Approving makes the requested changes to the instance; rejecting leaves it
+alone. Either way the decision, who made it and when is recorded on the
+request.`,
+		Example: `  # Requests made by one agent
+  claw selfconfig list my-agent
+
+  # Review one
+  claw selfconfig show my-agent-add-web-search
+
+  # Decide
+  claw selfconfig approve my-agent-add-web-search
+  claw selfconfig reject my-agent-raise-budget --reason "needs sign-off from finance"
+
+  # Follow new requests as they come in
+  claw selfconfig watch my-agent
+
+  # Narrow down what the agent may change
+  claw selfconfig policy my-agent --allow skills,config --deny envVars`,
+	}
+
+	cmd.AddCommand(newSelfConfigListCmd())
+	cmd.AddCommand(newSelfConfigShowCmd())
+	cmd.AddCommand(newSelfConfigApproveCmd())
+	cmd.AddCommand(newSelfConfigRejectCmd())
+	cmd.AddCommand(newSelfConfigWatchCmd())
+	cmd.AddCommand(newSelfConfigPolicyCmd())
+
+	return cmd
+}
+
+func newSelfConfigListCmd() *cobra.Command {
+	var (
+		allNamespaces bool
+		pendingOnly   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list [INSTANCE]",
+		Aliases: []string{"ls"},
+		Short:   "List self-config requests",
+		Long: `List the self-config requests in the namespace, oldest first, or only those
+for INSTANCE: what each one changes, its phase and who approved or rejected
+it.`,
+		Example: `  # Requests awaiting a decision, in every namespace
+  claw selfconfig list -A --pending
+
+  # As JSON
+  claw selfconfig list my-agent -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+			instance := ""
+			if len(args) > 0 {
+				instance = args[0]
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				ns = ""
+			}
+
+			items, err := client.ListSelfConfigs(context.TODO(), ns, instance)
+			if err != nil {
+				return err
+			}
+
+			listing := &selfConfigListing{Items: []selfConfigEntry{}}
+			for i := range items {
+				if pendingOnly && !openclaw.SelfConfigPending(&items[i]) {
+					continue
+				}
+				listing.Items = append(listing.Items, newSelfConfigEntry(&items[i]))
+			}
+
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, listing)
+			}
+			if len(listing.Items) == 0 {
+				fmt.Println("No self-config requests found.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			printSelfConfigHeader(w, allNamespaces, output == outputWide)
+			for _, e := range listing.Items {
+				printSelfConfigRow(w, e, allNamespaces, output == outputWide)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "list requests across all namespaces")
+	cmd.Flags().BoolVar(&pendingOnly, "pending", false, "only requests awaiting a decision")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func newSelfConfigShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show NAME",
+		Short: "Show a self-config request as a diff against its instance",
+		Long: `Show a self-config request: which instance it is for, what it changes and
+whether it has been decided. The changes are shown as a diff against the
+instance's current spec, so parts of the request that would change nothing
+are left out, followed by anything the instance's self-configure policy does
+not allow.`,
+		Example: `  claw selfconfig show my-agent-add-web-search`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			sc, err := client.GetSelfConfig(context.TODO(), ns, args[0])
+			if err != nil {
+				return err
+			}
+			inst, err := client.GetInstance(context.TODO(), ns, sc.Spec.InstanceRef)
+			if err != nil {
+				return err
+			}
+			changes, err := openclaw.SelfConfigChanges(inst, sc)
+			if err != nil {
+				return err
+			}
+
+			view := &selfConfigView{
+				selfConfigEntry: newSelfConfigEntry(sc),
+				Changes:         changes,
+				Violations:      openclaw.SelfConfigPolicyViolations(&inst.Spec.SelfConfigure, sc),
+			}
+			if view.Changes == nil {
+				view.Changes = []openclaw.ConfigChange{}
+			}
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, view)
+			}
+			printSelfConfig(os.Stdout, view, term.IsTerminal(int(os.Stdout.Fd())))
+			return nil
+		},
+	}
+
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// selfConfigListing is the data model behind "selfconfig list".
+type selfConfigListing struct {
+	Items []selfConfigEntry `json:"items"`
+}
+
+type selfConfigEntry struct {
+	Namespace string                       `json:"namespace"`
+	Name      string                       `json:"name"`
+	Instance  string                       `json:"instance"`
+	Actions   []string                     `json:"actions"`
+	Phase     string                       `json:"phase"`
+	Message   string                       `json:"message,omitempty"`
+	Decision  *openclaw.SelfConfigDecision `json:"decision,omitempty"`
+	Created   time.Time                    `json:"created"`
+}
+
+// selfConfigView is the data model behind "selfconfig show".
+type selfConfigView struct {
+	selfConfigEntry
+	Changes []openclaw.ConfigChange `json:"changes"`
+	// Violations are the parts of the request the instance's self-configure
+	// policy does not allow.
+	Violations []string `json:"violations,omitempty"`
+}
+
+func newSelfConfigEntry(sc *v1alpha1.OpenClawSelfConfig) selfConfigEntry {
+	actions := sc.Spec.Actions()
+	if actions == nil {
+		actions = []string{}
+	}
+	e := selfConfigEntry{
+		Namespace: sc.Namespace,
+		Name:      sc.Name,
+		Instance:  sc.Spec.InstanceRef,
+		Actions:   actions,
+		Phase:     openclaw.SelfConfigPhase(sc),
+		Message:   sc.Status.Message,
+		Decision:  openclaw.DecisionOf(sc),
+		Created:   sc.CreationTimestamp.Time,
+	}
+	if e.Message == "" && e.Decision != nil {
+		e.Message = e.Decision.Error
+	}
+	return e
+}
+
+func (l *selfConfigListing) resourceNames() []string {
+	names := make([]string, len(l.Items))
+	for i, e := range l.Items {
+		names[i] = selfConfigResourceID + "/" + e.Name
+	}
+	return names
+}
+
+func (v *selfConfigView) resourceNames() []string {
+	return []string{selfConfigResourceID + "/" + v.Name}
+}
+
+func printSelfConfigHeader(w io.Writer, allNamespaces, wide bool) {
+	header := "NAME\tINSTANCE\tACTIONS\tPHASE\tDECIDED BY\tAGE"
+	if allNamespaces {
+		header = "NAMESPACE\t" + header
+	}
+	if wide {
+		header += "\tMESSAGE"
+	}
+	fmt.Fprintln(w, header)
+}
+
+func printSelfConfigRow(w io.Writer, e selfConfigEntry, allNamespaces, wide bool) {
+	decidedBy := ""
+	if e.Decision != nil {
+		decidedBy = e.Decision.DecidedBy
+	}
+	row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", e.Name, e.Instance,
+		valueOrNone(strings.Join(e.Actions, ",")), e.Phase, valueOrNone(decidedBy), formatAge(e.Created))
+	if allNamespaces {
+		row = e.Namespace + "\t" + row
+	}
+	if wide {
+		row += "\t" + valueOrNone(e.Message)
+	}
+	fmt.Fprintln(w, row)
+}
+
+func printSelfConfig(w io.Writer, v *selfConfigView, color bool) {
+	fmt.Fprintf(w, "Name:       %s\n", v.Name)
+	fmt.Fprintf(w, "Namespace:  %s\n", v.Namespace)
+	fmt.Fprintf(w, "Instance:   %s\n", v.Instance)
+	fmt.Fprintf(w, "Requested:  %s (%s ago)\n", v.Created.UTC().Format(time.RFC3339), formatAge(v.Created))
+	fmt.Fprintf(w, "Actions:    %s\n", valueOrNone(strings.Join(v.Actions, ", ")))
+	fmt.Fprintf(w, "Phase:      %s\n", v.Phase)
+	if v.Message != "" {
+		fmt.Fprintf(w, "Message:    %s\n", v.Message)
+	}
+	if d := v.Decision; d != nil {
+		fmt.Fprintf(w, "Decision:   %s by %s at %s\n", d.Decision, d.DecidedBy, d.DecidedAt.Format(time.RFC3339))
+		if d.Reason != "" {
+			fmt.Fprintf(w, "Reason:     %s\n", d.Reason)
+		}
+	}
+	fmt.Fprintln(w)
+
+	if len(v.Changes) == 0 {
+		fmt.Fprintf(w, "No changes: %s already matches this request.\n", v.Instance)
+	} else {
+		fmt.Fprintf(w, "Changes to %s:\n", v.Instance)
+		printConfigDiff(w, v.Changes, color)
+	}
+	printPolicyViolations(w, v.Violations)
+}
+
+// printPolicyViolations lists what a request asks for beyond its instance's
+// self-configure policy.
+func printPolicyViolations(w io.Writer, violations []string) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nOutside the self-configure policy:")
+	for _, v := range violations {
+		fmt.Fprintf(w, "  - %s\n", v)
+	}
+}
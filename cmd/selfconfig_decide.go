@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newSelfConfigApproveCmd() *cobra.Command {
+	var (
+		opts openclaw.ApproveSelfConfigOptions
+		yes  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "approve NAME",
+		Short: "Approve a self-config request and apply it",
+		Long: `Show the changes a pending self-config request makes to its instance and,
+once confirmed, apply them. The approval, your Kubernetes user name and the
+time are recorded in annotations on the request, which is then listed as
+Applied. Its status is left to the operator.
+
+What a request asks for beyond the instance's self-configure policy is
+always listed, also with --yes. Such requests are refused unless
+--ignore-policy is given.
+
+Only pending requests can be approved, and approved requests that could not
+be applied to the instance, which are listed as Failed: approving one of
+them again retries it. If someone else decides the request at the same
+time, only one of you succeeds.`,
+		Example: `  claw selfconfig approve my-agent-add-web-search
+
+  # Without asking, and wait for the rollout
+  claw selfconfig approve my-agent-add-web-search --yes --wait
+
+  # Approve a request the policy does not cover
+  claw selfconfig approve my-agent-raise-budget --ignore-policy --reason "one-off, see ticket"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx := context.TODO()
+
+			sc, err := client.GetSelfConfig(ctx, ns, name)
+			if err != nil {
+				return err
+			}
+			instance := sc.Spec.InstanceRef
+			inst, err := client.GetInstance(ctx, ns, instance)
+			if err != nil {
+				return err
+			}
+
+			violations := openclaw.SelfConfigPolicyViolations(&inst.Spec.SelfConfigure, sc)
+			if !yes {
+				changes, err := openclaw.SelfConfigChanges(inst, sc)
+				if err != nil {
+					return err
+				}
+				if len(changes) == 0 {
+					fmt.Printf("No changes: %s already matches this request.\n", instance)
+				} else {
+					printConfigDiff(os.Stdout, changes, term.IsTerminal(int(os.Stdout.Fd())))
+				}
+			}
+			printPolicyViolations(os.Stdout, violations)
+			if len(violations) > 0 && !opts.IgnorePolicy {
+				return fmt.Errorf("%s asks for more than the self-configure policy of %s/%s allows; approve it anyway with --ignore-policy", name, ns, instance)
+			}
+
+			if !yes {
+				fmt.Printf("\nApprove %s for %s/%s? [y/N]: ", name, ns, instance)
+				reader := bufio.NewReader(os.Stdin)
+				answer, _ := reader.ReadString('\n')
+				answer = strings.TrimSpace(strings.ToLower(answer))
+				if answer != "y" && answer != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			decision, err := client.ApproveSelfConfig(ctx, ns, name, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Self-config request %s approved by %s and applied to %s/%s.\n", name, decision.DecidedBy, ns, instance)
+			_, err = waitIfRequested(cmd, client, ns, instance)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Reason, "reason", "", "why the request was approved, kept with the decision")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "approve without showing the changes and asking")
+	cmd.Flags().BoolVar(&opts.IgnorePolicy, "ignore-policy", false, "approve even what the self-configure policy does not allow")
+	addWaitFlags(cmd)
+	return cmd
+}
+
+func newSelfConfigRejectCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "reject NAME",
+		Short: "Reject a self-config request",
+		Long: `Reject a pending self-config request. The instance is not changed; the
+rejection, your Kubernetes user name, the time and --reason are recorded in
+annotations on the request, which is then listed as Denied. Its status is
+left to the operator.`,
+		Example: `  claw selfconfig reject my-agent-raise-budget --reason "needs sign-off from finance"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			decision, err := client.RejectSelfConfig(context.TODO(), ns, name, reason)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Self-config request %s rejected by %s.\n", name, decision.DecidedBy)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "why the request was rejected, kept with the decision")
+	return cmd
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newSelfConfigPolicyCmd() *cobra.Command {
+	var (
+		enable, disable bool
+		opts            openclaw.SelfConfigPolicyOptions
+		recent          int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "policy NAME",
+		Short: "Show or change what an agent may change about itself",
+		Long: `Show an instance's self-configure policy together with its recent self-config
+requests, and whether each one stays within the policy; or change the policy.
+
+--allow and --deny add and remove actions: skills, config, workspaceFiles and
+envVars. Allowing an action turns self-configuration on. The operator allows
+every action when none are listed, so denying starts from all of them, and
+on a disabled policy only prepares what is allowed once it is turned on.
+
+The skills, config and envVars actions can be narrowed further. Each
+--allow-*/--deny-* flag replaces that list (pass "" to clear it). Deny wins
+over allow, and an empty allow list allows everything not denied.
+
+  Skills        names, with * and ? as wildcards, e.g. "web-*"
+  Config paths  dotted paths, covering everything below them, with * for
+                any one key, e.g. "agents.defaults" or "channels.*.enabled"
+  Env vars      names, with * and ? as wildcards, e.g. "FEATURE_*"
+
+The policy is validated before it is written. If the cluster's CRD does not
+have the rules, the command fails without changing the allowed actions.`,
+		Example: `  # Show the policy and recent requests
+  claw selfconfig policy my-agent
+
+  # Let the agent manage skills and config, but not its environment
+  claw selfconfig policy my-agent --allow skills,config --deny envVars
+
+  # Only skills from the public web-* family, never the gateway settings
+  claw selfconfig policy my-agent --allow-skills 'web-*' --deny-config gateway
+
+  # Turn self-configuration off
+  claw selfconfig policy my-agent --disable`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+			if enable && disable {
+				return fmt.Errorf("--enable and --disable cannot be used together")
+			}
+			if enable || disable {
+				opts.Enabled = &enable
+			}
+			for flag, list := range map[string]*[]string{
+				"allow-skills": &opts.Skills.Allow, "deny-skills": &opts.Skills.Deny,
+				"allow-config": &opts.ConfigPaths.Allow, "deny-config": &opts.ConfigPaths.Deny,
+				"allow-env": &opts.EnvVars.Allow, "deny-env": &opts.EnvVars.Deny,
+			} {
+				// A list set to "" clears the patterns, which needs to be told
+				// apart from a flag that was not given.
+				if cmd.Flags().Changed(flag) && *list == nil {
+					*list = []string{}
+				}
+			}
+			changing := opts.Enabled != nil || len(opts.Allow) > 0 || len(opts.Deny) > 0 ||
+				opts.Skills.Allow != nil || opts.Skills.Deny != nil ||
+				opts.ConfigPaths.Allow != nil || opts.ConfigPaths.Deny != nil ||
+				opts.EnvVars.Allow != nil || opts.EnvVars.Deny != nil
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx := context.TODO()
+
+			if changing {
+				_, warnings, err := client.SetSelfConfigPolicy(ctx, ns, name, opts)
+				if err != nil {
+					return err
+				}
+				for _, w := range warnings {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+				}
+				if !isStructuredOutput(output) {
+					fmt.Printf("Self-configure policy updated for %s/%s.\n\n", ns, name)
+				}
+			}
+
+			inst, err := client.GetInstance(ctx, ns, name)
+			if err != nil {
+				return err
+			}
+			requests, err := client.ListSelfConfigs(ctx, ns, name)
+			if err != nil {
+				return err
+			}
+
+			view := &selfConfigPolicyView{
+				Namespace:        ns,
+				Name:             name,
+				Policy:           inst.Spec.SelfConfigure,
+				EffectiveActions: openclaw.EffectiveSelfConfigureActions(&inst.Spec.SelfConfigure),
+				Recent:           []selfConfigActivity{},
+			}
+			if view.EffectiveActions == nil {
+				view.EffectiveActions = []string{}
+			}
+			// Newest first.
+			for i := len(requests) - 1; i >= 0 && len(view.Recent) < recent; i-- {
+				view.Recent = append(view.Recent, selfConfigActivity{
+					selfConfigEntry: newSelfConfigEntry(&requests[i]),
+					Violations:      openclaw.SelfConfigPolicyViolations(&inst.Spec.SelfConfigure, &requests[i]),
+				})
+			}
+
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, view)
+			}
+			printSelfConfigPolicy(os.Stdout, view)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&enable, "enable", false, "turn self-configuration on")
+	cmd.Flags().BoolVar(&disable, "disable", false, "turn self-configuration off")
+	cmd.Flags().StringSliceVar(&opts.Allow, "allow", nil, "actions to allow: skills, config, workspaceFiles, envVars")
+	cmd.Flags().StringSliceVar(&opts.Deny, "deny", nil, "actions to deny")
+	cmd.Flags().StringSliceVar(&opts.Skills.Allow, "allow-skills", nil, "skill patterns the agent may add or remove")
+	cmd.Flags().StringSliceVar(&opts.Skills.Deny, "deny-skills", nil, "skill patterns the agent may not add or remove")
+	cmd.Flags().StringSliceVar(&opts.ConfigPaths.Allow, "allow-config", nil, "config paths the agent may change")
+	cmd.Flags().StringSliceVar(&opts.ConfigPaths.Deny, "deny-config", nil, "config paths the agent may not change")
+	cmd.Flags().StringSliceVar(&opts.EnvVars.Allow, "allow-env", nil, "env var name patterns the agent may set or remove")
+	cmd.Flags().StringSliceVar(&opts.EnvVars.Deny, "deny-env", nil, "env var name patterns the agent may not set or remove")
+	cmd.Flags().IntVar(&recent, "recent", 10, "number of recent self-config requests to show")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// selfConfigPolicyView is the data model behind "selfconfig policy".
+type selfConfigPolicyView struct {
+	Namespace        string                     `json:"namespace"`
+	Name             string                     `json:"name"`
+	Policy           v1alpha1.SelfConfigureSpec `json:"policy"`
+	EffectiveActions []string                   `json:"effectiveActions"`
+	Recent           []selfConfigActivity       `json:"recent"`
+}
+
+type selfConfigActivity struct {
+	selfConfigEntry
+	Violations []string `json:"violations,omitempty"`
+}
+
+func (v *selfConfigPolicyView) resourceNames() []string {
+	return []string{instanceResourceName(v.Name)}
+}
+
+func printSelfConfigPolicy(w io.Writer, v *selfConfigPolicyView) {
+	p := &v.Policy
+	if !p.Enabled {
+		fmt.Fprintln(w, "Self-Configure:   disabled")
+	} else {
+		fmt.Fprintln(w, "Self-Configure:   enabled")
+		var denied []string
+		for _, a := range openclaw.AllSelfConfigureActions {
+			if !containsAction(v.EffectiveActions, a) {
+				denied = append(denied, a)
+			}
+		}
+		fmt.Fprintf(w, "Allowed Actions:  %s\n", strings.Join(v.EffectiveActions, ", "))
+		if len(denied) > 0 {
+			fmt.Fprintf(w, "Denied Actions:   %s\n", strings.Join(denied, ", "))
+		}
+		fmt.Fprintf(w, "Skills:           %s\n", formatSelfConfigRules(p.Skills))
+		fmt.Fprintf(w, "Config Paths:     %s\n", formatSelfConfigRules(p.ConfigPaths))
+		fmt.Fprintf(w, "Env Vars:         %s\n", formatSelfConfigRules(p.EnvVars))
+	}
+	fmt.Fprintln(w)
+
+	if len(v.Recent) == 0 {
+		fmt.Fprintln(w, "No self-config requests.")
+		return
+	}
+	fmt.Fprintln(w, "Recent Requests:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  NAME\tACTIONS\tPHASE\tDECIDED BY\tAGE\tPOLICY")
+	for _, a := range v.Recent {
+		decidedBy := ""
+		if a.Decision != nil {
+			decidedBy = a.Decision.DecidedBy
+		}
+		policy := "ok"
+		switch len(a.Violations) {
+		case 0:
+		case 1:
+			policy = a.Violations[0]
+		default:
+			policy = fmt.Sprintf("%s (+%d more)", a.Violations[0], len(a.Violations)-1)
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\n", a.Name, valueOrNone(strings.Join(a.Actions, ",")),
+			a.Phase, valueOrNone(decidedBy), formatAge(a.Created), policy)
+	}
+	tw.Flush()
+}
+
+// formatSelfConfigRules renders allow and deny patterns on one line.
+func formatSelfConfigRules(r *v1alpha1.SelfConfigureRules) string {
+	if r == nil || (len(r.Allow) == 0 && len(r.Deny) == 0) {
+		return "any"
+	}
+	var parts []string
+	if len(r.Allow) > 0 {
+		parts = append(parts, "allow "+strings.Join(r.Allow, ", "))
+	}
+	if len(r.Deny) > 0 {
+		parts = append(parts, "deny "+strings.Join(r.Deny, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func containsAction(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func newSelfConfigWatchCmd() *cobra.Command {
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:   "watch [INSTANCE]",
+		Short: "Follow self-config requests as they are made and decided",
+		Long: `List the self-config requests, like "selfconfig list", then print a new line
+each time one is filed, changes phase or is deleted, until interrupted.
+Pending requests are highlighted on a terminal.`,
+		Example: `  claw selfconfig watch my-agent
+
+  # Every agent in the cluster
+  claw selfconfig watch -A`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instance := ""
+			if len(args) > 0 {
+				instance = args[0]
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				ns = ""
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			events, err := client.WatchSelfConfigs(ctx, ns, instance)
+			if err != nil {
+				return err
+			}
+
+			tty := term.IsTerminal(int(os.Stdout.Fd()))
+			// Each row is flushed as it arrives, so a minimum column width
+			// keeps most rows aligned with the header.
+			w := tabwriter.NewWriter(os.Stdout, 16, 0, 2, ' ', 0)
+			printSelfConfigHeader(w, allNamespaces, false)
+			w.Flush()
+			for e := range events {
+				entry := newSelfConfigEntry(e.SelfConfig)
+				if e.Type == watch.Deleted {
+					entry.Phase = "Deleted"
+				}
+				if tty && e.Type != watch.Deleted && openclaw.SelfConfigPending(e.SelfConfig) {
+					os.Stdout.WriteString(ansiYellow)
+					printSelfConfigRow(w, entry, allNamespaces, false)
+					w.Flush()
+					os.Stdout.WriteString(ansiReset)
+				} else {
+					printSelfConfigRow(w, entry, allNamespaces, false)
+					w.Flush()
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "watch requests across all namespaces")
+	return cmd
+}
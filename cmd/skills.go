@@ -2,13 +2,10 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newSkillsCmd() *cobra.Command {
@@ -29,11 +26,16 @@ Without a subcommand, lists the currently installed skills.`,
   claw skills add my-agent npm:@anthropic/tool-use
 
   # Remove a skill
-  claw skills remove my-agent web-search`,
+  claw skills remove my-agent web-search
+
+  # Skill names only
+  claw skills my-agent -o jsonpath='{.skills[*]}'`,
 		Args: cobra.ExactArgs(1),
 		RunE: skillsListRunE,
 	}
 
+	addOutputFlag(cmd)
+
 	cmd.AddCommand(newSkillsAddCmd())
 	cmd.AddCommand(newSkillsRemoveCmd())
 
@@ -43,53 +45,64 @@ Without a subcommand, lists the currently installed skills.`,
 func skillsListRunE(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	clients, err := kube.NewClients(kubeconfig)
-	if err != nil {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output); err != nil {
 		return err
 	}
 
-	ns := namespace
-	if ns == "" {
-		ns, err = resolveNamespace()
-		if err != nil {
-			return err
-		}
+	client, ns, err := newClient()
+	if err != nil {
+		return err
 	}
 
-	obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-		context.TODO(), name, metav1.GetOptions{},
-	)
+	inst, err := client.GetInstance(context.TODO(), ns, name)
 	if err != nil {
-		return fmt.Errorf("instance %q not found: %w", name, err)
+		return err
+	}
+	listing := &skillsListing{
+		Namespace:       ns,
+		Name:            name,
+		Skills:          append([]string{}, inst.Spec.Skills...),
+		SkillPacksReady: inst.Status.ConditionStatus("SkillPacksReady"),
+	}
+
+	if isStructuredOutput(output) {
+		return printStructured(os.Stdout, output, listing)
 	}
 
-	spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-	skills, ok := getNestedSlice(spec, "skills")
-	if !ok || len(skills) == 0 {
+	if len(listing.Skills) == 0 {
 		fmt.Printf("No skills installed on %q.\n", name)
 		fmt.Printf("\nAdd skills with:\n  kubectl openclaw skills add %s <skill-name>\n", name)
 		return nil
 	}
 
 	fmt.Printf("Skills for %s/%s:\n", ns, name)
-	for _, s := range skills {
-		if str, ok := s.(string); ok {
-			fmt.Printf("  - %s\n", str)
-		}
+	for _, s := range listing.Skills {
+		fmt.Printf("  - %s\n", s)
 	}
 
 	// Show condition
-	status, _, _ := unstructuredNestedMap(obj.Object, "status")
-	condStatus := getConditionStatus(status, "SkillPacksReady")
-	if condStatus == "False" {
+	if listing.SkillPacksReady == "False" {
 		fmt.Printf("\nWarning: SkillPacksReady condition is False — some skills may not be resolved.\n")
 	}
 
 	return nil
 }
 
+// skillsListing is the data model behind "skills".
+type skillsListing struct {
+	Namespace       string   `json:"namespace"`
+	Name            string   `json:"name"`
+	Skills          []string `json:"skills"`
+	SkillPacksReady string   `json:"skillPacksReady"`
+}
+
+func (l *skillsListing) resourceNames() []string {
+	return []string{instanceResourceName(l.Name)}
+}
+
 func newSkillsAddCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "add NAME SKILL [SKILL...]",
 		Short: "Add skills to an OpenClaw instance",
 		Args:  cobra.MinimumNArgs(2),
@@ -97,47 +110,14 @@ func newSkillsAddCmd() *cobra.Command {
 			name := args[0]
 			newSkills := args[1:]
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			added, err := client.AddSkills(context.TODO(), ns, name, newSkills...)
 			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			existing, _ := getNestedSlice(spec, "skills")
-
-			// Dedup: build set of existing skills
-			seen := make(map[string]bool)
-			for _, s := range existing {
-				if str, ok := s.(string); ok {
-					seen[str] = true
-				}
-			}
-
-			merged := make([]interface{}, len(existing))
-			copy(merged, existing)
-
-			added := 0
-			for _, s := range newSkills {
-				if !seen[s] {
-					merged = append(merged, s)
-					seen[s] = true
-					added++
-				}
+				return err
 			}
 
 			if added == 0 {
@@ -145,72 +125,32 @@ func newSkillsAddCmd() *cobra.Command {
 				return nil
 			}
 
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"skills": merged,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to update skills: %w", err)
-			}
-
 			fmt.Printf("Added %d skill(s) to %s/%s.\n", added, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
 
 func newSkillsRemoveCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "remove NAME SKILL [SKILL...]",
 		Short: "Remove skills from an OpenClaw instance",
 		Args:  cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
-			toRemove := make(map[string]bool)
-			for _, s := range args[1:] {
-				toRemove[s] = true
-			}
 
-			clients, err := kube.NewClients(kubeconfig)
+			client, ns, err := newClient()
 			if err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
-			}
-
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			removed, err := client.RemoveSkills(context.TODO(), ns, name, args[1:]...)
 			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
-
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			existing, _ := getNestedSlice(spec, "skills")
-
-			var filtered []interface{}
-			removed := 0
-			for _, s := range existing {
-				if str, ok := s.(string); ok && toRemove[str] {
-					removed++
-				} else {
-					filtered = append(filtered, s)
-				}
+				return err
 			}
 
 			if removed == 0 {
@@ -218,30 +158,12 @@ func newSkillsRemoveCmd() *cobra.Command {
 				return nil
 			}
 
-			// Use empty slice instead of nil to clear all skills
-			if filtered == nil {
-				filtered = []interface{}{}
-			}
-
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"skills": filtered,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to update skills: %w", err)
-			}
-
 			fmt.Printf("Removed %d skill(s) from %s/%s.\n", removed, ns, name)
-			return nil
+			_, err = waitIfRequested(cmd, client, ns, name)
+			return err
 		},
 	}
+
+	addWaitFlags(cmd)
+	return cmd
 }
@@ -3,91 +3,190 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func newStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status NAME",
 		Short: "Show detailed status of an OpenClaw instance",
 		Long: `Display comprehensive status of an OpenClawInstance including phase,
 endpoints, sidecars, conditions, managed resources, backup/restore state,
-auto-update status, and pod health.`,
+auto-update status, and pod health.
+
+With --watch, the screen is redrawn whenever the instance, its pods or their
+events change, and what changed since the previous update is highlighted.`,
 		Example: `  kubectl openclaw status my-agent
-  kubectl openclaw status my-agent -n production`,
+  kubectl openclaw status my-agent -n production
+
+  # Instance, conditions, managed resources and pod states as JSON
+  kubectl openclaw status my-agent -o json
+
+  # Just the phase
+  kubectl openclaw status my-agent -o jsonpath='{.instance.status.phase}'
+
+  # Follow an upgrade or restore live
+  kubectl openclaw status my-agent -w`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			clients, err := kube.NewClients(kubeconfig)
-			if err != nil {
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
 				return err
 			}
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
-				if err != nil {
-					return err
-				}
+			watch, _ := cmd.Flags().GetBool("watch")
+			if watch && isStructuredOutput(output) {
+				return fmt.Errorf("--watch cannot be combined with -o %s", output)
 			}
 
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
+			client, ns, err := newClient()
 			if err != nil {
-				return fmt.Errorf("failed to get OpenClawInstance %q: %w", name, err)
+				return err
 			}
 
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			status, _, _ := unstructuredNestedMap(obj.Object, "status")
-
-			phase := getNestedString(status, "phase")
-			if phase == "" {
-				phase = "Pending"
+			if watch {
+				return watchStatus(client, ns, name)
 			}
 
-			// Header
-			fmt.Printf("OpenClawInstance: %s/%s\n", ns, name)
-			fmt.Printf("Phase:           %s\n", phaseWithIndicator(phase))
-			fmt.Printf("Age:             %s\n", formatAge(obj.GetCreationTimestamp().Time))
-
-			gen := obj.GetGeneration()
-			observedGen, ok := getNestedInt64(status, "observedGeneration")
-			if ok {
-				if gen == observedGen {
-					fmt.Printf("Generation:      %d (up to date)\n", gen)
-				} else {
-					fmt.Printf("Generation:      %d (observed: %d, reconciling...)\n", gen, observedGen)
-				}
+			st, err := collectInstanceStatus(client, ns, name)
+			if err != nil {
+				return err
 			}
-			fmt.Println()
-
-			printImageInfo(spec)
-			printEndpoints(status)
-			printSidecars(spec)
-			printSkills(spec)
-			printRuntimeDeps(spec)
-			printResources(spec)
-			printStorage(spec)
-			printNetworking(spec)
-			printBackupSummary(spec, status)
-			printAutoUpdate(spec, status)
-			printSelfConfigure(spec)
-			printObservability(spec)
-			printConditions(status)
-			printManagedResources(status)
-			printPodStatus(clients, ns, name)
 
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, st)
+			}
+			printInstanceStatus(os.Stdout, st)
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolP("watch", "w", false, "keep watching and redraw on every instance, pod or event change")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// instanceStatus is the data model behind "status". The text printers and the
+// structured (-o) printers both render from it.
+type instanceStatus struct {
+	Instance         *unstructured.Unstructured `json:"instance"`
+	Conditions       []conditionView            `json:"conditions"`
+	ManagedResources []managedResourceView      `json:"managedResources"`
+	Pods             []podView                  `json:"pods"`
+	PodError         string                     `json:"podError,omitempty"`
+
+	typed *v1alpha1.OpenClawInstance
+}
+
+type conditionView struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type managedResourceView struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type podView struct {
+	Name       string          `json:"name"`
+	Phase      string          `json:"phase"`
+	Restarts   int32           `json:"restarts"`
+	Created    metav1.Time     `json:"created"`
+	Containers []containerView `json:"containers"`
+}
+
+type containerView struct {
+	Name     string `json:"name"`
+	Init     bool   `json:"init,omitempty"`
+	Ready    bool   `json:"ready"`
+	State    string `json:"state"`
+	Restarts int32  `json:"restarts"`
+}
+
+func (s *instanceStatus) resourceNames() []string {
+	return []string{instanceResourceName(s.Instance.GetName())}
+}
+
+func collectInstanceStatus(client *openclaw.Client, ns, name string) (*instanceStatus, error) {
+	obj, err := client.GetInstanceObject(context.TODO(), ns, name)
+	if err != nil {
+		return nil, err
+	}
+	pods, podErr := client.Pods(context.TODO(), ns, name)
+	return newInstanceStatus(obj, pods, podErr)
+}
+
+// newInstanceStatus builds the status view from already fetched objects, so
+// that cache-backed callers (the dashboard) can render it too.
+func newInstanceStatus(obj *unstructured.Unstructured, pods []corev1.Pod, podErr error) (*instanceStatus, error) {
+	inst, err := v1alpha1.FromUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &instanceStatus{
+		Instance:         obj,
+		Conditions:       conditionViews(inst.Status.Conditions),
+		ManagedResources: managedResourceViews(inst.Status.ManagedResources),
+		typed:            inst,
+	}
+	if podErr != nil {
+		st.PodError = podErr.Error()
+		return st, nil
+	}
+	st.Pods = podViews(pods)
+	return st, nil
+}
+
+func printInstanceStatus(w io.Writer, st *instanceStatus) {
+	inst := st.typed
+	spec := &inst.Spec
+	status := &inst.Status
+
+	// Header
+	fmt.Fprintf(w, "OpenClawInstance: %s/%s\n", inst.Namespace, inst.Name)
+	fmt.Fprintf(w, "Phase:           %s\n", phaseWithIndicator(status.CurrentPhase()))
+	fmt.Fprintf(w, "Age:             %s\n", formatAge(inst.CreationTimestamp.Time))
+
+	if status.ObservedGeneration != 0 {
+		if inst.Generation == status.ObservedGeneration {
+			fmt.Fprintf(w, "Generation:      %d (up to date)\n", inst.Generation)
+		} else {
+			fmt.Fprintf(w, "Generation:      %d (observed: %d, reconciling...)\n", inst.Generation, status.ObservedGeneration)
+		}
+	}
+	fmt.Fprintln(w)
+
+	printImageInfo(w, spec)
+	printEndpoints(w, status)
+	printSidecars(w, spec)
+	printSkills(w, spec)
+	printRuntimeDeps(w, spec)
+	printResources(w, spec)
+	printStorage(w, spec)
+	printNetworking(w, spec)
+	printBackupSummary(w, spec, status)
+	printAutoUpdate(w, spec, status)
+	printSelfConfigure(w, spec)
+	printObservability(w, spec)
+	printConditions(w, st.Conditions)
+	printManagedResources(w, st.ManagedResources)
+	printPodStatus(w, st)
 }
 
 func phaseWithIndicator(phase string) string {
@@ -113,499 +212,468 @@ func phaseWithIndicator(phase string) string {
 	}
 }
 
-func printImageInfo(spec map[string]interface{}) {
-	image := getNestedString(spec, "image", "repository")
-	tag := getNestedString(spec, "image", "tag")
-	digest := getNestedString(spec, "image", "digest")
-	pullPolicy := getNestedString(spec, "image", "pullPolicy")
-	registry := getNestedString(spec, "registry")
-
-	if image == "" {
-		image = "ghcr.io/openclaw/openclaw"
-	}
-	if registry != "" {
-		image = registry + "/" + image
+func printImageInfo(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	fmt.Fprintf(w, "Image:           %s\n", spec.ImageReference())
+	if spec.Image.PullPolicy != "" {
+		fmt.Fprintf(w, "Pull Policy:     %s\n", spec.Image.PullPolicy)
 	}
-
-	if digest != "" {
-		fmt.Printf("Image:           %s@%s\n", image, digest)
-	} else {
-		if tag == "" {
-			tag = "latest"
-		}
-		fmt.Printf("Image:           %s:%s\n", image, tag)
-	}
-	if pullPolicy != "" {
-		fmt.Printf("Pull Policy:     %s\n", pullPolicy)
-	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printEndpoints(status map[string]interface{}) {
-	gateway := getNestedString(status, "gatewayEndpoint")
-	canvas := getNestedString(status, "canvasEndpoint")
-	if gateway == "" && canvas == "" {
+func printEndpoints(w io.Writer, status *v1alpha1.OpenClawInstanceStatus) {
+	if status.GatewayEndpoint == "" && status.CanvasEndpoint == "" {
 		return
 	}
 
-	fmt.Println("Endpoints:")
-	if gateway != "" {
-		fmt.Printf("  Gateway (WebSocket): %s\n", gateway)
+	fmt.Fprintln(w, "Endpoints:")
+	if status.GatewayEndpoint != "" {
+		fmt.Fprintf(w, "  Gateway (WebSocket): %s\n", status.GatewayEndpoint)
 	}
-	if canvas != "" {
-		fmt.Printf("  Canvas (HTTP):       %s\n", canvas)
+	if status.CanvasEndpoint != "" {
+		fmt.Fprintf(w, "  Canvas (HTTP):       %s\n", status.CanvasEndpoint)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printSidecars(spec map[string]interface{}) {
+func printSidecars(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
 	var sidecars []string
 
-	chromiumEnabled, ok := getNestedBool(spec, "chromium", "enabled")
-	if ok && chromiumEnabled {
+	if spec.Chromium.Enabled {
 		detail := "enabled"
-		persistenceEnabled, pOk := getNestedBool(spec, "chromium", "persistence", "enabled")
-		if pOk && persistenceEnabled {
+		if spec.Chromium.Persistence.Enabled {
 			detail += " [persistent]"
 		}
 		sidecars = append(sidecars, fmt.Sprintf("  Chromium:      %s", detail))
 	}
 
-	tailscaleEnabled, ok := getNestedBool(spec, "tailscale", "enabled")
-	if ok && tailscaleEnabled {
-		mode := getNestedString(spec, "tailscale", "mode")
+	if spec.Tailscale.Enabled {
+		mode := spec.Tailscale.Mode
 		if mode == "" {
 			mode = "serve"
 		}
-		hostname := getNestedString(spec, "tailscale", "hostname")
 		detail := fmt.Sprintf("enabled (mode: %s", mode)
-		if hostname != "" {
-			detail += fmt.Sprintf(", hostname: %s", hostname)
+		if spec.Tailscale.Hostname != "" {
+			detail += fmt.Sprintf(", hostname: %s", spec.Tailscale.Hostname)
 		}
 		detail += ")"
 		sidecars = append(sidecars, fmt.Sprintf("  Tailscale:     %s", detail))
 	}
 
-	ollamaEnabled, ok := getNestedBool(spec, "ollama", "enabled")
-	if ok && ollamaEnabled {
+	if spec.Ollama.Enabled {
 		detail := "enabled"
-		models, mOk := getNestedSlice(spec, "ollama", "models")
-		if mOk && len(models) > 0 {
-			var names []string
-			for _, m := range models {
-				if s, ok := m.(string); ok {
-					names = append(names, s)
-				}
-			}
-			detail += fmt.Sprintf(" (models: %s)", strings.Join(names, ", "))
+		if len(spec.Ollama.Models) > 0 {
+			detail += fmt.Sprintf(" (models: %s)", strings.Join(spec.Ollama.Models, ", "))
 		}
-		gpu, gOk := getNestedInt64(spec, "ollama", "gpu")
-		if gOk && gpu > 0 {
-			detail += fmt.Sprintf(" [%d GPU]", gpu)
+		if spec.Ollama.GPU > 0 {
+			detail += fmt.Sprintf(" [%d GPU]", spec.Ollama.GPU)
 		}
 		sidecars = append(sidecars, fmt.Sprintf("  Ollama:        %s", detail))
 	}
 
-	webTermEnabled, ok := getNestedBool(spec, "webTerminal", "enabled")
-	if ok && webTermEnabled {
+	if spec.WebTerminal.Enabled {
 		detail := "enabled"
-		readOnly, rOk := getNestedBool(spec, "webTerminal", "readOnly")
-		if rOk && readOnly {
+		if spec.WebTerminal.ReadOnly {
 			detail += " [read-only]"
 		}
 		sidecars = append(sidecars, fmt.Sprintf("  Web Terminal:  %s", detail))
 	}
 
 	if len(sidecars) > 0 {
-		fmt.Println("Sidecars:")
+		fmt.Fprintln(w, "Sidecars:")
 		for _, s := range sidecars {
-			fmt.Println(s)
+			fmt.Fprintln(w, s)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 }
 
-func printSkills(spec map[string]interface{}) {
-	skills, ok := getNestedSlice(spec, "skills")
-	if !ok || len(skills) == 0 {
+func printSkills(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	if len(spec.Skills) == 0 {
 		return
 	}
-	fmt.Println("Skills:")
-	for _, s := range skills {
-		if str, ok := s.(string); ok {
-			fmt.Printf("  - %s\n", str)
-		}
+	fmt.Fprintln(w, "Skills:")
+	for _, s := range spec.Skills {
+		fmt.Fprintf(w, "  - %s\n", s)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printRuntimeDeps(spec map[string]interface{}) {
-	pnpm, _ := getNestedBool(spec, "runtimeDeps", "pnpm")
-	python, _ := getNestedBool(spec, "runtimeDeps", "python")
-	if !pnpm && !python {
+func printRuntimeDeps(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	if !spec.RuntimeDeps.Pnpm && !spec.RuntimeDeps.Python {
 		return
 	}
-	fmt.Println("Runtime Dependencies:")
-	if pnpm {
-		fmt.Println("  pnpm: installed")
+	fmt.Fprintln(w, "Runtime Dependencies:")
+	if spec.RuntimeDeps.Pnpm {
+		fmt.Fprintln(w, "  pnpm: installed")
 	}
-	if python {
-		fmt.Println("  python: installed (3.12 + uv)")
+	if spec.RuntimeDeps.Python {
+		fmt.Fprintln(w, "  python: installed (3.12 + uv)")
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printResources(spec map[string]interface{}) {
-	reqCPU := getNestedString(spec, "resources", "requests", "cpu")
-	reqMem := getNestedString(spec, "resources", "requests", "memory")
-	limCPU := getNestedString(spec, "resources", "limits", "cpu")
-	limMem := getNestedString(spec, "resources", "limits", "memory")
+func printResources(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	reqCPU := quantityString(spec.Resources.Requests, corev1.ResourceCPU)
+	reqMem := quantityString(spec.Resources.Requests, corev1.ResourceMemory)
+	limCPU := quantityString(spec.Resources.Limits, corev1.ResourceCPU)
+	limMem := quantityString(spec.Resources.Limits, corev1.ResourceMemory)
 	if reqCPU == "" && reqMem == "" && limCPU == "" && limMem == "" {
 		return
 	}
 
-	fmt.Println("Resources:")
+	fmt.Fprintln(w, "Resources:")
 	if reqCPU != "" || reqMem != "" {
-		fmt.Printf("  Requests:  %s CPU, %s memory\n", reqCPU, reqMem)
+		fmt.Fprintf(w, "  Requests:  %s CPU, %s memory\n", reqCPU, reqMem)
 	}
 	if limCPU != "" || limMem != "" {
-		fmt.Printf("  Limits:    %s CPU, %s memory\n", limCPU, limMem)
+		fmt.Fprintf(w, "  Limits:    %s CPU, %s memory\n", limCPU, limMem)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printStorage(spec map[string]interface{}) {
-	enabled, ok := getNestedBool(spec, "storage", "persistence", "enabled")
-	if ok && !enabled {
-		fmt.Println("Storage: disabled")
-		fmt.Println()
-		return
+func quantityString(list corev1.ResourceList, name corev1.ResourceName) string {
+	if q, ok := list[name]; ok {
+		return q.String()
 	}
+	return ""
+}
 
-	size := getNestedString(spec, "storage", "persistence", "size")
-	storageClass := getNestedString(spec, "storage", "persistence", "storageClass")
-	existingClaim := getNestedString(spec, "storage", "persistence", "existingClaim")
+func printStorage(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	persistence := spec.Storage.Persistence
+	if !spec.PersistenceEnabled() {
+		fmt.Fprintln(w, "Storage: disabled")
+		fmt.Fprintln(w)
+		return
+	}
 
-	if size == "" && existingClaim == "" {
+	if persistence.Size == "" && persistence.ExistingClaim == "" {
 		return
 	}
 
-	fmt.Println("Storage:")
-	if existingClaim != "" {
-		fmt.Printf("  Existing Claim:   %s\n", existingClaim)
+	fmt.Fprintln(w, "Storage:")
+	if persistence.ExistingClaim != "" {
+		fmt.Fprintf(w, "  Existing Claim:   %s\n", persistence.ExistingClaim)
 	} else {
-		fmt.Printf("  Size:             %s\n", size)
+		fmt.Fprintf(w, "  Size:             %s\n", persistence.Size)
 	}
-	if storageClass != "" {
-		fmt.Printf("  Storage Class:    %s\n", storageClass)
+	if persistence.StorageClass != "" {
+		fmt.Fprintf(w, "  Storage Class:    %s\n", persistence.StorageClass)
 	}
-	orphan, oOk := getNestedBool(spec, "storage", "persistence", "orphan")
-	if oOk {
-		fmt.Printf("  Orphan on Delete: %v\n", orphan)
+	if persistence.Orphan != nil {
+		fmt.Fprintf(w, "  Orphan on Delete: %v\n", *persistence.Orphan)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printNetworking(spec map[string]interface{}) {
-	svcType := getNestedString(spec, "networking", "service", "type")
-	ingressEnabled, ingressOk := getNestedBool(spec, "networking", "ingress", "enabled")
-	npEnabled, npOk := getNestedBool(spec, "security", "networkPolicy", "enabled")
+func printNetworking(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	svcType := spec.Networking.Service.Type
+	ingress := spec.Networking.Ingress
+	networkPolicy := spec.Security.NetworkPolicy.Enabled
 
-	if svcType == "" && !ingressOk && !npOk {
+	if svcType == "" && ingress.Enabled == nil && networkPolicy == nil {
 		return
 	}
 
-	fmt.Println("Networking:")
+	fmt.Fprintln(w, "Networking:")
 	if svcType != "" {
-		fmt.Printf("  Service Type:    %s\n", svcType)
+		fmt.Fprintf(w, "  Service Type:    %s\n", svcType)
 	}
-	if npOk {
-		if npEnabled {
-			fmt.Printf("  Network Policy:  enabled\n")
+	if networkPolicy != nil {
+		if *networkPolicy {
+			fmt.Fprintf(w, "  Network Policy:  enabled\n")
 		} else {
-			fmt.Printf("  Network Policy:  disabled\n")
+			fmt.Fprintf(w, "  Network Policy:  disabled\n")
 		}
 	}
-	if ingressOk && ingressEnabled {
-		hosts, hOk := getNestedSlice(spec, "networking", "ingress", "hosts")
-		if hOk && len(hosts) > 0 {
-			var hostNames []string
-			for _, h := range hosts {
-				if hm, ok := h.(map[string]interface{}); ok {
-					host := getNestedString(hm, "host")
-					if host != "" {
-						hostNames = append(hostNames, host)
-					}
-				}
+	if ingress.Enabled != nil && *ingress.Enabled {
+		var hostNames []string
+		for _, h := range ingress.Hosts {
+			if h.Host != "" {
+				hostNames = append(hostNames, h.Host)
 			}
-			fmt.Printf("  Ingress:         enabled (%s)\n", strings.Join(hostNames, ", "))
+		}
+		if len(ingress.Hosts) > 0 {
+			fmt.Fprintf(w, "  Ingress:         enabled (%s)\n", strings.Join(hostNames, ", "))
 		} else {
-			fmt.Printf("  Ingress:         enabled\n")
+			fmt.Fprintf(w, "  Ingress:         enabled\n")
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printBackupSummary(spec, status map[string]interface{}) {
-	schedule := getNestedString(spec, "backup", "schedule")
-	lastPath := getNestedString(status, "lastBackupPath")
-	lastTime := getNestedString(status, "lastBackupTime")
-	restoredFrom := getNestedString(status, "restoredFrom")
-
-	if schedule == "" && lastPath == "" && restoredFrom == "" {
+func printBackupSummary(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec, status *v1alpha1.OpenClawInstanceStatus) {
+	if spec.Backup.Schedule == "" && status.LastBackupPath == "" && status.RestoredFrom == "" {
 		return
 	}
 
-	fmt.Println("Backup:")
-	if schedule != "" {
-		fmt.Printf("  Schedule:    %s\n", schedule)
+	fmt.Fprintln(w, "Backup:")
+	if spec.Backup.Schedule != "" {
+		fmt.Fprintf(w, "  Schedule:    %s\n", spec.Backup.Schedule)
 	}
-	if lastPath != "" {
-		fmt.Printf("  Last Path:   %s\n", lastPath)
+	if status.LastBackupPath != "" {
+		fmt.Fprintf(w, "  Last Path:   %s\n", status.LastBackupPath)
 	}
-	if lastTime != "" {
-		fmt.Printf("  Last Time:   %s\n", lastTime)
+	if status.LastBackupTime != nil {
+		fmt.Fprintf(w, "  Last Time:   %s\n", formatTimestamp(*status.LastBackupTime))
 	}
-	if restoredFrom != "" {
-		fmt.Printf("  Restored:    %s\n", restoredFrom)
+	if status.RestoredFrom != "" {
+		fmt.Fprintf(w, "  Restored:    %s\n", status.RestoredFrom)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printAutoUpdate(spec, status map[string]interface{}) {
-	enabled, ok := getNestedBool(spec, "autoUpdate", "enabled")
-	if !ok || !enabled {
+func printAutoUpdate(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec, status *v1alpha1.OpenClawInstanceStatus) {
+	if !spec.AutoUpdateEnabled() {
 		return
 	}
 
-	fmt.Println("Auto-Update:")
-	checkInterval := getNestedString(spec, "autoUpdate", "checkInterval")
-	if checkInterval != "" {
-		fmt.Printf("  Check Interval:  %s\n", checkInterval)
+	fmt.Fprintln(w, "Auto-Update:")
+	if spec.AutoUpdate.CheckInterval != "" {
+		fmt.Fprintf(w, "  Check Interval:  %s\n", spec.AutoUpdate.CheckInterval)
+	}
+	if spec.AutoUpdate.VersionConstraint != "" {
+		fmt.Fprintf(w, "  Constraint:      %s\n", spec.AutoUpdate.VersionConstraint)
 	}
 
-	autoUpdateStatus, ok, _ := unstructuredNestedMap(status, "autoUpdate")
-	if ok {
-		current := getNestedString(autoUpdateStatus, "currentVersion")
-		latest := getNestedString(autoUpdateStatus, "latestVersion")
-		pending := getNestedString(autoUpdateStatus, "pendingVersion")
-		updatePhase := getNestedString(autoUpdateStatus, "updatePhase")
-		lastErr := getNestedString(autoUpdateStatus, "lastUpdateError")
-
-		if current != "" {
-			fmt.Printf("  Current:         %s\n", current)
+	if au := status.AutoUpdate; au != nil {
+		if au.CurrentVersion != "" {
+			fmt.Fprintf(w, "  Current:         %s\n", au.CurrentVersion)
 		}
-		if latest != "" {
-			fmt.Printf("  Latest:          %s\n", latest)
+		if au.LatestVersion != "" {
+			fmt.Fprintf(w, "  Latest:          %s\n", au.LatestVersion)
 		}
-		if pending != "" {
-			fmt.Printf("  Pending:         %s\n", pending)
+		if au.PendingVersion != "" {
+			fmt.Fprintf(w, "  Pending:         %s\n", au.PendingVersion)
 		}
-		if updatePhase != "" {
-			fmt.Printf("  Update Phase:    %s\n", updatePhase)
+		if au.UpdatePhase != "" {
+			fmt.Fprintf(w, "  Update Phase:    %s\n", au.UpdatePhase)
 		}
-		if lastErr != "" {
-			fmt.Printf("  Last Error:      %s\n", lastErr)
+		if au.LastUpdateError != "" {
+			fmt.Fprintf(w, "  Last Error:      %s\n", au.LastUpdateError)
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printSelfConfigure(spec map[string]interface{}) {
-	enabled, ok := getNestedBool(spec, "selfConfigure", "enabled")
-	if !ok || !enabled {
+func printSelfConfigure(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	if !spec.SelfConfigure.Enabled {
 		return
 	}
 
-	actions, aOk := getNestedSlice(spec, "selfConfigure", "allowedActions")
-	if aOk && len(actions) > 0 {
-		var actionNames []string
-		for _, a := range actions {
-			if s, ok := a.(string); ok {
-				actionNames = append(actionNames, s)
-			}
-		}
-		fmt.Printf("Self-Configure:  enabled (%s)\n\n", strings.Join(actionNames, ", "))
+	sc := &spec.SelfConfigure
+	if len(sc.AllowedActions) > 0 {
+		fmt.Fprintf(w, "Self-Configure:  enabled (%s)\n", strings.Join(sc.AllowedActions, ", "))
 	} else {
-		fmt.Println("Self-Configure:  enabled")
-		fmt.Println()
+		fmt.Fprintln(w, "Self-Configure:  enabled")
+	}
+	for _, r := range []struct {
+		label string
+		rules *v1alpha1.SelfConfigureRules
+	}{{"Skills", sc.Skills}, {"Config Paths", sc.ConfigPaths}, {"Env Vars", sc.EnvVars}} {
+		if r.rules != nil {
+			fmt.Fprintf(w, "  %-15s%s\n", r.label+":", formatSelfConfigRules(r.rules))
+		}
 	}
+	fmt.Fprintln(w)
 }
 
-func printObservability(spec map[string]interface{}) {
-	metricsEnabled, mOk := getNestedBool(spec, "observability", "metrics", "enabled")
-	logLevel := getNestedString(spec, "observability", "logging", "level")
-	logFormat := getNestedString(spec, "observability", "logging", "format")
+func printObservability(w io.Writer, spec *v1alpha1.OpenClawInstanceSpec) {
+	metrics := spec.Observability.Metrics
+	logging := spec.Observability.Logging
 
-	if !mOk && logLevel == "" {
+	if metrics.Enabled == nil && logging.Level == "" {
 		return
 	}
 
-	fmt.Println("Observability:")
-	if mOk {
-		if metricsEnabled {
-			port, pOk := getNestedInt64(spec, "observability", "metrics", "port")
-			if pOk {
-				fmt.Printf("  Metrics:         enabled (port: %d)\n", port)
+	fmt.Fprintln(w, "Observability:")
+	if metrics.Enabled != nil {
+		if *metrics.Enabled {
+			if metrics.Port != nil {
+				fmt.Fprintf(w, "  Metrics:         enabled (port: %d)\n", *metrics.Port)
 			} else {
-				fmt.Printf("  Metrics:         enabled\n")
+				fmt.Fprintf(w, "  Metrics:         enabled\n")
 			}
-			smEnabled, _ := getNestedBool(spec, "observability", "metrics", "serviceMonitor", "enabled")
-			if smEnabled {
-				fmt.Printf("  ServiceMonitor:  enabled\n")
+			if metrics.ServiceMonitor.Enabled {
+				fmt.Fprintf(w, "  ServiceMonitor:  enabled\n")
 			}
-			prEnabled, _ := getNestedBool(spec, "observability", "metrics", "prometheusRule", "enabled")
-			if prEnabled {
-				fmt.Printf("  PrometheusRule:  enabled\n")
+			if metrics.PrometheusRule.Enabled {
+				fmt.Fprintf(w, "  PrometheusRule:  enabled\n")
 			}
-			gdEnabled, _ := getNestedBool(spec, "observability", "metrics", "grafanaDashboard", "enabled")
-			if gdEnabled {
-				fmt.Printf("  Grafana:         enabled\n")
+			if metrics.GrafanaDashboard.Enabled {
+				fmt.Fprintf(w, "  Grafana:         enabled\n")
 			}
 		} else {
-			fmt.Printf("  Metrics:         disabled\n")
+			fmt.Fprintf(w, "  Metrics:         disabled\n")
 		}
 	}
-	if logLevel != "" {
-		fmt.Printf("  Log Level:       %s\n", logLevel)
+	if logging.Level != "" {
+		fmt.Fprintf(w, "  Log Level:       %s\n", logging.Level)
 	}
-	if logFormat != "" {
-		fmt.Printf("  Log Format:      %s\n", logFormat)
+	if logging.Format != "" {
+		fmt.Fprintf(w, "  Log Format:      %s\n", logging.Format)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
-func printConditions(status map[string]interface{}) {
-	conditions, ok := status["conditions"]
-	if !ok {
-		return
-	}
-	condList, ok := conditions.([]interface{})
-	if !ok || len(condList) == 0 {
+func conditionViews(conditions []metav1.Condition) []conditionView {
+	var views []conditionView
+	for _, cond := range conditions {
+		views = append(views, conditionView{
+			Type:    cond.Type,
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: cond.Message,
+		})
+	}
+	return views
+}
+
+func printConditions(w io.Writer, conditions []conditionView) {
+	if len(conditions) == 0 {
 		return
 	}
 
-	fmt.Println("Conditions:")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
-	for _, c := range condList {
-		cond, ok := c.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		condType := getNestedString(cond, "type")
-		condStatus := getNestedString(cond, "status")
-		reason := getNestedString(cond, "reason")
-		message := getNestedString(cond, "message")
-
+	fmt.Fprintln(w, "Conditions:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+	for _, cond := range conditions {
 		indicator := " "
-		if condStatus == "True" {
+		if cond.Status == "True" {
 			indicator = "+"
-		} else if condStatus == "False" {
+		} else if cond.Status == "False" {
 			indicator = "-"
 		}
 
-		fmt.Fprintf(w, "  %s %s\t%s\t%s\t%s\n", indicator, condType, condStatus, reason, message)
+		fmt.Fprintf(tw, "  %s %s\t%s\t%s\t%s\n", indicator, cond.Type, cond.Status, cond.Reason, cond.Message)
 	}
-	w.Flush()
-	fmt.Println()
+	tw.Flush()
+	fmt.Fprintln(w)
 }
 
-func printManagedResources(status map[string]interface{}) {
-	managed, ok, _ := unstructuredNestedMap(status, "managedResources")
-	if !ok {
+func managedResourceViews(managed *v1alpha1.ManagedResources) []managedResourceView {
+	if managed == nil {
+		return nil
+	}
+
+	resources := []managedResourceView{
+		{"StatefulSet", managed.StatefulSet},
+		{"Deployment", managed.Deployment},
+		{"Service", managed.Service},
+		{"ConfigMap", managed.ConfigMap},
+		{"PVC", managed.PVC},
+		{"Chromium PVC", managed.ChromiumPVC},
+		{"NetworkPolicy", managed.NetworkPolicy},
+		{"PDB", managed.PodDisruptionBudget},
+		{"HPA", managed.HorizontalPodAutoscaler},
+		{"ServiceAccount", managed.ServiceAccount},
+		{"Role", managed.Role},
+		{"RoleBinding", managed.RoleBinding},
+		{"Gateway Secret", managed.GatewayTokenSecret},
+		{"Basic Auth Secret", managed.BasicAuthSecret},
+		{"Tailscale Secret", managed.TailscaleStateSecret},
+		{"Backup CronJob", managed.BackupCronJob},
+		{"PrometheusRule", managed.PrometheusRule},
+		{"Grafana (Operator)", managed.GrafanaDashboardOperator},
+		{"Grafana (Instance)", managed.GrafanaDashboardInstance},
+	}
+
+	views := []managedResourceView{}
+	for _, r := range resources {
+		if r.Name != "" {
+			views = append(views, r)
+		}
+	}
+	return views
+}
+
+func printManagedResources(w io.Writer, resources []managedResourceView) {
+	if resources == nil {
 		return
 	}
 
-	resources := []struct {
-		label string
-		key   string
-	}{
-		{"StatefulSet", "statefulSet"},
-		{"Deployment", "deployment"},
-		{"Service", "service"},
-		{"ConfigMap", "configMap"},
-		{"PVC", "pvc"},
-		{"Chromium PVC", "chromiumPVC"},
-		{"NetworkPolicy", "networkPolicy"},
-		{"PDB", "podDisruptionBudget"},
-		{"HPA", "horizontalPodAutoscaler"},
-		{"ServiceAccount", "serviceAccount"},
-		{"Role", "role"},
-		{"RoleBinding", "roleBinding"},
-		{"Gateway Secret", "gatewayTokenSecret"},
-		{"Basic Auth Secret", "basicAuthSecret"},
-		{"Tailscale Secret", "tailscaleStateSecret"},
-		{"Backup CronJob", "backupCronJob"},
-		{"PrometheusRule", "prometheusRule"},
-		{"Grafana (Operator)", "grafanaDashboardOperator"},
-		{"Grafana (Instance)", "grafanaDashboardInstance"},
-	}
-
-	fmt.Println("Managed Resources:")
-	found := false
+	fmt.Fprintln(w, "Managed Resources:")
 	for _, r := range resources {
-		val := getNestedString(managed, r.key)
-		if val != "" {
-			fmt.Printf("  %-22s %s\n", r.label+":", val)
-			found = true
+		fmt.Fprintf(w, "  %-22s %s\n", r.Kind+":", r.Name)
+	}
+	if len(resources) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	fmt.Fprintln(w)
+}
+
+func podViews(pods []corev1.Pod) []podView {
+	views := make([]podView, 0, len(pods))
+	for _, pod := range pods {
+		view := podView{
+			Name:    pod.Name,
+			Phase:   string(pod.Status.Phase),
+			Created: pod.CreationTimestamp,
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			view.Restarts += cs.RestartCount
 		}
+		for _, cs := range pod.Status.InitContainerStatuses {
+			view.Containers = append(view.Containers, containerViewFor(cs, true))
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			view.Containers = append(view.Containers, containerViewFor(cs, false))
+		}
+		views = append(views, view)
 	}
-	if !found {
-		fmt.Println("  (none)")
+	return views
+}
+
+func containerViewFor(cs corev1.ContainerStatus, init bool) containerView {
+	state := "Unknown"
+	if cs.State.Running != nil {
+		state = "Running"
+	} else if cs.State.Waiting != nil {
+		state = "Waiting: " + cs.State.Waiting.Reason
+	} else if cs.State.Terminated != nil {
+		state = "Terminated: " + cs.State.Terminated.Reason
+	}
+	return containerView{
+		Name:     cs.Name,
+		Init:     init,
+		Ready:    cs.Ready,
+		State:    state,
+		Restarts: cs.RestartCount,
 	}
-	fmt.Println()
 }
 
-func printPodStatus(clients *kube.Clients, ns, instanceName string) {
-	pods, err := clients.Kube.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: podLabelSelector(instanceName),
-	})
-	if err != nil {
-		fmt.Printf("Pod Status: failed to list pods: %v\n", err)
+func printPodStatus(w io.Writer, st *instanceStatus) {
+	if st.PodError != "" {
+		fmt.Fprintf(w, "Pod Status: failed to list pods: %s\n", st.PodError)
 		return
 	}
 
-	if len(pods.Items) == 0 {
-		fmt.Println("Pods: none found")
+	if len(st.Pods) == 0 {
+		fmt.Fprintln(w, "Pods: none found")
 		return
 	}
 
-	fmt.Println("Pods:")
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "  NAME\tSTATUS\tRESTARTS\tAGE")
-	for _, pod := range pods.Items {
-		restarts := int32(0)
-		for _, cs := range pod.Status.ContainerStatuses {
-			restarts += cs.RestartCount
-		}
-		fmt.Fprintf(w, "  %s\t%s\t%d\t%s\n",
-			pod.Name, string(pod.Status.Phase), restarts, formatAge(pod.CreationTimestamp.Time))
-	}
-	w.Flush()
-	fmt.Println()
-
-	pod := pods.Items[0]
-	allStatuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
-	if len(allStatuses) > 0 {
-		fmt.Println("Containers:")
-		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "  NAME\tREADY\tSTATE\tRESTARTS")
-		for _, cs := range allStatuses {
-			state := "Unknown"
-			if cs.State.Running != nil {
-				state = "Running"
-			} else if cs.State.Waiting != nil {
-				state = "Waiting: " + cs.State.Waiting.Reason
-			} else if cs.State.Terminated != nil {
-				state = "Terminated: " + cs.State.Terminated.Reason
-			}
+	fmt.Fprintln(w, "Pods:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  NAME\tSTATUS\tRESTARTS\tAGE")
+	for _, pod := range st.Pods {
+		fmt.Fprintf(tw, "  %s\t%s\t%d\t%s\n",
+			pod.Name, pod.Phase, pod.Restarts, formatAge(pod.Created.Time))
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+
+	pod := st.Pods[0]
+	if len(pod.Containers) > 0 {
+		fmt.Fprintln(w, "Containers:")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  NAME\tREADY\tSTATE\tRESTARTS")
+		for _, cs := range pod.Containers {
 			ready := "false"
 			if cs.Ready {
 				ready = "true"
 			}
-			fmt.Fprintf(w, "  %s\t%s\t%s\t%d\n", cs.Name, ready, state, cs.RestartCount)
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%d\n", cs.Name, ready, cs.State, cs.Restarts)
 		}
-		w.Flush()
+		tw.Flush()
 	}
 }
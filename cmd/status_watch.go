@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	ansiClearScreen = "\033[H\033[2J"
+	ansiYellow      = "\033[33m"
+	ansiReset       = "\033[0m"
+
+	// watchEventCount is how many recent events the watch view shows.
+	watchEventCount = 5
+)
+
+// watchStatus redraws the status screen every time the instance, its pods or
+// their events change, until interrupted or the instance is deleted.
+func watchStatus(client *openclaw.Client, ns, name string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	changed, err := client.WatchInstance(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	var prev *instanceStatus
+
+	for {
+		st, err := collectInstanceStatus(client, ns, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				fmt.Printf("\nOpenClawInstance %s/%s was deleted.\n", ns, name)
+				return nil
+			}
+			return err
+		}
+
+		var frame bytes.Buffer
+		printWatchFrame(&frame, client, st, diffInstanceStatus(prev, st), tty)
+		if tty {
+			fmt.Print(ansiClearScreen)
+		} else if prev != nil {
+			fmt.Println()
+		}
+		os.Stdout.Write(frame.Bytes())
+		prev = st
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+		// Let a burst of related updates (pod and condition changes usually
+		// arrive together) settle into one frame.
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+func printWatchFrame(w io.Writer, client *openclaw.Client, st *instanceStatus, changes []string, tty bool) {
+	fmt.Fprintf(w, "Watching %s/%s, updated %s (Ctrl-C to exit)\n\n",
+		st.typed.Namespace, st.typed.Name, time.Now().Format("15:04:05"))
+
+	if len(changes) > 0 {
+		fmt.Fprintln(w, "Changed:")
+		for _, c := range changes {
+			if tty {
+				fmt.Fprintf(w, "  %s* %s%s\n", ansiYellow, c, ansiReset)
+			} else {
+				fmt.Fprintf(w, "  * %s\n", c)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	printInstanceStatus(w, st)
+
+	events, err := client.Events(context.TODO(), st.typed.Namespace, st.typed.Name)
+	if err != nil || len(events.Items) == 0 {
+		return
+	}
+	items := events.Items
+	if len(items) > watchEventCount {
+		items = items[len(items)-watchEventCount:]
+	}
+	fmt.Fprintln(w, "\nRecent Events:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range items {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s/%s\t%s\n", formatAge(openclaw.EventTime(e)), e.Type, e.Reason,
+			e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Message)
+	}
+	tw.Flush()
+}
+
+// diffInstanceStatus describes what changed between two frames: phase
+// transitions, generation bumps, condition flips, pods coming and going, and
+// container restarts.
+func diffInstanceStatus(prev, cur *instanceStatus) []string {
+	if prev == nil {
+		return nil
+	}
+	var changes []string
+
+	prevInst, curInst := prev.typed, cur.typed
+	if p, c := prevInst.Status.CurrentPhase(), curInst.Status.CurrentPhase(); p != c {
+		changes = append(changes, fmt.Sprintf("Phase: %s -> %s", p, c))
+	}
+	if prevInst.Generation != curInst.Generation {
+		changes = append(changes, fmt.Sprintf("Generation: %d -> %d", prevInst.Generation, curInst.Generation))
+	}
+	if p, c := prevInst.Spec.ImageReference(), curInst.Spec.ImageReference(); p != c {
+		changes = append(changes, fmt.Sprintf("Image: %s -> %s", p, c))
+	}
+
+	prevConds := make(map[string]string)
+	for _, c := range prev.Conditions {
+		prevConds[c.Type] = c.Status
+	}
+	for _, c := range cur.Conditions {
+		if p, ok := prevConds[c.Type]; !ok {
+			changes = append(changes, fmt.Sprintf("Condition %s: %s", c.Type, c.Status))
+		} else if p != c.Status {
+			changes = append(changes, fmt.Sprintf("Condition %s: %s -> %s", c.Type, p, c.Status))
+		}
+	}
+
+	prevPods := make(map[string]podView)
+	for _, p := range prev.Pods {
+		prevPods[p.Name] = p
+	}
+	for _, pod := range cur.Pods {
+		p, ok := prevPods[pod.Name]
+		delete(prevPods, pod.Name)
+		if !ok || !p.Created.Equal(&pod.Created) {
+			changes = append(changes, fmt.Sprintf("Pod %s created", pod.Name))
+			continue
+		}
+		if p.Phase != pod.Phase {
+			changes = append(changes, fmt.Sprintf("Pod %s: %s -> %s", pod.Name, p.Phase, pod.Phase))
+		}
+
+		prevContainers := make(map[string]containerView)
+		for _, c := range p.Containers {
+			prevContainers[c.Name] = c
+		}
+		for _, c := range pod.Containers {
+			pc, ok := prevContainers[c.Name]
+			if !ok {
+				continue
+			}
+			if c.Restarts > pc.Restarts {
+				changes = append(changes, fmt.Sprintf("Container %s/%s restarted (%d -> %d)", pod.Name, c.Name, pc.Restarts, c.Restarts))
+			} else if pc.Ready != c.Ready {
+				changes = append(changes, fmt.Sprintf("Container %s/%s ready: %t -> %t", pod.Name, c.Name, pc.Ready, c.Ready))
+			}
+		}
+	}
+	for podName := range prevPods {
+		changes = append(changes, fmt.Sprintf("Pod %s deleted", podName))
+	}
+
+	return changes
+}
@@ -2,28 +2,60 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
 
-	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 func newUpgradeCmd() *cobra.Command {
 	var (
-		digest string
-		image  string
+		digest     string
+		image      string
+		pin        bool
+		plainHTTP  bool
+		force      bool
+		skipBackup bool
+		fleet      fleetUpgradeFlags
 	)
 
 	cmd := &cobra.Command{
-		Use:   "upgrade NAME [TAG]",
+		Use:   "upgrade (NAME [TAG] | -l SELECTOR [-A] TAG)",
 		Short: "Upgrade an OpenClaw instance to a new version",
 		Long: `Update the container image tag or digest for an OpenClawInstance.
 The operator will perform a rolling update of the StatefulSet.
 
-If auto-update is enabled, upgrades are handled automatically.`,
+If auto-update is enabled, upgrades are handled automatically: its status is
+shown and upgrade refuses to run until auto-update is paused with
+"claw autoupdate NAME pause".
+
+When the current and target tags are semantic versions, downgrades and
+upgrades across a major version are refused unless --force is set.
+
+With --pin, TAG is resolved to the digest it currently points to, using the
+OCI distribution API of the instance's registry (spec.registry) and its image
+pull secrets. The digest is deployed, so the running version cannot change
+under a moving tag, and the tag is kept to show which version it is. See
+"claw versions" for the available tags.
+
+With --wait, the StatefulSet rollout is followed: replica counts, container
+states and the new pod's events (image pulls, starts, probe failures). If the
+new pod cannot start (ImagePullBackOff, CrashLoopBackOff, ...), its logs and
+events are shown and the command exits non-zero.
+
+The image being replaced is recorded on the instance; see "upgrade history"
+and "rollback". If the instance has a backup schedule, a backup is taken first
+and recorded with it, so "rollback --restore-backup" can return to the data
+as it was just before the upgrade; --skip-backup upgrades without one.
+Fleet upgrades do not take these backups.
+
+With --selector and/or --all-namespaces, every matching instance is upgraded
+in batches of --batch-size, at most --max-unavailable at a time. Each batch
+must reach Running and Ready before the next one starts. If an instance goes
+Failed or Degraded, or does not become ready within --timeout, the upgrade
+halts and reports; --rollback-on-failure rolls the failed instances back.
+The matching instances are listed and must be confirmed unless --yes is given.`,
 		Example: `  # Upgrade to a specific tag
   claw upgrade my-agent v1.2.3
 
@@ -33,10 +65,45 @@ If auto-update is enabled, upgrades are handled automatically.`,
   # Pin to a specific digest
   claw upgrade my-agent --digest sha256:abc123...
 
+  # Resolve a tag to its current digest and deploy that
+  claw upgrade my-agent v1.2.3 --pin
+
   # Change the image repository
-  claw upgrade my-agent v1.2.3 --image ghcr.io/custom/openclaw`,
-		Args: cobra.RangeArgs(1, 2),
+  claw upgrade my-agent v1.2.3 --image ghcr.io/custom/openclaw
+
+  # Go back to an older version on purpose
+  claw upgrade my-agent v1.1.0 --force
+
+  # Upgrade and fail the pipeline if the new version does not come up
+  claw upgrade my-agent v1.2.3 --wait
+
+  # Show previous images
+  claw upgrade history my-agent
+
+  # Upgrade a team's agents across namespaces, 5 at a time, one canary first
+  claw upgrade -l team=research -A v1.2.3 --batch-size 1
+  claw upgrade -l team=research -A v1.2.3 --batch-size 5 --rollback-on-failure --yes`,
+		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fleet.selector != "" || fleet.allNamespaces {
+				if len(args) > 1 {
+					return fmt.Errorf("with --selector or --all-namespaces, pass only TAG")
+				}
+				tag := ""
+				if len(args) == 1 {
+					tag = args[0]
+				}
+				if tag == "" && digest == "" {
+					return fmt.Errorf("provide a TAG argument or --digest flag")
+				}
+				if pin {
+					return fmt.Errorf("--pin cannot be used with --selector or --all-namespaces")
+				}
+				return upgradeFleet(cmd, fleet, openclaw.UpgradeOptions{Tag: tag, Digest: digest, Repository: image, Force: force})
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("provide an instance NAME, or --selector/--all-namespaces")
+			}
 			name := args[0]
 
 			tag := ""
@@ -47,53 +114,68 @@ If auto-update is enabled, upgrades are handled automatically.`,
 			if tag == "" && digest == "" {
 				return fmt.Errorf("provide a TAG argument or --digest flag")
 			}
+			if pin && (tag == "" || digest != "") {
+				return fmt.Errorf("--pin requires a TAG argument and cannot be combined with --digest")
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
 
-			clients, err := kube.NewClients(kubeconfig)
+			opts := openclaw.UpgradeOptions{
+				Tag:        tag,
+				Digest:     digest,
+				Repository: image,
+				Force:      force,
+			}
+			inst, err := client.GetInstance(context.TODO(), ns, name)
 			if err != nil {
 				return err
 			}
+			printAutoUpdate(os.Stdout, &inst.Spec, &inst.Status)
 
-			ns := namespace
-			if ns == "" {
-				ns, err = resolveNamespace()
+			if pin {
+				opts.Digest, err = client.ResolveDigest(context.TODO(), ns, name, tag, openclaw.RegistryOptions{
+					Repository: image,
+					PlainHTTP:  plainHTTP,
+				})
 				if err != nil {
 					return err
 				}
 			}
 
-			// Get current instance to show what's changing
-			obj, err := clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Get(
-				context.TODO(), name, metav1.GetOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("instance %q not found: %w", name, err)
-			}
+			digest = opts.Digest
 
-			spec, _, _ := unstructuredNestedMap(obj.Object, "spec")
-			currentTag := getNestedString(spec, "image", "tag")
-			currentDigest := getNestedString(spec, "image", "digest")
+			opts.Backup = backupBeforeImageChange(client, skipBackup)
 
-			imageSpec := map[string]interface{}{}
-
-			if image != "" {
-				imageSpec["repository"] = image
+			result, err := client.Upgrade(context.TODO(), ns, name, opts)
+			if err != nil {
+				return err
+			}
+			if result.Check.NeedsForce() {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", result.Check.Warning())
 			}
 
-			if digest != "" {
-				imageSpec["digest"] = digest
-				// Clear tag when setting digest
-				imageSpec["tag"] = ""
-				fmt.Printf("Upgrading %s/%s:\n", ns, name)
+			currentTag := result.Previous.Tag
+			currentDigest := result.Previous.Digest
+			fmt.Printf("Upgrading %s/%s:\n", ns, name)
+			if pin {
+				from := currentTag
+				if currentDigest != "" {
+					from = truncateDigest(currentDigest)
+				}
+				if from == "" {
+					from = "(default)"
+				}
+				fmt.Printf("  %s -> %s@%s\n", from, tag, truncateDigest(digest))
+			} else if digest != "" {
 				if currentDigest != "" {
 					fmt.Printf("  digest: %s -> %s\n", truncateDigest(currentDigest), truncateDigest(digest))
 				} else {
 					fmt.Printf("  %s -> %s\n", currentTag, truncateDigest(digest))
 				}
 			} else {
-				imageSpec["tag"] = tag
-				// Clear digest when setting tag
-				imageSpec["digest"] = ""
-				fmt.Printf("Upgrading %s/%s:\n", ns, name)
 				if currentTag != "" {
 					fmt.Printf("  %s -> %s\n", currentTag, tag)
 				} else if currentDigest != "" {
@@ -103,21 +185,8 @@ If auto-update is enabled, upgrades are handled automatically.`,
 				}
 			}
 
-			patch := map[string]interface{}{
-				"spec": map[string]interface{}{
-					"image": imageSpec,
-				},
-			}
-			patchBytes, err := json.Marshal(patch)
-			if err != nil {
-				return fmt.Errorf("failed to create patch: %w", err)
-			}
-
-			_, err = clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns).Patch(
-				context.TODO(), name, types.MergePatchType, patchBytes, metav1.PatchOptions{},
-			)
-			if err != nil {
-				return fmt.Errorf("failed to upgrade: %w", err)
+			if waited, err := waitForRollout(cmd, client, ns, name); waited {
+				return err
 			}
 
 			fmt.Printf("\nUpgrade initiated. Monitor with:\n")
@@ -128,7 +197,14 @@ If auto-update is enabled, upgrades are handled automatically.`,
 
 	cmd.Flags().StringVar(&digest, "digest", "", "pin to a specific image digest")
 	cmd.Flags().StringVar(&image, "image", "", "change the image repository")
-
+	cmd.Flags().BoolVar(&pin, "pin", false, "resolve TAG to its digest in the registry and deploy the digest")
+	cmd.Flags().BoolVar(&force, "force", false, "allow downgrades and major version jumps")
+	cmd.Flags().BoolVar(&plainHTTP, "plain-http", false, "talk HTTP to the registry with --pin (implied for localhost)")
+	cmd.Flags().BoolVar(&skipBackup, "skip-backup", false, "do not back up the data before changing the image")
+	addWaitFlags(cmd)
+	addFleetUpgradeFlags(cmd, &fleet)
+
+	cmd.AddCommand(newUpgradeHistoryCmd())
 	return cmd
 }
 
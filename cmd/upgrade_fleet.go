@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+type fleetUpgradeFlags struct {
+	selector          string
+	allNamespaces     bool
+	batchSize         int
+	maxUnavailable    int
+	rollbackOnFailure bool
+	yes               bool
+}
+
+func addFleetUpgradeFlags(cmd *cobra.Command, f *fleetUpgradeFlags) {
+	cmd.Flags().StringVarP(&f.selector, "selector", "l", "", "upgrade all instances matching this label selector")
+	cmd.Flags().BoolVarP(&f.allNamespaces, "all-namespaces", "A", false, "upgrade matching instances in all namespaces")
+	cmd.Flags().IntVar(&f.batchSize, "batch-size", 1, "instances per batch; each batch must be ready before the next starts")
+	cmd.Flags().IntVar(&f.maxUnavailable, "max-unavailable", 0, "instances of a batch upgraded at the same time (default: the batch size)")
+	cmd.Flags().BoolVar(&f.rollbackOnFailure, "rollback-on-failure", false, "roll failed instances back to their previous image")
+	cmd.Flags().BoolVarP(&f.yes, "yes", "y", false, "upgrade the matching instances without asking")
+}
+
+// upgradeFleet upgrades every instance matching the selector in batches and
+// reports progress as it goes. The matching instances are listed and, unless
+// --yes is given, confirmed first.
+func upgradeFleet(cmd *cobra.Command, f fleetUpgradeFlags, upgrade openclaw.UpgradeOptions) error {
+	client, ns, err := newClient()
+	if err != nil {
+		return err
+	}
+	if f.allNamespaces {
+		ns = ""
+	}
+
+	list, err := client.ListInstances(context.TODO(), ns, openclaw.ListOptions{LabelSelector: f.selector})
+	if err != nil {
+		return err
+	}
+	if len(list.Items) == 0 {
+		fmt.Println("No matching OpenClaw instances found.")
+		return nil
+	}
+
+	target := upgrade.Tag
+	if upgrade.Digest != "" {
+		target = truncateDigest(upgrade.Digest)
+	}
+	fmt.Printf("Upgrading %d instance(s) to %s in batches of %d:\n", len(list.Items), target, f.batchSize)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i := range list.Items {
+		inst := &list.Items[i]
+		fmt.Fprintf(w, "  %s/%s\t%s\t%s\t%s\n", inst.Namespace, inst.Name, formatImageSpec(inst.Spec.Image), inst.Status.CurrentPhase(), upgradeCheckNote(openclaw.CheckUpgrade(inst, upgrade), upgrade.Force))
+	}
+	w.Flush()
+
+	if !f.yes {
+		fmt.Printf("\nUpgrade these %d instance(s)? [y/N]: ", len(list.Items))
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	instances := make([]*v1alpha1.OpenClawInstance, len(list.Items))
+	for i := range list.Items {
+		instances[i] = &list.Items[i]
+	}
+
+	result, err := client.FleetUpgrade(context.Background(), instances, openclaw.FleetUpgradeOptions{
+		UpgradeOptions:    upgrade,
+		BatchSize:         f.batchSize,
+		MaxUnavailable:    f.maxUnavailable,
+		Timeout:           timeout,
+		RollbackOnFailure: f.rollbackOnFailure,
+		OnProgress:        printFleetUpgradeProgress,
+	})
+
+	fmt.Println()
+	if result != nil {
+		printFleetUpgradeResult(result)
+	}
+	return err
+}
+
+func printFleetUpgradeProgress(p openclaw.FleetUpgradeProgress) {
+	switch p.Step {
+	case openclaw.StepBatchStarted:
+		fmt.Printf("\nBatch %d/%d: %s\n", p.Batch, p.Batches, strings.Join(p.Instances, ", "))
+	case openclaw.StepUpgrading:
+		fmt.Printf("  %s: %s -> %s, waiting for Running/Ready...\n", p.Instance, formatImageSpec(p.Previous), formatImageSpec(p.Current))
+	case openclaw.StepReady:
+		fmt.Printf("  %s: ready\n", p.Instance)
+	case openclaw.StepSkipped:
+		fmt.Printf("  %s: already on %s, skipped\n", p.Instance, formatImageSpec(p.Previous))
+	case openclaw.StepFailed:
+		fmt.Printf("  %s: FAILED: %v\n", p.Instance, p.Err)
+	case openclaw.StepRolledBack:
+		if p.Err != nil {
+			fmt.Printf("  %s: rollback FAILED: %v\n", p.Instance, p.Err)
+		} else {
+			fmt.Printf("  %s: rolled back %s -> %s\n", p.Instance, formatImageSpec(p.Previous), formatImageSpec(p.Current))
+		}
+	}
+}
+
+func printFleetUpgradeResult(r *openclaw.FleetUpgradeResult) {
+	fmt.Printf("Upgraded: %d, skipped: %d, failed: %d, rolled back: %d, not attempted: %d\n",
+		len(r.Upgraded), len(r.Skipped), len(r.Failed), len(r.RolledBack), len(r.NotAttempted))
+	if len(r.Failed) > 0 {
+		fmt.Printf("Failed:        %s\n", strings.Join(r.Failed, ", "))
+	}
+	if len(r.NotAttempted) > 0 {
+		fmt.Printf("Not attempted: %s\n", strings.Join(r.NotAttempted, ", "))
+	}
+}
+
+// upgradeCheckNote flags instances whose upgrade will be refused or needs
+// --force, so the listing explains failures before they halt the fleet.
+func upgradeCheckNote(check openclaw.UpgradeCheck, force bool) string {
+	switch {
+	case check.AutoUpdateActive:
+		return "refused: auto-update enabled"
+	case check.Downgrade && !force:
+		return "refused: downgrade, needs --force"
+	case check.MajorJump && !force:
+		return "refused: major version jump, needs --force"
+	case check.Downgrade:
+		return "downgrade"
+	case check.MajorJump:
+		return "major version jump"
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newUpgradeHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history NAME",
+		Short: "Show the images an OpenClaw instance ran before",
+		Long: `List the images an OpenClawInstance ran before its current one, as recorded by
+"upgrade" and "rollback", with the backup taken just before each was
+replaced, if one was. Use a revision number with "rollback --to-revision".`,
+		Example: `  # Show upgrade history
+  kubectl openclaw upgrade history my-agent
+
+  # As JSON
+  kubectl openclaw upgrade history my-agent -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			inst, err := client.GetInstance(context.TODO(), ns, name)
+			if err != nil {
+				return err
+			}
+			revisions, err := openclaw.ImageHistory(inst)
+			if err != nil {
+				return err
+			}
+
+			history := &upgradeHistory{Namespace: ns, Name: name, Current: inst.Spec.Image, Revisions: revisions}
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, history)
+			}
+
+			fmt.Printf("Current: %s\n\n", formatImageSpec(inst.Spec.Image))
+			if len(revisions) == 0 {
+				fmt.Println("No upgrade history recorded.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "REVISION\tIMAGE\tREPLACED\tBACKUP BEFORE REPLACE")
+			for i := len(revisions) - 1; i >= 0; i-- {
+				r := revisions[i]
+				backup := r.Backup
+				if backup == "" {
+					backup = "<none>"
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s ago\t%s\n", r.Revision, formatImageSpec(r.Image), formatAge(r.ReplacedAt.Time), backup)
+			}
+			return w.Flush()
+		},
+	}
+
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// upgradeHistory is the data model behind "upgrade history".
+type upgradeHistory struct {
+	Namespace string                   `json:"namespace"`
+	Name      string                   `json:"name"`
+	Current   v1alpha1.ImageSpec       `json:"current"`
+	Revisions []openclaw.ImageRevision `json:"revisions"`
+}
+
+func (h *upgradeHistory) resourceNames() []string {
+	return []string{instanceResourceName(h.Name)}
+}
+
+// formatImageSpec renders an image spec compactly; unset fields are the
+// operator's defaults.
+func formatImageSpec(img v1alpha1.ImageSpec) string {
+	repo := img.Repository
+	if repo == "" {
+		repo = "(default)"
+	}
+	switch {
+	case img.Digest != "" && img.Tag != "":
+		return repo + ":" + img.Tag + "@" + truncateDigest(img.Digest)
+	case img.Digest != "":
+		return repo + "@" + truncateDigest(img.Digest)
+	case img.Tag != "":
+		return repo + ":" + img.Tag
+	}
+	return repo + ":(default)"
+}
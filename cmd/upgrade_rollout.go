@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// stuckLogLines is how many log lines are shown for a stuck container.
+const stuckLogLines int64 = 20
+
+// waitForRollout is waitIfRequested for image changes: with --wait it follows
+// the StatefulSet rollout, printing replica counts, container states and the
+// new pods' events (image pulls, starts, probe failures) as they happen. A
+// stuck rollout is reported with the container's logs and events.
+func waitForRollout(cmd *cobra.Command, client *openclaw.Client, ns, name string) (bool, error) {
+	ctx, cancel, ok := waitContext(cmd)
+	if !ok {
+		return false, nil
+	}
+	defer cancel()
+
+	fmt.Printf("Waiting for %s/%s to roll out...\n", ns, name)
+	since := time.Now().Add(-time.Second)
+	seen := map[string]int32{}
+	var lastProgress string
+
+	st, err := client.WaitForRollout(ctx, ns, name, func(st *openclaw.RolloutStatus) {
+		for _, e := range st.Events {
+			if openclaw.EventTime(e).Before(since) || seen[string(e.UID)] == e.Count {
+				continue
+			}
+			seen[string(e.UID)] = e.Count
+			fmt.Printf("  pod/%s: %s: %s\n", e.InvolvedObject.Name, e.Reason, e.Message)
+		}
+		if p := rolloutProgress(st); p != lastProgress {
+			lastProgress = p
+			fmt.Printf("  %s\n", p)
+		}
+	})
+
+	var stuck *openclaw.RolloutStuckError
+	if errors.As(err, &stuck) {
+		printStuckRollout(client, ns, st, stuck)
+		return true, err
+	}
+	if err != nil {
+		return true, err
+	}
+
+	inst, err := client.WaitFor(ctx, ns, name, openclaw.Settled...)
+	if err != nil {
+		return true, err
+	}
+	fmt.Printf("Rollout complete. Instance %s/%s is %s.\n", ns, name, inst.Status.CurrentPhase())
+	return true, nil
+}
+
+// rolloutProgress summarizes replica counts and the containers of updated
+// pods that are not ready yet.
+func rolloutProgress(st *openclaw.RolloutStatus) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "statefulset/%s: %d/%d updated, %d/%d ready", st.StatefulSet,
+		st.UpdatedReplicas, st.Replicas, st.ReadyReplicas, st.Replicas)
+
+	var waiting []string
+	for _, pod := range st.Pods {
+		if !pod.Updated {
+			continue
+		}
+		for _, cs := range pod.Containers {
+			if cs.Ready {
+				continue
+			}
+			state := "not ready"
+			switch {
+			case cs.State.Waiting != nil:
+				state = cs.State.Waiting.Reason
+			case cs.State.Terminated != nil:
+				state = cs.State.Terminated.Reason
+			case cs.State.Running != nil:
+				state = "starting"
+			}
+			if state == "Completed" {
+				// Finished init containers.
+				continue
+			}
+			waiting = append(waiting, fmt.Sprintf("%s/%s %s", pod.Name, cs.Name, state))
+		}
+	}
+	if len(waiting) > 0 {
+		b.WriteString(" (" + strings.Join(waiting, ", ") + ")")
+	}
+	return b.String()
+}
+
+// printStuckRollout shows the stuck container's recent logs and its pod's
+// events, which usually explain why it cannot start.
+func printStuckRollout(client *openclaw.Client, ns string, st *openclaw.RolloutStatus, stuck *openclaw.RolloutStuckError) {
+	fmt.Printf("\nRollout stuck: container %s in pod %s is in %s.\n", stuck.Container, stuck.Pod, stuck.Reason)
+
+	if stuck.Reason == "CrashLoopBackOff" || stuck.Restarted {
+		tail := stuckLogLines
+		opts := &corev1.PodLogOptions{Container: stuck.Container, Previous: stuck.Restarted, TailLines: &tail}
+		if stream, err := client.LogStream(context.TODO(), ns, stuck.Pod, opts); err == nil {
+			fmt.Printf("\nLast logs of %s/%s:\n", stuck.Pod, stuck.Container)
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				fmt.Printf("  %s\n", scanner.Text())
+			}
+			stream.Close()
+		}
+	}
+
+	if st != nil {
+		var events []corev1.Event
+		for _, e := range st.Events {
+			if e.InvolvedObject.Name == stuck.Pod {
+				events = append(events, e)
+			}
+		}
+		if len(events) > 10 {
+			events = events[len(events)-10:]
+		}
+		if len(events) > 0 {
+			fmt.Printf("\nEvents of pod %s:\n", stuck.Pod)
+			for _, e := range events {
+				fmt.Printf("  %s ago\t%s\t%s\t%s\n", formatAge(openclaw.EventTime(e)), e.Type, e.Reason, e.Message)
+			}
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+func newVersionsCmd() *cobra.Command {
+	var (
+		image       string
+		plainHTTP   bool
+		prereleases bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "versions NAME",
+		Short: "List the image versions available for an OpenClaw instance",
+		Long: `List the tags of an OpenClawInstance's image repository, queried through the
+OCI distribution API of its registry (spec.registry) with its image pull
+secrets. Semantic versions come first, newest first, followed by other tags
+such as "latest". The version the instance runs is marked with *.
+
+Prereleases are hidden unless --prereleases is set or the instance runs one.`,
+		Example: `  # List available versions
+  kubectl openclaw versions my-agent
+
+  # Include prereleases
+  kubectl openclaw versions my-agent --prereleases
+
+  # Against a local test registry
+  kubectl openclaw versions my-agent --image localhost:5000/openclaw
+
+  # As JSON
+  kubectl openclaw versions my-agent -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			output, _ := cmd.Flags().GetString("output")
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			versions, err := client.AvailableVersions(context.TODO(), ns, name, openclaw.RegistryOptions{
+				Repository: image,
+				PlainHTTP:  plainHTTP,
+			})
+			if err != nil {
+				return err
+			}
+			if !prereleases {
+				var tags []openclaw.ImageTag
+				for _, t := range versions.Tags {
+					if !t.Prerelease || t.Current {
+						tags = append(tags, t)
+					}
+				}
+				versions.Tags = tags
+			}
+
+			listing := &versionsListing{Namespace: ns, Name: name, ImageVersions: versions}
+			if isStructuredOutput(output) {
+				return printStructured(os.Stdout, output, listing)
+			}
+
+			fmt.Printf("Repository: %s\n\n", versions.Repository)
+			if len(versions.Tags) == 0 {
+				fmt.Println("No tags found.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tTAG")
+			for _, t := range versions.Tags {
+				current := ""
+				if t.Current {
+					current = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\n", current, t.Tag)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&image, "image", "", "list tags of this repository instead of the instance's")
+	cmd.Flags().BoolVar(&plainHTTP, "plain-http", false, "talk HTTP to the registry (implied for localhost)")
+	cmd.Flags().BoolVar(&prereleases, "prereleases", false, "include prerelease versions")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+// versionsListing is the data model behind "versions".
+type versionsListing struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	*openclaw.ImageVersions
+}
+
+// resourceNames returns the tags, which is what upgrade takes.
+func (l *versionsListing) resourceNames() []string {
+	names := make([]string, len(l.Tags))
+	for i, t := range l.Tags {
+		names[i] = t.Tag
+	}
+	return names
+}
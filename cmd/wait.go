@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/openclaw"
+	"github.com/spf13/cobra"
+)
+
+const defaultWaitTimeout = 5 * time.Minute
+
+func newWaitCmd() *cobra.Command {
+	var (
+		forConditions []string
+		timeout       time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "wait NAME",
+		Short: "Wait for an OpenClaw instance to reach a phase or condition",
+		Long: `Block until an OpenClawInstance reaches a phase, a status condition, or until
+the operator has reconciled its latest spec. Uses a watch, not polling.
+
+Conditions (--for, repeatable; all must hold):
+  phase=PHASE              status.phase, e.g. phase=Running
+  condition=TYPE[=STATUS]  a status condition, STATUS defaults to True
+  generation               status.observedGeneration equals metadata.generation
+
+Exits non-zero on timeout, or as soon as the instance is Failed or Degraded
+for its current spec (unless that phase is what you are waiting for).`,
+		Example: `  # Wait for an instance to be running
+  claw wait my-agent --for=phase=Running
+
+  # Wait for skills to be resolved
+  claw wait my-agent --for=condition=SkillPacksReady
+
+  # Wait for the operator to pick up the latest change and become ready
+  claw wait my-agent --for=generation --for=condition=Ready --timeout 10m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			conditions := make([]openclaw.WaitCondition, 0, len(forConditions))
+			for _, s := range forConditions {
+				cond, err := openclaw.ParseWaitCondition(s)
+				if err != nil {
+					return err
+				}
+				conditions = append(conditions, cond)
+			}
+			if len(conditions) == 0 {
+				conditions = openclaw.Settled
+			}
+
+			client, ns, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if _, err := client.WaitFor(ctx, ns, name, conditions...); err != nil {
+				return err
+			}
+
+			fmt.Printf("%s condition met (%s)\n", instanceResourceName(name), describeConditions(conditions))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&forConditions, "for", nil, "condition to wait for: phase=PHASE, condition=TYPE[=STATUS] or generation (default: generation and phase=Running)")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultWaitTimeout, "how long to wait before giving up")
+
+	return cmd
+}
+
+// addWaitFlags adds --wait and --timeout to a command that changes an instance.
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "wait until the change has rolled out; fail if the instance ends up Failed or Degraded")
+	cmd.Flags().Duration("timeout", defaultWaitTimeout, "how long to wait with --wait")
+}
+
+// waitIfRequested blocks until the instance has settled when --wait was given.
+// It reports whether it waited, so callers can skip their "monitor with" hints.
+func waitIfRequested(cmd *cobra.Command, client *openclaw.Client, ns, name string) (bool, error) {
+	ctx, cancel, ok := waitContext(cmd)
+	if !ok {
+		return false, nil
+	}
+	defer cancel()
+
+	fmt.Printf("Waiting for %s/%s (%s)...\n", ns, name, describeConditions(openclaw.Settled))
+	inst, err := client.WaitFor(ctx, ns, name, openclaw.Settled...)
+	if err != nil {
+		return true, err
+	}
+	fmt.Printf("Instance %s/%s is %s.\n", ns, name, inst.Status.CurrentPhase())
+	return true, nil
+}
+
+// waitContext returns a context bounded by --timeout, and false when --wait
+// was not given.
+func waitContext(cmd *cobra.Command) (context.Context, context.CancelFunc, bool) {
+	wait, _ := cmd.Flags().GetBool("wait")
+	if !wait {
+		return nil, nil, false
+	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return ctx, cancel, true
+}
+
+func describeConditions(conditions []openclaw.WaitCondition) string {
+	parts := make([]string, len(conditions))
+	for i, c := range conditions {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FromUnstructured converts an object returned by the dynamic client into a
+// typed OpenClawInstance. Unlike walking the map by hand, a field with an
+// unexpected type is reported as an error instead of being read as empty.
+func FromUnstructured(obj *unstructured.Unstructured) (*OpenClawInstance, error) {
+	var inst OpenClawInstance
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &inst); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenClawInstance %q: %w", obj.GetName(), err)
+	}
+	return &inst, nil
+}
+
+// ListFromUnstructured converts a list returned by the dynamic client.
+func ListFromUnstructured(list *unstructured.UnstructuredList) (*OpenClawInstanceList, error) {
+	out := &OpenClawInstanceList{Items: make([]OpenClawInstance, 0, len(list.Items))}
+	out.APIVersion = list.GetAPIVersion()
+	out.Kind = list.GetKind()
+	out.ResourceVersion = list.GetResourceVersion()
+	out.Continue = list.GetContinue()
+	for i := range list.Items {
+		inst, err := FromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		out.Items = append(out.Items, *inst)
+	}
+	return out, nil
+}
+
+// ToUnstructured converts a typed OpenClawInstance for use with the dynamic client.
+// Fields this package does not model are not present in the result, and unset
+// sections are omitted rather than sent as empty objects.
+func ToUnstructured(inst *OpenClawInstance) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(inst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenClawInstance %q: %w", inst.Name, err)
+	}
+	pruneEmpty(content)
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetAPIVersion(SchemeGroupVersion.String())
+	obj.SetKind(Kind)
+	return obj, nil
+}
+
+// pruneEmpty removes nil values and empty nested objects, which the converter
+// emits for every struct field regardless of omitempty.
+func pruneEmpty(m map[string]interface{}) {
+	for k, v := range m {
+		switch val := v.(type) {
+		case nil:
+			delete(m, k)
+		case map[string]interface{}:
+			pruneEmpty(val)
+			if len(val) == 0 {
+				delete(m, k)
+			}
+		}
+	}
+}
+
+// SelfConfigFromUnstructured converts an OpenClawSelfConfig returned by the
+// dynamic client.
+func SelfConfigFromUnstructured(obj *unstructured.Unstructured) (*OpenClawSelfConfig, error) {
+	var sc OpenClawSelfConfig
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &sc); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenClawSelfConfig %q: %w", obj.GetName(), err)
+	}
+	return &sc, nil
+}
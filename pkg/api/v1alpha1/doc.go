@@ -0,0 +1,7 @@
+// Package v1alpha1 contains typed Go representations of the openclaw.rocks/v1alpha1
+// API served by the OpenClaw operator, along with converters from the
+// unstructured objects returned by the dynamic client.
+//
+// The types only cover the fields the plugin reads. Writes are still done with
+// merge patches so that fields unknown to this package are never dropped.
+package v1alpha1
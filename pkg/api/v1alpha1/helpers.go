@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CurrentPhase returns status.phase, or Pending if the operator has not set it yet.
+func (s *OpenClawInstanceStatus) CurrentPhase() string {
+	if s.Phase == "" {
+		return PhasePending
+	}
+	return s.Phase
+}
+
+// Condition returns the condition of the given type, or nil if it is not set.
+func (s *OpenClawInstanceStatus) Condition(condType string) *metav1.Condition {
+	return meta.FindStatusCondition(s.Conditions, condType)
+}
+
+// ConditionStatus returns the status of the given condition type, or "Unknown"
+// if it is not set.
+func (s *OpenClawInstanceStatus) ConditionStatus(condType string) string {
+	if c := s.Condition(condType); c != nil {
+		return string(c.Status)
+	}
+	return string(metav1.ConditionUnknown)
+}
+
+// Managed returns the managed resource names, never nil.
+func (s *OpenClawInstanceStatus) Managed() ManagedResources {
+	if s.ManagedResources == nil {
+		return ManagedResources{}
+	}
+	return *s.ManagedResources
+}
+
+// ImageRepository returns the repository the operator will pull from,
+// including spec.registry when set.
+func (s *OpenClawInstanceSpec) ImageRepository() string {
+	repo := s.Image.Repository
+	if repo == "" {
+		repo = DefaultImageRepository
+	}
+	if s.Registry != "" {
+		repo = s.Registry + "/" + repo
+	}
+	return repo
+}
+
+// ImageReference renders the full image reference (registry, repository and
+// tag or digest) that the operator will deploy.
+func (s *OpenClawInstanceSpec) ImageReference() string {
+	if s.Image.Digest != "" {
+		return s.ImageRepository() + "@" + s.Image.Digest
+	}
+	tag := s.Image.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	return s.ImageRepository() + ":" + tag
+}
+
+// PersistenceEnabled reports whether the instance has a data PVC. Persistence
+// is on unless explicitly disabled.
+func (s *OpenClawInstanceSpec) PersistenceEnabled() bool {
+	return s.Storage.Persistence.Enabled == nil || *s.Storage.Persistence.Enabled
+}
+
+// AutoUpdateEnabled reports whether spec.autoUpdate.enabled is true.
+func (s *OpenClawInstanceSpec) AutoUpdateEnabled() bool {
+	return s.AutoUpdate.Enabled != nil && *s.AutoUpdate.Enabled
+}
+
+// Actions lists the kinds of change a self-config request makes, named as in
+// spec.selfConfigure.allowedActions: skills, config, workspaceFiles and
+// envVars.
+func (s *OpenClawSelfConfigSpec) Actions() []string {
+	var actions []string
+	if len(s.AddSkills) > 0 || len(s.RemoveSkills) > 0 {
+		actions = append(actions, "skills")
+	}
+	if s.ConfigPatch != nil && len(s.ConfigPatch.Raw) > 0 {
+		actions = append(actions, "config")
+	}
+	if len(s.AddWorkspaceFiles) > 0 || len(s.RemoveWorkspaceFiles) > 0 {
+		actions = append(actions, "workspaceFiles")
+	}
+	if len(s.AddEnvVars) > 0 || len(s.RemoveEnvVars) > 0 {
+		actions = append(actions, "envVars")
+	}
+	return actions
+}
@@ -0,0 +1,321 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	GroupName = "openclaw.rocks"
+	Version   = "v1alpha1"
+	Kind      = "OpenClawInstance"
+
+	// SelfConfigKind is the kind of the requests an agent files to change
+	// its own instance.
+	SelfConfigKind = "OpenClawSelfConfig"
+
+	// DefaultImageRepository is used by the operator when spec.image.repository is unset.
+	DefaultImageRepository = "ghcr.io/openclaw/openclaw"
+)
+
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: Version}
+
+// Instance phases reported in status.phase.
+const (
+	PhasePending      = "Pending"
+	PhaseProvisioning = "Provisioning"
+	PhaseRunning      = "Running"
+	PhaseDegraded     = "Degraded"
+	PhaseFailed       = "Failed"
+	PhaseTerminating  = "Terminating"
+	PhaseBackingUp    = "BackingUp"
+	PhaseRestoring    = "Restoring"
+	PhaseUpdating     = "Updating"
+)
+
+type OpenClawInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenClawInstanceSpec   `json:"spec,omitempty"`
+	Status OpenClawInstanceStatus `json:"status,omitempty"`
+}
+
+type OpenClawInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OpenClawInstance `json:"items"`
+}
+
+type OpenClawInstanceSpec struct {
+	Image            ImageSpec                     `json:"image,omitempty"`
+	Registry         string                        `json:"registry,omitempty"`
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Config           ConfigSpec                    `json:"config,omitempty"`
+	Skills           []string                      `json:"skills,omitempty"`
+	Workspace        WorkspaceSpec                 `json:"workspace,omitempty"`
+	Env              []corev1.EnvVar               `json:"env,omitempty"`
+	EnvFrom          []corev1.EnvFromSource        `json:"envFrom,omitempty"`
+	Resources        corev1.ResourceRequirements   `json:"resources,omitempty"`
+	Storage          StorageSpec                   `json:"storage,omitempty"`
+	Networking       NetworkingSpec                `json:"networking,omitempty"`
+	Security         SecuritySpec                  `json:"security,omitempty"`
+	RuntimeDeps      RuntimeDepsSpec               `json:"runtimeDeps,omitempty"`
+	Chromium         ChromiumSpec                  `json:"chromium,omitempty"`
+	Tailscale        TailscaleSpec                 `json:"tailscale,omitempty"`
+	Ollama           OllamaSpec                    `json:"ollama,omitempty"`
+	WebTerminal      WebTerminalSpec               `json:"webTerminal,omitempty"`
+	Backup           BackupSpec                    `json:"backup,omitempty"`
+	RestoreFrom      string                        `json:"restoreFrom,omitempty"`
+	AutoUpdate       AutoUpdateSpec                `json:"autoUpdate,omitempty"`
+	SelfConfigure    SelfConfigureSpec             `json:"selfConfigure,omitempty"`
+	Observability    ObservabilitySpec             `json:"observability,omitempty"`
+}
+
+type ImageSpec struct {
+	Repository string            `json:"repository,omitempty"`
+	Tag        string            `json:"tag,omitempty"`
+	Digest     string            `json:"digest,omitempty"`
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+}
+
+// ConfigSpec holds the inline openclaw.json. Raw is kept as JSON so that the
+// plugin never needs to know the config schema to round-trip it.
+type ConfigSpec struct {
+	Raw *runtime.RawExtension `json:"raw,omitempty"`
+}
+
+// WorkspaceSpec seeds the agent's workspace. Files are written when missing
+// and never overwrite what the agent has changed since.
+type WorkspaceSpec struct {
+	InitialFiles       map[string]string `json:"initialFiles,omitempty"`
+	InitialDirectories []string          `json:"initialDirectories,omitempty"`
+}
+
+type StorageSpec struct {
+	Persistence PersistenceSpec `json:"persistence,omitempty"`
+}
+
+type PersistenceSpec struct {
+	Enabled       *bool  `json:"enabled,omitempty"`
+	Size          string `json:"size,omitempty"`
+	StorageClass  string `json:"storageClass,omitempty"`
+	ExistingClaim string `json:"existingClaim,omitempty"`
+	Orphan        *bool  `json:"orphan,omitempty"`
+}
+
+type NetworkingSpec struct {
+	Service ServiceSpec `json:"service,omitempty"`
+	Ingress IngressSpec `json:"ingress,omitempty"`
+}
+
+type ServiceSpec struct {
+	Type corev1.ServiceType `json:"type,omitempty"`
+}
+
+type IngressSpec struct {
+	Enabled *bool         `json:"enabled,omitempty"`
+	Hosts   []IngressHost `json:"hosts,omitempty"`
+}
+
+type IngressHost struct {
+	Host string `json:"host,omitempty"`
+}
+
+type SecuritySpec struct {
+	NetworkPolicy NetworkPolicySpec `json:"networkPolicy,omitempty"`
+}
+
+type NetworkPolicySpec struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type RuntimeDepsSpec struct {
+	Pnpm   bool `json:"pnpm,omitempty"`
+	Python bool `json:"python,omitempty"`
+}
+
+type ChromiumSpec struct {
+	Enabled     bool                `json:"enabled,omitempty"`
+	Persistence ChromiumPersistence `json:"persistence,omitempty"`
+}
+
+type ChromiumPersistence struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type TailscaleSpec struct {
+	Enabled          bool                         `json:"enabled,omitempty"`
+	Mode             string                       `json:"mode,omitempty"`
+	Hostname         string                       `json:"hostname,omitempty"`
+	AuthKeySecretRef *corev1.LocalObjectReference `json:"authKeySecretRef,omitempty"`
+}
+
+type OllamaSpec struct {
+	Enabled bool     `json:"enabled,omitempty"`
+	Models  []string `json:"models,omitempty"`
+	GPU     int32    `json:"gpu,omitempty"`
+}
+
+type WebTerminalSpec struct {
+	Enabled  bool `json:"enabled,omitempty"`
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+type BackupSpec struct {
+	Schedule           string `json:"schedule,omitempty"`
+	HistoryLimit       *int32 `json:"historyLimit,omitempty"`
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+	Timeout            string `json:"timeout,omitempty"`
+}
+
+type AutoUpdateSpec struct {
+	Enabled       *bool  `json:"enabled,omitempty"`
+	CheckInterval string `json:"checkInterval,omitempty"`
+	// VersionConstraint limits the versions auto-update moves to, e.g. "~1.4"
+	// or ">=1.2 <2".
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+}
+
+type SelfConfigureSpec struct {
+	Enabled        bool     `json:"enabled,omitempty"`
+	AllowedActions []string `json:"allowedActions,omitempty"`
+	// Skills, ConfigPaths and EnvVars narrow down what the skills, config
+	// and envVars actions may touch.
+	Skills      *SelfConfigureRules `json:"skills,omitempty"`
+	ConfigPaths *SelfConfigureRules `json:"configPaths,omitempty"`
+	EnvVars     *SelfConfigureRules `json:"envVars,omitempty"`
+}
+
+// SelfConfigureRules lists patterns an agent may and may not use. Deny wins
+// over Allow; an empty Allow allows everything not denied.
+type SelfConfigureRules struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+type ObservabilitySpec struct {
+	Metrics MetricsSpec `json:"metrics,omitempty"`
+	Logging LoggingSpec `json:"logging,omitempty"`
+}
+
+type MetricsSpec struct {
+	Enabled          *bool       `json:"enabled,omitempty"`
+	Port             *int32      `json:"port,omitempty"`
+	ServiceMonitor   EnabledFlag `json:"serviceMonitor,omitempty"`
+	PrometheusRule   EnabledFlag `json:"prometheusRule,omitempty"`
+	GrafanaDashboard EnabledFlag `json:"grafanaDashboard,omitempty"`
+}
+
+// EnabledFlag is the shape of the many optional add-ons that only carry an
+// "enabled" switch.
+type EnabledFlag struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type LoggingSpec struct {
+	Level  string `json:"level,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+type OpenClawInstanceStatus struct {
+	Phase              string             `json:"phase,omitempty"`
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	GatewayEndpoint    string             `json:"gatewayEndpoint,omitempty"`
+	CanvasEndpoint     string             `json:"canvasEndpoint,omitempty"`
+	ManagedResources   *ManagedResources  `json:"managedResources,omitempty"`
+	LastBackupPath     string             `json:"lastBackupPath,omitempty"`
+	LastBackupTime     *metav1.Time       `json:"lastBackupTime,omitempty"`
+	BackupJobName      string             `json:"backupJobName,omitempty"`
+	BackingUpSince     *metav1.Time       `json:"backingUpSince,omitempty"`
+	RestoreJobName     string             `json:"restoreJobName,omitempty"`
+	RestoredFrom       string             `json:"restoredFrom,omitempty"`
+	AutoUpdate         *AutoUpdateStatus  `json:"autoUpdate,omitempty"`
+}
+
+// ManagedResources names the objects the operator created for an instance.
+type ManagedResources struct {
+	StatefulSet              string `json:"statefulSet,omitempty"`
+	Deployment               string `json:"deployment,omitempty"`
+	Service                  string `json:"service,omitempty"`
+	ConfigMap                string `json:"configMap,omitempty"`
+	PVC                      string `json:"pvc,omitempty"`
+	ChromiumPVC              string `json:"chromiumPVC,omitempty"`
+	NetworkPolicy            string `json:"networkPolicy,omitempty"`
+	PodDisruptionBudget      string `json:"podDisruptionBudget,omitempty"`
+	HorizontalPodAutoscaler  string `json:"horizontalPodAutoscaler,omitempty"`
+	ServiceAccount           string `json:"serviceAccount,omitempty"`
+	Role                     string `json:"role,omitempty"`
+	RoleBinding              string `json:"roleBinding,omitempty"`
+	GatewayTokenSecret       string `json:"gatewayTokenSecret,omitempty"`
+	BasicAuthSecret          string `json:"basicAuthSecret,omitempty"`
+	TailscaleStateSecret     string `json:"tailscaleStateSecret,omitempty"`
+	BackupCronJob            string `json:"backupCronJob,omitempty"`
+	PrometheusRule           string `json:"prometheusRule,omitempty"`
+	GrafanaDashboardOperator string `json:"grafanaDashboardOperator,omitempty"`
+	GrafanaDashboardInstance string `json:"grafanaDashboardInstance,omitempty"`
+}
+
+type AutoUpdateStatus struct {
+	CurrentVersion  string `json:"currentVersion,omitempty"`
+	LatestVersion   string `json:"latestVersion,omitempty"`
+	PendingVersion  string `json:"pendingVersion,omitempty"`
+	UpdatePhase     string `json:"updatePhase,omitempty"`
+	LastUpdateError string `json:"lastUpdateError,omitempty"`
+}
+
+// Self-config request phases reported in status.phase.
+const (
+	SelfConfigPhasePending = "Pending"
+	SelfConfigPhaseApplied = "Applied"
+	SelfConfigPhaseFailed  = "Failed"
+	SelfConfigPhaseDenied  = "Denied"
+)
+
+// OpenClawSelfConfig is a change an agent with spec.selfConfigure enabled
+// asks to make to its own instance.
+type OpenClawSelfConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenClawSelfConfigSpec   `json:"spec,omitempty"`
+	Status OpenClawSelfConfigStatus `json:"status,omitempty"`
+}
+
+type OpenClawSelfConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OpenClawSelfConfig `json:"items"`
+}
+
+type OpenClawSelfConfigSpec struct {
+	// InstanceRef names the OpenClawInstance, in the same namespace, the
+	// request applies to.
+	InstanceRef  string   `json:"instanceRef"`
+	AddSkills    []string `json:"addSkills,omitempty"`
+	RemoveSkills []string `json:"removeSkills,omitempty"`
+	// ConfigPatch is a JSON merge patch for spec.config.raw.
+	ConfigPatch          *runtime.RawExtension `json:"configPatch,omitempty"`
+	AddWorkspaceFiles    map[string]string     `json:"addWorkspaceFiles,omitempty"`
+	RemoveWorkspaceFiles []string              `json:"removeWorkspaceFiles,omitempty"`
+	AddEnvVars           []SelfConfigEnvVar    `json:"addEnvVars,omitempty"`
+	RemoveEnvVars        []string              `json:"removeEnvVars,omitempty"`
+}
+
+// SelfConfigEnvVar is a plain-value environment variable; agents cannot
+// reference Secrets.
+type SelfConfigEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type OpenClawSelfConfigStatus struct {
+	Phase          string       `json:"phase,omitempty"`
+	Message        string       `json:"message,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -0,0 +1,442 @@
+// Package jsonschema validates decoded JSON against the subset of JSON Schema
+// (draft 2020-12) used for configuration files: type, enum, const,
+// properties, additionalProperties, patternProperties, required, items,
+// minItems, maxItems, minimum, maximum, minLength, pattern, anyOf, oneOf and
+// local $ref into $defs.
+//
+// Beyond the standard, keys that an object schema with properties neither
+// describes nor restricts are reported as unknown keys, marked
+// Error.Unknown, so that callers can treat likely typos as warnings.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema is a parsed JSON Schema.
+type Schema struct {
+	root *node
+}
+
+type node struct {
+	// Bool schemas: true accepts everything, false nothing.
+	boolean *bool
+
+	Ref                  string           `json:"$ref"`
+	Defs                 map[string]*node `json:"$defs"`
+	Type                 typeList         `json:"type"`
+	Enum                 []interface{}    `json:"enum"`
+	Const                interface{}      `json:"const"`
+	Properties           map[string]*node `json:"properties"`
+	AdditionalProperties *node            `json:"additionalProperties"`
+	PatternProperties    map[string]*node `json:"patternProperties"`
+	Required             []string         `json:"required"`
+	Items                *node            `json:"items"`
+	MinItems             *int             `json:"minItems"`
+	MaxItems             *int             `json:"maxItems"`
+	Minimum              *float64         `json:"minimum"`
+	Maximum              *float64         `json:"maximum"`
+	MinLength            *int             `json:"minLength"`
+	Pattern              string           `json:"pattern"`
+	AnyOf                []*node          `json:"anyOf"`
+	OneOf                []*node          `json:"oneOf"`
+	Description          string           `json:"description"`
+
+	// hasConst is set when the schema has "const", which may be null.
+	hasConst bool
+	pattern  *regexp.Regexp
+	patterns map[string]*regexp.Regexp
+}
+
+func (n *node) UnmarshalJSON(data []byte) error {
+	var b bool
+	if json.Unmarshal(data, &b) == nil {
+		n.boolean = &b
+		return nil
+	}
+	type plain node
+	if err := json.Unmarshal(data, (*plain)(n)); err != nil {
+		return err
+	}
+	// "const": null decodes like a missing const, so look for the key.
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	_, n.hasConst = keys["const"]
+	return nil
+}
+
+// typeList accepts "type": "string" as well as "type": ["string", "null"].
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*t = typeList{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// Parse parses a schema document.
+func Parse(data []byte) (*Schema, error) {
+	root := &node{}
+	if err := json.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	s := &Schema{root: root}
+	if err := s.compile(root, "#"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile resolves regular expressions and checks references once, so that
+// validation cannot fail on the schema itself.
+func (s *Schema) compile(n *node, at string) error {
+	if n == nil || n.boolean != nil {
+		return nil
+	}
+	if n.Ref != "" {
+		if _, err := s.resolve(n.Ref); err != nil {
+			return fmt.Errorf("invalid JSON schema at %s: %w", at, err)
+		}
+	}
+	if n.Pattern != "" {
+		re, err := regexp.Compile(n.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid JSON schema at %s: %w", at, err)
+		}
+		n.pattern = re
+	}
+	n.patterns = map[string]*regexp.Regexp{}
+	for p, sub := range n.PatternProperties {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("invalid JSON schema at %s: %w", at, err)
+		}
+		n.patterns[p] = re
+		if err := s.compile(sub, at+"/patternProperties/"+p); err != nil {
+			return err
+		}
+	}
+	for k, sub := range n.Defs {
+		if err := s.compile(sub, at+"/$defs/"+k); err != nil {
+			return err
+		}
+	}
+	for k, sub := range n.Properties {
+		if err := s.compile(sub, at+"/properties/"+k); err != nil {
+			return err
+		}
+	}
+	for i, sub := range n.AnyOf {
+		if err := s.compile(sub, fmt.Sprintf("%s/anyOf/%d", at, i)); err != nil {
+			return err
+		}
+	}
+	for i, sub := range n.OneOf {
+		if err := s.compile(sub, fmt.Sprintf("%s/oneOf/%d", at, i)); err != nil {
+			return err
+		}
+	}
+	if err := s.compile(n.AdditionalProperties, at+"/additionalProperties"); err != nil {
+		return err
+	}
+	return s.compile(n.Items, at+"/items")
+}
+
+func (s *Schema) resolve(ref string) (*node, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only #/$defs/NAME is supported", ref)
+	}
+	def, ok := s.root.Defs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown $ref %q", ref)
+	}
+	return def, nil
+}
+
+// Error is a validation failure at a location in the document.
+type Error struct {
+	// Path holds the object keys and array indices (as decimal strings)
+	// leading to the invalid value; empty for the document itself.
+	Path    []string
+	Message string
+	// Unknown is set for a key that an object schema listing its properties
+	// does not describe, but does not forbid either. Such a key is valid,
+	// but is often a typo, so it is reported too.
+	Unknown bool
+}
+
+func (e Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return strings.Join(e.Path, ".") + ": " + e.Message
+}
+
+// Validate checks a decoded JSON document, as produced by encoding/json
+// into an interface{}, and returns all failures ordered by path.
+func (s *Schema) Validate(doc interface{}) []Error {
+	var errs []Error
+	s.validate(s.root, doc, nil, &errs)
+	sort.SliceStable(errs, func(i, j int) bool {
+		return strings.Join(errs[i].Path, "\x00") < strings.Join(errs[j].Path, "\x00")
+	})
+	return errs
+}
+
+func (s *Schema) validate(n *node, v interface{}, path []string, errs *[]Error) {
+	if n == nil {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, Error{Path: append([]string{}, path...), Message: fmt.Sprintf(format, args...)})
+	}
+	if n.boolean != nil {
+		if !*n.boolean {
+			fail("not allowed")
+		}
+		return
+	}
+	if n.Ref != "" {
+		def, _ := s.resolve(n.Ref)
+		s.validate(def, v, path, errs)
+	}
+
+	if len(n.Type) > 0 && !matchesType(n.Type, v) {
+		fail("expected %s, got %s", strings.Join(n.Type, " or "), typeOf(v))
+		return
+	}
+	if len(n.Enum) > 0 {
+		found := false
+		for _, e := range n.Enum {
+			if reflect.DeepEqual(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %s", formatValues(n.Enum))
+		}
+	}
+	if n.hasConst && !reflect.DeepEqual(n.Const, v) {
+		fail("must be %s", formatValues([]interface{}{n.Const}))
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		s.validateObject(n, val, path, errs)
+	case []interface{}:
+		if n.MinItems != nil && len(val) < *n.MinItems {
+			fail("must have at least %d items", *n.MinItems)
+		}
+		if n.MaxItems != nil && len(val) > *n.MaxItems {
+			fail("must have at most %d items", *n.MaxItems)
+		}
+		if n.Items != nil {
+			for i, item := range val {
+				s.validate(n.Items, item, append(path, fmt.Sprint(i)), errs)
+			}
+		}
+	case float64:
+		if n.Minimum != nil && val < *n.Minimum {
+			fail("must be at least %v", *n.Minimum)
+		}
+		if n.Maximum != nil && val > *n.Maximum {
+			fail("must be at most %v", *n.Maximum)
+		}
+	case string:
+		if n.MinLength != nil && len([]rune(val)) < *n.MinLength {
+			fail("must be at least %d characters", *n.MinLength)
+		}
+		if n.pattern != nil && !n.pattern.MatchString(val) {
+			fail("must match %s", n.Pattern)
+		}
+	}
+
+	if len(n.AnyOf) > 0 {
+		if matches, unknown := s.countMatches(n.AnyOf, v, path); matches == 0 {
+			fail("does not match any of the allowed forms")
+		} else {
+			*errs = append(*errs, unknown...)
+		}
+	}
+	if len(n.OneOf) > 0 {
+		if matches, unknown := s.countMatches(n.OneOf, v, path); matches != 1 {
+			fail("must match exactly one of the allowed forms")
+		} else {
+			*errs = append(*errs, unknown...)
+		}
+	}
+}
+
+func (s *Schema) validateObject(n *node, obj map[string]interface{}, path []string, errs *[]Error) {
+	for _, r := range n.Required {
+		if _, ok := obj[r]; !ok {
+			*errs = append(*errs, Error{Path: append([]string{}, path...), Message: fmt.Sprintf("missing required key %q", r)})
+		}
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sub := append(append([]string{}, path...), k)
+		matched := false
+		if p, ok := n.Properties[k]; ok {
+			s.validate(p, obj[k], sub, errs)
+			matched = true
+		}
+		for expr, re := range n.patterns {
+			if re.MatchString(k) {
+				s.validate(n.PatternProperties[expr], obj[k], sub, errs)
+				matched = true
+			}
+		}
+		if matched {
+			continue
+		}
+		ap := n.AdditionalProperties
+		if ap == nil {
+			if len(n.Properties) > 0 {
+				*errs = append(*errs, Error{Path: sub, Message: unknownKey(k, n.Properties), Unknown: true})
+			}
+			continue
+		}
+		if ap.boolean != nil && !*ap.boolean {
+			*errs = append(*errs, Error{Path: sub, Message: unknownKey(k, n.Properties)})
+			continue
+		}
+		s.validate(ap, obj[k], sub, errs)
+	}
+}
+
+func unknownKey(key string, properties map[string]*node) string {
+	msg := "unknown key"
+	if guess := closest(key, properties); guess != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", guess)
+	}
+	return msg
+}
+
+// countMatches counts the alternatives v is valid against, and returns the
+// unknown keys of the one among them with the fewest.
+func (s *Schema) countMatches(alternatives []*node, v interface{}, path []string) (int, []Error) {
+	matches := 0
+	var unknown []Error
+	for _, alt := range alternatives {
+		var errs []Error
+		s.validate(alt, v, path, &errs)
+		if hasFailures(errs) {
+			continue
+		}
+		if matches == 0 || len(errs) < len(unknown) {
+			unknown = errs
+		}
+		matches++
+	}
+	return matches, unknown
+}
+
+// hasFailures reports whether errs has any error other than an unknown key
+// the schema allows.
+func hasFailures(errs []Error) bool {
+	for _, e := range errs {
+		if !e.Unknown {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(types []string, v interface{}) bool {
+	for _, t := range types {
+		switch t {
+		case "integer":
+			if f, ok := v.(float64); ok && f == math.Trunc(f) {
+				return true
+			}
+		case typeOf(v):
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func formatValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		data, _ := json.Marshal(v)
+		parts[i] = string(data)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// closest returns the known property most similar to key, if it is close
+// enough to be a typo.
+func closest(key string, properties map[string]*node) string {
+	best, bestDist := "", 3
+	for p := range properties {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(p)); d < bestDist || (d == bestDist && p < best && best != "") {
+			best, bestDist = p, d
+		}
+	}
+	if bestDist > 2 || bestDist >= len(key) {
+		return ""
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,216 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		doc    string
+		// want lists the expected errors as "path: message", in order.
+		want []string
+	}{
+		{name: "true schema", schema: `true`, doc: `{"a": 1}`},
+		{name: "false schema", schema: `false`, doc: `1`, want: []string{"not allowed"}},
+		{name: "empty schema", schema: `{}`, doc: `[1, "a", null]`},
+
+		{name: "type", schema: `{"type": "string"}`, doc: `"a"`},
+		{name: "type mismatch", schema: `{"type": "string"}`, doc: `1`, want: []string{"expected string, got number"}},
+		{name: "type list", schema: `{"type": ["string", "null"]}`, doc: `null`},
+		{name: "type list mismatch", schema: `{"type": ["string", "null"]}`, doc: `true`, want: []string{"expected string or null, got boolean"}},
+		{name: "integer", schema: `{"type": "integer"}`, doc: `3`},
+		{name: "integer written as float", schema: `{"type": "integer"}`, doc: `3.0`},
+		{name: "not an integer", schema: `{"type": "integer"}`, doc: `3.5`, want: []string{"expected integer, got number"}},
+		{name: "array type", schema: `{"type": "array"}`, doc: `{}`, want: []string{"expected array, got object"}},
+
+		{name: "enum", schema: `{"enum": ["a", 1, null]}`, doc: `null`},
+		{name: "enum mismatch", schema: `{"enum": ["a", 1]}`, doc: `"b"`, want: []string{`must be one of "a", 1`}},
+		{name: "const", schema: `{"const": {"a": [1]}}`, doc: `{"a": [1]}`},
+		{name: "const mismatch", schema: `{"const": "x"}`, doc: `"y"`, want: []string{`must be "x"`}},
+		{name: "const null", schema: `{"const": null}`, doc: `null`},
+		{name: "const null mismatch", schema: `{"const": null}`, doc: `0`, want: []string{"must be null"}},
+		{name: "const false", schema: `{"const": false}`, doc: `null`, want: []string{"must be false"}},
+
+		{name: "minimum and maximum", schema: `{"minimum": 1, "maximum": 10}`, doc: `10`},
+		{name: "below minimum", schema: `{"minimum": 1}`, doc: `0`, want: []string{"must be at least 1"}},
+		{name: "above maximum", schema: `{"maximum": 65535}`, doc: `70000`, want: []string{"must be at most 65535"}},
+		{name: "minLength counts runes", schema: `{"minLength": 2}`, doc: `"üü"`},
+		{name: "too short", schema: `{"minLength": 1}`, doc: `""`, want: []string{"must be at least 1 characters"}},
+		{name: "pattern", schema: `{"pattern": "^[a-z]+/"}`, doc: `"anthropic/claude"`},
+		{name: "pattern mismatch", schema: `{"pattern": "^[a-z]+/"}`, doc: `"claude"`, want: []string{"must match ^[a-z]+/"}},
+		{name: "keywords apply to their type only", schema: `{"minimum": 1, "minLength": 3}`, doc: `"ab"`, want: []string{"must be at least 3 characters"}},
+
+		{
+			name:   "items and item counts",
+			schema: `{"type": "array", "minItems": 1, "maxItems": 2, "items": {"type": "string"}}`,
+			doc:    `["a", 2, "c"]`,
+			want:   []string{"must have at most 2 items", "1: expected string, got number"},
+		},
+		{name: "too few items", schema: `{"minItems": 1}`, doc: `[]`, want: []string{"must have at least 1 items"}},
+
+		{
+			name:   "properties and required",
+			schema: `{"type": "object", "required": ["port", "bind"], "properties": {"port": {"type": "integer"}}}`,
+			doc:    `{"port": "80"}`,
+			want:   []string{`missing required key "bind"`, "port: expected integer, got string"},
+		},
+		{
+			name:   "keys not described are reported",
+			schema: `{"properties": {"port": {"type": "integer"}}}`,
+			doc:    `{"prot": 80, "other": true}`,
+			want:   []string{"other: unknown key", `prot: unknown key (did you mean "port"?)`},
+		},
+		{
+			name:   "keys are not reported without properties",
+			schema: `{"type": "object"}`,
+			doc:    `{"port": 80}`,
+		},
+		{
+			name:   "unknown keys do not rule out an alternative",
+			schema: `{"oneOf": [{"properties": {"a": {"type": "string"}}}, {"type": "string"}]}`,
+			doc:    `{"b": 1}`,
+			want:   []string{"b: unknown key"},
+		},
+		{
+			name:   "unknown keys of the closest alternative",
+			schema: `{"anyOf": [{"properties": {"a": {}}}, {"properties": {"a": {}, "b": {}}}]}`,
+			doc:    `{"a": 1, "b": 2}`,
+		},
+		{
+			name:   "additionalProperties false",
+			schema: `{"properties": {"port": {}, "bind": {}}, "additionalProperties": false}`,
+			doc:    `{"prot": 80, "zzzzzz": 1}`,
+			want:   []string{`prot: unknown key (did you mean "port"?)`, "zzzzzz: unknown key"},
+		},
+		{
+			name:   "additionalProperties schema",
+			schema: `{"properties": {"a": {}}, "additionalProperties": {"type": "string"}}`,
+			doc:    `{"a": 1, "b": "x", "c": 2}`,
+			want:   []string{"c: expected string, got number"},
+		},
+		{
+			name:   "patternProperties",
+			schema: `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`,
+			doc:    `{"x-a": "1", "x-b": 2, "y": 3}`,
+			want:   []string{"x-b: expected string, got number", "y: unknown key"},
+		},
+		{
+			name:   "nested paths",
+			schema: `{"properties": {"models": {"items": {"properties": {"name": {"type": "string"}}}}}}`,
+			doc:    `{"models": [{"name": "a"}, {"name": 1}]}`,
+			want:   []string{"models.1.name: expected string, got number"},
+		},
+
+		{name: "anyOf", schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, doc: `1`},
+		{name: "anyOf none", schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, doc: `true`, want: []string{"does not match any of the allowed forms"}},
+		{name: "oneOf", schema: `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, doc: `"a"`},
+		{name: "oneOf several", schema: `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, doc: `1`, want: []string{"must match exactly one of the allowed forms"}},
+		{name: "oneOf none", schema: `{"oneOf": [{"type": "string"}]}`, doc: `1`, want: []string{"must match exactly one of the allowed forms"}},
+
+		{
+			name:   "$ref",
+			schema: `{"$defs": {"level": {"enum": ["info", "debug"]}}, "properties": {"level": {"$ref": "#/$defs/level"}}}`,
+			doc:    `{"level": "trace"}`,
+			want:   []string{`level: must be one of "info", "debug"`},
+		},
+		{
+			name:   "recursive $ref",
+			schema: `{"$defs": {"tree": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/tree"}}}}}, "$ref": "#/$defs/tree"}`,
+			doc:    `{"children": [{"children": [1]}]}`,
+			want:   []string{"children.0.children.0: expected object, got number"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var doc interface{}
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range s.Validate(doc) {
+				got = append(got, e.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	s, err := Parse([]byte(`{"properties": {"port": {"type": "integer"}, "mode": {}}, "patternProperties": {"^x-": {}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc interface{}
+	_ = json.Unmarshal([]byte(`{"port": "80", "mdoe": 1, "x-a": 1}`), &doc)
+	errs := s.Validate(doc)
+	if len(errs) != 2 {
+		t.Fatalf("Validate = %+v, want 2 errors", errs)
+	}
+	if e := errs[0]; strings.Join(e.Path, ".") != "mdoe" || !e.Unknown {
+		t.Errorf("errs[0] = %+v, want an unknown key at mdoe", e)
+	}
+	if e := errs[1]; strings.Join(e.Path, ".") != "port" || e.Unknown {
+		t.Errorf("errs[1] = %+v, want a type error at port", e)
+	}
+}
+
+func TestErrorPath(t *testing.T) {
+	s, err := Parse([]byte(`{"properties": {"a": {"properties": {"b": {"type": "string"}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc interface{}
+	_ = json.Unmarshal([]byte(`{"a": {"b": 1}}`), &doc)
+	errs := s.Validate(doc)
+	if len(errs) != 1 || strings.Join(errs[0].Path, "/") != "a/b" {
+		t.Errorf("Validate = %+v, want one error at a/b", errs)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		schema string
+		want   string
+	}{
+		{schema: `{"type": `, want: "invalid JSON schema"},
+		{schema: `{"type": 1}`, want: "invalid JSON schema"},
+		{schema: `{"$ref": "#/definitions/x"}`, want: "unsupported $ref"},
+		{schema: `{"properties": {"a": {"$ref": "#/$defs/missing"}}}`, want: `at #/properties/a: unknown $ref`},
+		{schema: `{"pattern": "("}`, want: "at #:"},
+		{schema: `{"patternProperties": {"(": {}}}`, want: "at #:"},
+		{schema: `{"oneOf": [{}, {"pattern": "["}]}`, want: "at #/oneOf/1:"},
+		{schema: `{"items": {"anyOf": [{"pattern": "["}]}}`, want: "at #/items/anyOf/0:"},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.schema))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%s) = %v, want error containing %q", tt.schema, err, tt.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	props := map[string]*node{"gateway": {}, "agents": {}, "models": {}}
+	tests := map[string]string{
+		"gatway":   "gateway",
+		"Agents":   "agents",
+		"modles":   "models",
+		"channels": "",
+		"a":        "",
+	}
+	for key, want := range tests {
+		if got := closest(key, props); got != want {
+			t.Errorf("closest(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/semver"
+)
+
+// AutoUpdateCheckAnnotation asks the operator to check for a new version
+// right away instead of at the next check interval. Its value is the time of
+// the request, so that repeated requests change the object. It is only a
+// request: an operator that does not act on it checks at the next interval.
+const AutoUpdateCheckAnnotation = "openclaw.rocks/auto-update-check-requested"
+
+// AutoUpdateOptions configures auto-update when enabling it. Empty fields
+// are left as they are.
+type AutoUpdateOptions struct {
+	// CheckInterval is how often the operator looks for new versions, as a
+	// Go duration such as "1h".
+	CheckInterval string
+	// VersionConstraint limits the versions updated to, see
+	// semver.ParseConstraint. It needs an operator whose CRD has
+	// spec.autoUpdate.versionConstraint.
+	VersionConstraint string
+}
+
+// EnableAutoUpdate turns on auto-update and applies opts. A pause is lifted.
+//
+// The version constraint is written first and read back: if the installed
+// CRD does not have the field, the API server drops it, and auto-update is
+// left as it was instead of being enabled without the constraint.
+func (c *Client) EnableAutoUpdate(ctx context.Context, ns, name string, opts AutoUpdateOptions) error {
+	spec := map[string]interface{}{"enabled": true}
+	if opts.CheckInterval != "" {
+		if d, err := time.ParseDuration(opts.CheckInterval); err != nil || d <= 0 {
+			return fmt.Errorf("invalid check interval %q: must be a positive duration such as 30m or 6h", opts.CheckInterval)
+		}
+		spec["checkInterval"] = opts.CheckInterval
+	}
+	if opts.VersionConstraint != "" {
+		if _, err := semver.ParseConstraint(opts.VersionConstraint); err != nil {
+			return err
+		}
+		updated, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{
+			"autoUpdate": map[string]interface{}{"versionConstraint": opts.VersionConstraint},
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to set the version constraint: %w", err)
+		}
+		if updated.Spec.AutoUpdate.VersionConstraint != opts.VersionConstraint {
+			return fmt.Errorf("the version constraint was not saved: the OpenClawInstance CRD in the cluster has no spec.autoUpdate.versionConstraint, upgrade the operator to use --constraint; auto-update was not changed")
+		}
+	}
+	if err := c.setAutoUpdate(ctx, ns, name, spec, nil); err != nil {
+		return fmt.Errorf("failed to enable auto-update: %w", err)
+	}
+	return nil
+}
+
+// DisableAutoUpdate turns off auto-update. A pause is forgotten, so that
+// resume does not turn it back on.
+func (c *Client) DisableAutoUpdate(ctx context.Context, ns, name string) error {
+	if err := c.setAutoUpdate(ctx, ns, name, map[string]interface{}{"enabled": false}, nil); err != nil {
+		return fmt.Errorf("failed to disable auto-update: %w", err)
+	}
+	return nil
+}
+
+// PauseAutoUpdate stops the operator from applying updates, e.g. for a
+// manual upgrade or an investigation. Auto-update is turned off through
+// spec.autoUpdate.enabled, keeping its other settings, and marked as paused
+// for ResumeAutoUpdate.
+func (c *Client) PauseAutoUpdate(ctx context.Context, ns, name string) error {
+	if err := c.requireAutoUpdate(ctx, ns, name); err != nil {
+		return err
+	}
+	if err := c.setAutoUpdate(ctx, ns, name, map[string]interface{}{"enabled": false}, "true"); err != nil {
+		return fmt.Errorf("failed to pause auto-update: %w", err)
+	}
+	return nil
+}
+
+// ResumeAutoUpdate turns auto-update back on after PauseAutoUpdate.
+func (c *Client) ResumeAutoUpdate(ctx context.Context, ns, name string) error {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+	if !AutoUpdatePaused(inst) {
+		return fmt.Errorf("auto-update is not paused for %s/%s", ns, name)
+	}
+	if err := c.setAutoUpdate(ctx, ns, name, map[string]interface{}{"enabled": true}, nil); err != nil {
+		return fmt.Errorf("failed to resume auto-update: %w", err)
+	}
+	return nil
+}
+
+// setAutoUpdate patches spec.autoUpdate and sets the pause annotation to
+// paused, or removes it when paused is nil.
+func (c *Client) setAutoUpdate(ctx context.Context, ns, name string, spec map[string]interface{}, paused interface{}) error {
+	patch := specPatch(map[string]interface{}{"autoUpdate": spec})
+	patch["metadata"] = map[string]interface{}{
+		"annotations": map[string]interface{}{AutoUpdatePausedAnnotation: paused},
+	}
+	_, err := c.patchInstance(ctx, ns, name, patch)
+	return err
+}
+
+// RequestAutoUpdateCheck asks the operator to check for a new version now.
+// It is only a request: an operator that does not act on the annotation
+// checks at the next interval as usual.
+func (c *Client) RequestAutoUpdateCheck(ctx context.Context, ns, name string) error {
+	if err := c.requireAutoUpdate(ctx, ns, name); err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	return c.annotate(ctx, ns, name, AutoUpdateCheckAnnotation, now, "request an update check")
+}
+
+func (c *Client) requireAutoUpdate(ctx context.Context, ns, name string) error {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+	if !inst.Spec.AutoUpdateEnabled() {
+		return fmt.Errorf("auto-update is not enabled for %s/%s", ns, name)
+	}
+	return nil
+}
+
+// annotate sets an annotation, or removes it when value is nil.
+func (c *Client) annotate(ctx context.Context, ns, name, key string, value interface{}, what string) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{key: value},
+		},
+	}
+	if _, err := c.patchInstance(ctx, ns, name, patch); err != nil {
+		return fmt.Errorf("failed to %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+var (
+	// ErrBackupInProgress is returned by StartBackup when a backup job for the
+	// instance is already running.
+	ErrBackupInProgress = errors.New("backup already in progress")
+	// ErrNoBackupCronJob is returned by StartBackup when the operator has not
+	// created a backup CronJob for the instance.
+	ErrNoBackupCronJob = errors.New("instance has no backup CronJob")
+)
+
+// StartBackup creates a one-off Job from the instance's backup CronJob, the
+// same way "kubectl create job --from=cronjob" does, and returns it.
+func (c *Client) StartBackup(ctx context.Context, ns, name string) (*batchv1.Job, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if inst.Status.BackupJobName != "" {
+		return nil, fmt.Errorf("%w: job %s is running for %s/%s", ErrBackupInProgress, inst.Status.BackupJobName, ns, name)
+	}
+	cronJobName := inst.Status.Managed().BackupCronJob
+	if cronJobName == "" {
+		return nil, fmt.Errorf("%w: %s/%s (is spec.backup.schedule set?)", ErrNoBackupCronJob, ns, name)
+	}
+
+	cronJob, err := c.clients.Kube.BatchV1().CronJobs(ns).Get(ctx, cronJobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup CronJob %s: %w", cronJobName, err)
+	}
+	if len(cronJob.Status.Active) > 0 {
+		return nil, fmt.Errorf("%w: job %s is running for %s/%s", ErrBackupInProgress, cronJob.Status.Active[0].Name, ns, name)
+	}
+
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        manualJobName(cronJobName, time.Now()),
+			Namespace:   ns,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+
+	created, err := c.clients.Kube.BatchV1().Jobs(ns).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup job: %w", err)
+	}
+	return created, nil
+}
+
+// manualJobName derives a job name from the CronJob name, kept within the 63
+// characters allowed for the job-name label.
+func manualJobName(cronJobName string, now time.Time) string {
+	suffix := fmt.Sprintf("-manual-%d", now.Unix())
+	if max := 63 - len(suffix); len(cronJobName) > max {
+		cronJobName = strings.TrimRight(cronJobName[:max], "-")
+	}
+	return cronJobName + suffix
+}
+
+// WaitForBackupRecorded blocks until the operator has recorded a backup taken
+// at or after since in the instance status.
+func (c *Client) WaitForBackupRecorded(ctx context.Context, ns, name string, since time.Time) (*v1alpha1.OpenClawInstance, error) {
+	// lastBackupTime has second precision.
+	since = since.Truncate(time.Second)
+	var inst *v1alpha1.OpenClawInstance
+	check := func(event watch.Event) (bool, error) {
+		obj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok || event.Type == watch.Deleted {
+			return false, nil
+		}
+		decoded, err := v1alpha1.FromUnstructured(obj)
+		if err != nil {
+			return false, err
+		}
+		inst = decoded
+		t := inst.Status.LastBackupTime
+		return t != nil && !t.Time.Before(since) && inst.Status.LastBackupPath != "", nil
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, c.instanceListWatch(ctx, ns, name), &unstructured.Unstructured{}, nil, check)
+	if err != nil && ctx.Err() != nil {
+		return inst, fmt.Errorf("timed out waiting for the backup of %s/%s to be recorded", ns, name)
+	}
+	return inst, err
+}
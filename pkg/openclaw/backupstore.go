@@ -0,0 +1,335 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/s3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupCredentialsSecret is the Secret the operator reads its object store
+// settings from, in the operator's namespace.
+const BackupCredentialsSecret = "s3-backup-credentials"
+
+// OperatorNamespaces are the namespaces the operator is installed into by
+// its Helm chart and kustomize manifests.
+var OperatorNamespaces = []string{"openclaw-operator-system", "openclaw-system"}
+
+// Environment variable and Secret keys recognised for each setting, in order
+// of preference.
+var (
+	endpointKeys     = []string{"S3_ENDPOINT", "AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL", "RCLONE_S3_ENDPOINT"}
+	bucketKeys       = []string{"S3_BUCKET", "BUCKET_NAME", "BUCKET"}
+	regionKeys       = []string{"S3_REGION", "AWS_REGION", "AWS_DEFAULT_REGION", "RCLONE_S3_REGION"}
+	accessKeyKeys    = []string{"S3_ACCESS_KEY_ID", "AWS_ACCESS_KEY_ID", "RCLONE_S3_ACCESS_KEY_ID"}
+	secretKeyKeys    = []string{"S3_SECRET_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY", "RCLONE_S3_SECRET_ACCESS_KEY"}
+	sessionTokenKeys = []string{"S3_SESSION_TOKEN", "AWS_SESSION_TOKEN"}
+)
+
+// BackupStoreOptions override parts of the backup destination. Empty fields
+// are resolved from the cluster.
+type BackupStoreOptions struct {
+	Endpoint string
+	Bucket   string
+	Region   string
+	// Prefix is the key prefix holding the instance's backups. By default it
+	// is the parent of status.lastBackupPath.
+	Prefix string
+	// CredentialsSecret is NAME or NAMESPACE/NAME of a Secret with the keys
+	// the operator uses, e.g. S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY.
+	CredentialsSecret string
+}
+
+// BackupStore is the object store location holding an instance's backups.
+type BackupStore struct {
+	Client *s3.Client
+	Bucket string
+	// Prefix ends in a slash; every backup is one entry below it. It is empty
+	// when it could not be determined.
+	Prefix string
+
+	instance string
+}
+
+// Backup is one backup in the store. A backup is either a key prefix with
+// several objects or a single archive object.
+type Backup struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Time    time.Time `json:"time"`
+	Size    int64     `json:"size"`
+	Objects int       `json:"objects"`
+}
+
+// BackupStore resolves where an instance's backups live and how to access
+// them. Settings come from opts, then from the environment of the backup
+// CronJob's containers, then from the operator's credentials Secret.
+func (c *Client) BackupStore(ctx context.Context, ns, name string, opts BackupStoreOptions) (*BackupStore, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sources in order of precedence.
+	var sources []map[string]string
+	if opts.CredentialsSecret != "" {
+		secretNS, secretName := ns, opts.CredentialsSecret
+		if i := strings.Index(secretName, "/"); i >= 0 {
+			secretNS, secretName = secretName[:i], secretName[i+1:]
+		}
+		secret, err := c.clients.Kube.CoreV1().Secrets(secretNS).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials secret %s/%s: %w", secretNS, secretName, err)
+		}
+		sources = append(sources, secretData(secret))
+	}
+	if cronJob := inst.Status.Managed().BackupCronJob; cronJob != "" {
+		env, err := c.cronJobEnv(ctx, ns, cronJob)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, env)
+	}
+	for _, opNS := range OperatorNamespaces {
+		secret, err := c.clients.Kube.CoreV1().Secrets(opNS).Get(ctx, BackupCredentialsSecret, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret %s/%s: %w", opNS, BackupCredentialsSecret, err)
+		}
+		sources = append(sources, secretData(secret))
+		break
+	}
+
+	cfg := s3.Config{
+		Endpoint: firstNonEmpty(opts.Endpoint, lookup(sources, endpointKeys)),
+		Region:   firstNonEmpty(opts.Region, lookup(sources, regionKeys)),
+	}
+	// Credentials are taken as a set from the first source that has them.
+	for _, src := range sources {
+		one := []map[string]string{src}
+		if id, secret := lookup(one, accessKeyKeys), lookup(one, secretKeyKeys); id != "" && secret != "" {
+			cfg.AccessKeyID, cfg.SecretAccessKey = id, secret
+			cfg.SessionToken = lookup(one, sessionTokenKeys)
+			break
+		}
+	}
+	bucket := firstNonEmpty(opts.Bucket, lookup(sources, bucketKeys))
+
+	prefix := opts.Prefix
+	if prefix == "" && inst.Status.LastBackupPath != "" {
+		lastBucket, key := SplitBackupPath(inst.Status.LastBackupPath)
+		if lastBucket != "" && opts.Bucket == "" {
+			bucket = lastBucket
+		}
+		prefix = path.Dir(strings.TrimSuffix(key, "/"))
+	}
+	if prefix == "." {
+		prefix = ""
+	}
+	if prefix != "" {
+		prefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+
+	client, err := s3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &BackupStore{Client: client, Bucket: bucket, Prefix: prefix, instance: ns + "/" + name}, nil
+}
+
+// List returns the backups below the store's prefix, newest first.
+func (s *BackupStore) List(ctx context.Context) ([]Backup, error) {
+	if s.Prefix == "" {
+		return nil, fmt.Errorf("no backup recorded for %s yet; pass the backup prefix explicitly", s.instance)
+	}
+	if s.Bucket == "" {
+		return nil, fmt.Errorf("could not determine the backup bucket for %s; pass it explicitly", s.instance)
+	}
+	objects, err := s.Client.List(ctx, s.Bucket, s.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*Backup{}
+	for _, o := range objects {
+		rel := strings.TrimPrefix(o.Key, s.Prefix)
+		entry := rel
+		if i := strings.Index(rel, "/"); i >= 0 {
+			entry = rel[:i]
+		}
+		if entry == "" {
+			continue
+		}
+		b, ok := byName[entry]
+		if !ok {
+			b = &Backup{Name: entry, Path: "s3://" + s.Bucket + "/" + s.Prefix + entry}
+			byName[entry] = b
+		}
+		b.Size += o.Size
+		b.Objects++
+		if o.LastModified.After(b.Time) {
+			b.Time = o.LastModified
+		}
+	}
+
+	backups := make([]Backup, 0, len(byName))
+	for _, b := range byName {
+		backups = append(backups, *b)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].Time.Equal(backups[j].Time) {
+			return backups[i].Time.After(backups[j].Time)
+		}
+		return backups[i].Name > backups[j].Name
+	})
+	return backups, nil
+}
+
+// Exists reports whether a backup path is present in the store. Paths
+// without a bucket refer to the store's bucket.
+func (s *BackupStore) Exists(ctx context.Context, path string) (bool, error) {
+	bucket, key := SplitBackupPath(path)
+	if bucket == "" {
+		bucket = s.Bucket
+	}
+	if bucket == "" {
+		return false, fmt.Errorf("could not determine the bucket of %s; pass it explicitly", path)
+	}
+	if key == "" {
+		return false, fmt.Errorf("invalid backup path %q", path)
+	}
+	return s.Client.Exists(ctx, bucket, key)
+}
+
+// SplitBackupPath splits "s3://bucket/key" into bucket and key. Paths
+// without the scheme are keys in the configured bucket.
+func SplitBackupPath(p string) (bucket, key string) {
+	if !strings.HasPrefix(p, "s3://") {
+		return "", strings.TrimPrefix(p, "/")
+	}
+	rest := strings.TrimPrefix(p, "s3://")
+	if i := strings.Index(rest, "/"); i >= 0 {
+		return rest[:i], rest[i+1:]
+	}
+	return rest, ""
+}
+
+// cronJobEnv collects the environment of the backup CronJob's containers,
+// resolving values taken from Secrets and ConfigMaps.
+func (c *Client) cronJobEnv(ctx context.Context, ns, name string) (map[string]string, error) {
+	cronJob, err := c.clients.Kube.BatchV1().CronJobs(ns).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup CronJob %s: %w", name, err)
+	}
+
+	env := map[string]string{}
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, container := range containers {
+		for _, from := range container.EnvFrom {
+			values, err := c.envFromSource(ctx, ns, from)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range values {
+				env[from.Prefix+k] = v
+			}
+		}
+		for _, e := range container.Env {
+			v, err := c.envValue(ctx, ns, e)
+			if err != nil {
+				return nil, err
+			}
+			if v != "" {
+				env[e.Name] = v
+			}
+		}
+	}
+	return env, nil
+}
+
+func (c *Client) envFromSource(ctx context.Context, ns string, from corev1.EnvFromSource) (map[string]string, error) {
+	switch {
+	case from.SecretRef != nil:
+		secret, err := c.clients.Kube.CoreV1().Secrets(ns).Get(ctx, from.SecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret %s: %w", from.SecretRef.Name, err)
+		}
+		return secretData(secret), nil
+	case from.ConfigMapRef != nil:
+		cm, err := c.clients.Kube.CoreV1().ConfigMaps(ns).Get(ctx, from.ConfigMapRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", from.ConfigMapRef.Name, err)
+		}
+		return cm.Data, nil
+	}
+	return nil, nil
+}
+
+func (c *Client) envValue(ctx context.Context, ns string, e corev1.EnvVar) (string, error) {
+	if e.ValueFrom == nil {
+		return e.Value, nil
+	}
+	switch {
+	case e.ValueFrom.SecretKeyRef != nil:
+		ref := e.ValueFrom.SecretKeyRef
+		secret, err := c.clients.Kube.CoreV1().Secrets(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get secret %s: %w", ref.Name, err)
+		}
+		return string(secret.Data[ref.Key]), nil
+	case e.ValueFrom.ConfigMapKeyRef != nil:
+		ref := e.ValueFrom.ConfigMapKeyRef
+		cm, err := c.clients.Kube.CoreV1().ConfigMaps(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get ConfigMap %s: %w", ref.Name, err)
+		}
+		return cm.Data[ref.Key], nil
+	}
+	return "", nil
+}
+
+func secretData(secret *corev1.Secret) map[string]string {
+	out := make(map[string]string, len(secret.Data)+len(secret.StringData))
+	for k, v := range secret.Data {
+		out[k] = string(v)
+	}
+	for k, v := range secret.StringData {
+		out[k] = v
+	}
+	return out
+}
+
+// lookup returns the first value set for any of keys, trying each source in
+// turn.
+func lookup(sources []map[string]string, keys []string) string {
+	for _, src := range sources {
+		for _, k := range keys {
+			if v := src[k]; v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+// Package openclaw is a Go SDK for managing OpenClaw instances. Every
+// operation the kubectl plugin performs is available here as a method on
+// Client, so other tools can embed it without shelling out.
+package openclaw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+type Client struct {
+	clients *kube.Clients
+}
+
+func NewClient(clients *kube.Clients) *Client {
+	return &Client{clients: clients}
+}
+
+// NewClientFromKubeconfig loads the given kubeconfig (or the default loading
+// rules when empty) and returns a Client for it.
+func NewClientFromKubeconfig(kubeconfig string) (*Client, error) {
+	clients, err := kube.NewClients(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(clients), nil
+}
+
+// Clients exposes the underlying Kubernetes clients for operations this
+// package does not wrap (exec, port-forward, log streaming).
+func (c *Client) Clients() *kube.Clients {
+	return c.clients
+}
+
+func (c *Client) instances(ns string) dynamic.ResourceInterface {
+	return c.clients.Dynamic.Resource(kube.OpenClawGVR).Namespace(ns)
+}
+
+// GetInstanceObject returns the instance exactly as stored, including fields
+// the typed API does not model.
+func (c *Client) GetInstanceObject(ctx context.Context, ns, name string) (*unstructured.Unstructured, error) {
+	obj, err := c.instances(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenClawInstance %s/%s: %w", ns, name, err)
+	}
+	return obj, nil
+}
+
+func (c *Client) GetInstance(ctx context.Context, ns, name string) (*v1alpha1.OpenClawInstance, error) {
+	obj, err := c.GetInstanceObject(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return v1alpha1.FromUnstructured(obj)
+}
+
+// ListOptions narrows ListInstances. An empty namespace lists across all namespaces.
+type ListOptions struct {
+	LabelSelector string
+}
+
+func (c *Client) ListInstanceObjects(ctx context.Context, ns string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	list, err := c.instances(ns).List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OpenClawInstances: %w", err)
+	}
+	return list, nil
+}
+
+func (c *Client) ListInstances(ctx context.Context, ns string, opts ListOptions) (*v1alpha1.OpenClawInstanceList, error) {
+	list, err := c.ListInstanceObjects(ctx, ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	return v1alpha1.ListFromUnstructured(list)
+}
+
+// Pods returns the pods belonging to an instance.
+func (c *Client) Pods(ctx context.Context, ns, name string) ([]corev1.Pod, error) {
+	pods, err := c.clients.Kube.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: PodLabelSelector(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	return pods.Items, nil
+}
+
+// DataClaim returns the PVC holding the instance's data, or nil when the
+// instance has no persistent storage.
+func (c *Client) DataClaim(ctx context.Context, inst *v1alpha1.OpenClawInstance) (*corev1.PersistentVolumeClaim, error) {
+	name := inst.Status.Managed().PVC
+	if name == "" {
+		name = inst.Spec.Storage.Persistence.ExistingClaim
+	}
+	if name == "" {
+		return nil, nil
+	}
+	pvc, err := c.clients.Kube.CoreV1().PersistentVolumeClaims(inst.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PVC %s: %w", name, err)
+	}
+	return pvc, nil
+}
+
+// PodLabelSelector selects the pods the operator creates for an instance.
+func PodLabelSelector(instanceName string) string {
+	return fmt.Sprintf("app.kubernetes.io/name=openclaw,app.kubernetes.io/instance=%s", instanceName)
+}
+
+// patchInstance applies a JSON merge patch to an instance and returns the result.
+func (c *Client) patchInstance(ctx context.Context, ns, name string, patch map[string]interface{}) (*v1alpha1.OpenClawInstance, error) {
+	return c.patchInstanceWithOptions(ctx, ns, name, patch, metav1.PatchOptions{})
+}
+
+// patchInstanceWithOptions is patchInstance with patch options, e.g. for a
+// server-side dry run.
+func (c *Client) patchInstanceWithOptions(ctx context.Context, ns, name string, patch map[string]interface{}, opts metav1.PatchOptions) (*v1alpha1.OpenClawInstance, error) {
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create patch: %w", err)
+	}
+	obj, err := c.instances(ns).Patch(ctx, name, types.MergePatchType, patchBytes, opts)
+	if err != nil {
+		return nil, err
+	}
+	return v1alpha1.FromUnstructured(obj)
+}
+
+// specPatch wraps fields in {"spec": fields}.
+func specPatch(fields map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"spec": fields}
+}
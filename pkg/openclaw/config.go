@@ -0,0 +1,80 @@
+package openclaw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigKey is the ConfigMap key holding the rendered openclaw.json.
+const ConfigKey = "openclaw.json"
+
+// EffectiveConfigMap returns the ConfigMap the operator renders for an
+// instance, which holds the configuration the agent actually runs with.
+func (c *Client) EffectiveConfigMap(ctx context.Context, ns, name string) (*corev1.ConfigMap, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	cmName := inst.Status.Managed().ConfigMap
+	if cmName == "" {
+		cmName = name
+	}
+
+	cm, err := c.clients.Kube.CoreV1().ConfigMaps(ns).Get(ctx, cmName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %q: %w", cmName, err)
+	}
+	return cm, nil
+}
+
+// EditableConfig returns indented JSON to start an edit from: the inline
+// spec.config.raw if set, otherwise the rendered config, otherwise "{}".
+func (c *Client) EditableConfig(ctx context.Context, ns, name string) ([]byte, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw := inst.Spec.Config.Raw; raw != nil && len(raw.Raw) > 0 {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, raw.Raw, "", "  "); err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	// Try to read from managed ConfigMap as starting point
+	if cmName := inst.Status.Managed().ConfigMap; cmName != "" {
+		cm, err := c.clients.Kube.CoreV1().ConfigMaps(ns).Get(ctx, cmName, metav1.GetOptions{})
+		if err == nil {
+			if data, ok := cm.Data[ConfigKey]; ok {
+				var parsed interface{}
+				if json.Unmarshal([]byte(data), &parsed) == nil {
+					if pretty, err := json.MarshalIndent(parsed, "", "  "); err == nil {
+						return pretty, nil
+					}
+				}
+			}
+		}
+	}
+	return []byte("{}\n"), nil
+}
+
+// PatchConfig merges config into spec.config.raw using JSON merge patch
+// semantics: keys set to nil are removed, other keys are added or replaced.
+func (c *Client) PatchConfig(ctx context.Context, ns, name string, config map[string]interface{}) error {
+	patch := specPatch(map[string]interface{}{
+		"config": map[string]interface{}{
+			"raw": config,
+		},
+	})
+	if _, err := c.patchInstance(ctx, ns, name, patch); err != nil {
+		return fmt.Errorf("failed to apply config: %w", err)
+	}
+	return nil
+}
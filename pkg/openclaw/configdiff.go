@@ -0,0 +1,224 @@
+package openclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigChangeType says how a config value changed.
+type ConfigChangeType string
+
+const (
+	ConfigAdded   ConfigChangeType = "Added"
+	ConfigRemoved ConfigChangeType = "Removed"
+	ConfigChanged ConfigChangeType = "Changed"
+)
+
+// ConfigChange is one difference between two configs.
+type ConfigChange struct {
+	// Path locates the value, e.g. "gateway.port" or "models[0].name".
+	Path string           `json:"path"`
+	Type ConfigChangeType `json:"type"`
+	Old  interface{}      `json:"old,omitempty"`
+	New  interface{}      `json:"new,omitempty"`
+}
+
+// DiffConfig compares two decoded JSON documents structurally. Objects are
+// compared key by key and arrays index by index, so a change deep inside the
+// config is reported at its own path. Changes are sorted by path.
+func DiffConfig(old, new interface{}) []ConfigChange {
+	var changes []ConfigChange
+	diffValue("", old, new, &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func diffValue(path string, old, new interface{}, changes *[]ConfigChange) {
+	switch o := old.(type) {
+	case map[string]interface{}:
+		if n, ok := new.(map[string]interface{}); ok {
+			for k, ov := range o {
+				if nv, ok := n[k]; ok {
+					diffValue(joinConfigPath(path, k), ov, nv, changes)
+				} else {
+					*changes = append(*changes, ConfigChange{Path: joinConfigPath(path, k), Type: ConfigRemoved, Old: ov})
+				}
+			}
+			for k, nv := range n {
+				if _, ok := o[k]; !ok {
+					*changes = append(*changes, ConfigChange{Path: joinConfigPath(path, k), Type: ConfigAdded, New: nv})
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if n, ok := new.([]interface{}); ok {
+			for i := 0; i < len(o) || i < len(n); i++ {
+				p := path + "[" + strconv.Itoa(i) + "]"
+				switch {
+				case i >= len(n):
+					*changes = append(*changes, ConfigChange{Path: p, Type: ConfigRemoved, Old: o[i]})
+				case i >= len(o):
+					*changes = append(*changes, ConfigChange{Path: p, Type: ConfigAdded, New: n[i]})
+				default:
+					diffValue(p, o[i], n[i], changes)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, ConfigChange{Path: path, Type: ConfigChanged, Old: old, New: new})
+	}
+}
+
+// joinConfigPath appends a key, quoting keys that would make the path
+// ambiguous.
+func joinConfigPath(path, key string) string {
+	if key == "" || strings.ContainsAny(key, ".[]\" ") {
+		key = strconv.Quote(key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// configMergePatch returns the JSON merge patch that turns old into new:
+// removed keys are set to nil.
+func configMergePatch(old, new map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k, ov := range old {
+		nv, ok := new[k]
+		if !ok {
+			patch[k] = nil
+			continue
+		}
+		om, oIsMap := ov.(map[string]interface{})
+		nm, nIsMap := nv.(map[string]interface{})
+		if oIsMap && nIsMap {
+			if sub := configMergePatch(om, nm); len(sub) > 0 {
+				patch[k] = sub
+			}
+		} else if !reflect.DeepEqual(ov, nv) {
+			patch[k] = nv
+		}
+	}
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			patch[k] = nv
+		}
+	}
+	return patch
+}
+
+// InlineConfig returns the instance's spec.config.raw decoded, or an empty
+// object if it has none.
+func InlineConfig(inst *v1alpha1.OpenClawInstance) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if raw := inst.Spec.Config.Raw; raw != nil && len(raw.Raw) > 0 {
+		if err := json.Unmarshal(raw.Raw, &config); err != nil {
+			return nil, fmt.Errorf("invalid spec.config.raw on %s/%s: %w", inst.Namespace, inst.Name, err)
+		}
+	}
+	return config, nil
+}
+
+// ErrConfigChanged is returned by ApplyConfig when the config changed after
+// it was read.
+var ErrConfigChanged = errors.New("the config was changed since it was read")
+
+// ApplyConfigOptions configures ApplyConfig.
+type ApplyConfigOptions struct {
+	// DryRun has the API server validate and admit the change, including the
+	// operator's webhook, without persisting it.
+	DryRun bool
+	// Base, if set, is the inline config the new config was based on, e.g.
+	// the one read before opening an editor. If spec.config.raw no longer
+	// matches it, the change is refused, so an edit made in the meantime is
+	// not overwritten. Other changes to the instance, like the operator's
+	// status updates, do not matter.
+	Base map[string]interface{}
+}
+
+// configApplyAttempts bounds how often ApplyConfig retries when the instance
+// changes between reading and patching it but the config does not.
+const configApplyAttempts = 5
+
+// ApplyConfigResult describes an applied config change.
+type ApplyConfigResult struct {
+	// Changes are the differences between the previous inline config and
+	// the one the API server accepted.
+	Changes []ConfigChange
+	// Config is the inline config as accepted by the API server.
+	Config map[string]interface{}
+}
+
+// ApplyConfig replaces spec.config.raw with config. Keys missing from config
+// are removed. The change is made against the resourceVersion read, so it
+// fails with ErrConfigChanged rather than overwriting a concurrent edit.
+// With opts.Base, the config is compared instead, and the change is retried
+// if only other parts of the instance changed.
+func (c *Client) ApplyConfig(ctx context.Context, ns, name string, config map[string]interface{}, opts ApplyConfigOptions) (*ApplyConfigResult, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := c.applyConfig(ctx, ns, name, config, opts)
+		if !errors.Is(err, errInstanceChanged) {
+			return result, err
+		}
+		if opts.Base == nil || attempt == configApplyAttempts {
+			return nil, fmt.Errorf("%w: %v", ErrConfigChanged, err)
+		}
+	}
+}
+
+// errInstanceChanged marks a patch rejected because the instance changed
+// after it was read.
+var errInstanceChanged = errors.New("the instance was changed")
+
+func (c *Client) applyConfig(ctx context.Context, ns, name string, config map[string]interface{}, opts ApplyConfigOptions) (*ApplyConfigResult, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	current, err := InlineConfig(inst)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Base != nil && !reflect.DeepEqual(current, opts.Base) {
+		return nil, ErrConfigChanged
+	}
+
+	patch := specPatch(map[string]interface{}{
+		"config": map[string]interface{}{
+			"raw": configMergePatch(current, config),
+		},
+	})
+	patch["metadata"] = map[string]interface{}{"resourceVersion": inst.ResourceVersion}
+
+	patchOpts := metav1.PatchOptions{}
+	if opts.DryRun {
+		patchOpts.DryRun = []string{metav1.DryRunAll}
+	}
+	updated, err := c.patchInstanceWithOptions(ctx, ns, name, patch, patchOpts)
+	if apierrors.IsConflict(err) {
+		return nil, fmt.Errorf("%w: %v", errInstanceChanged, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply config: %w", err)
+	}
+	accepted, err := InlineConfig(updated)
+	if err != nil {
+		return nil, err
+	}
+	return &ApplyConfigResult{Changes: DiffConfig(current, accepted), Config: accepted}, nil
+}
@@ -0,0 +1,52 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApplyConfigBase(t *testing.T) {
+	const current = `{"gateway": {"port": 18789}}`
+	edited := decodeConfig(t, `{"gateway": {"port": 8080}}`)
+
+	t.Run("unchanged config", func(t *testing.T) {
+		c := newConfigTestClient(t, current)
+		// A change elsewhere on the instance, like an operator status
+		// update, does not stop the edit.
+		if _, err := c.patchInstance(context.TODO(), "ns", "agent", map[string]interface{}{
+			"metadata": map[string]interface{}{"labels": map[string]interface{}{"team": "a"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		result, err := c.ApplyConfig(context.TODO(), "ns", "agent", edited, ApplyConfigOptions{Base: decodeConfig(t, current)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result.Config, edited) {
+			t.Errorf("config = %v, want %v", result.Config, edited)
+		}
+	})
+
+	t.Run("changed config", func(t *testing.T) {
+		c := newConfigTestClient(t, current)
+		_, err := c.ApplyConfig(context.TODO(), "ns", "agent", edited, ApplyConfigOptions{
+			Base: decodeConfig(t, `{"gateway": {"port": 1}}`),
+		})
+		if !errors.Is(err, ErrConfigChanged) {
+			t.Fatalf("ApplyConfig: %v, want ErrConfigChanged", err)
+		}
+		inst, err := c.GetInstance(context.TODO(), "ns", "agent")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := InlineConfig(inst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := decodeConfig(t, current); !reflect.DeepEqual(got, want) {
+			t.Errorf("config = %v, want it unchanged: %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package openclaw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodConfigPath is where the agent reads openclaw.json inside its pod.
+const PodConfigPath = "/etc/openclaw/openclaw.json"
+
+// ConfigDrift compares the three places an instance's config lives: the CR
+// (spec.config.raw), the ConfigMap the operator renders from it, and the file
+// the running container reads.
+type ConfigDrift struct {
+	Generation         int64  `json:"generation"`
+	ObservedGeneration int64  `json:"observedGeneration"`
+	ConfigMap          string `json:"configMap"`
+	// Pending are values set in spec.config.raw that the ConfigMap does not
+	// hold yet; Old is the ConfigMap's value. The operator adds defaults, so
+	// only keys set in the CR are compared, and keys removed from the CR
+	// cannot be told apart from defaults.
+	Pending []ConfigChange `json:"pending,omitempty"`
+	// Pods compare each pod's file with the ConfigMap.
+	Pods []PodConfigDrift `json:"pods"`
+	// RestartRequired is set when a running pod's file differs from the
+	// ConfigMap: the agent reads its config at startup.
+	RestartRequired bool `json:"restartRequired"`
+}
+
+// PodConfigDrift is the difference between a pod's config file and the
+// ConfigMap; Old is the ConfigMap's value, New the file's.
+type PodConfigDrift struct {
+	Pod     string         `json:"pod"`
+	Changes []ConfigChange `json:"changes,omitempty"`
+	// Error is set when the file could not be read, e.g. the pod is not
+	// running.
+	Error string `json:"error,omitempty"`
+}
+
+// InSync reports whether the CR, the ConfigMap and every readable pod file
+// agree.
+func (d *ConfigDrift) InSync() bool {
+	if len(d.Pending) > 0 || d.ObservedGeneration < d.Generation {
+		return false
+	}
+	for _, p := range d.Pods {
+		if len(p.Changes) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// UnreadPods counts the pods whose config file could not be read, for which
+// the pod layer is unknown.
+func (d *ConfigDrift) UnreadPods() int {
+	n := 0
+	for _, p := range d.Pods {
+		if p.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// ConfigDrift reads the config from the CR, the managed ConfigMap and, via
+// exec, from each of the instance's pods, and reports where they differ.
+func (c *Client) ConfigDrift(ctx context.Context, ns, name string) (*ConfigDrift, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := InlineConfig(inst)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := c.EffectiveConfigMap(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	rendered := map[string]interface{}{}
+	if data, ok := cm.Data[ConfigKey]; ok {
+		if err := json.Unmarshal([]byte(data), &rendered); err != nil {
+			return nil, fmt.Errorf("invalid %s in ConfigMap %s: %w", ConfigKey, cm.Name, err)
+		}
+	}
+
+	drift := &ConfigDrift{
+		Generation:         inst.Generation,
+		ObservedGeneration: inst.Status.ObservedGeneration,
+		ConfigMap:          cm.Name,
+		Pending:            configNotRendered("", raw, rendered),
+		Pods:               []PodConfigDrift{},
+	}
+	sort.SliceStable(drift.Pending, func(i, j int) bool { return drift.Pending[i].Path < drift.Pending[j].Path })
+
+	pods, err := c.Pods(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		pd := PodConfigDrift{Pod: pod.Name}
+		if pod.Status.Phase != corev1.PodRunning {
+			pd.Error = fmt.Sprintf("pod is %s", pod.Status.Phase)
+			drift.Pods = append(drift.Pods, pd)
+			continue
+		}
+		data, err := c.ReadPodFile(ctx, ns, pod.Name, "", PodConfigPath)
+		if err != nil {
+			pd.Error = err.Error()
+			drift.Pods = append(drift.Pods, pd)
+			continue
+		}
+		var file interface{}
+		if err := json.Unmarshal(data, &file); err != nil {
+			pd.Error = fmt.Sprintf("invalid JSON in %s: %v", PodConfigPath, err)
+		} else {
+			pd.Changes = DiffConfig(rendered, file)
+			drift.RestartRequired = drift.RestartRequired || len(pd.Changes) > 0
+		}
+		drift.Pods = append(drift.Pods, pd)
+	}
+	return drift, nil
+}
+
+// configNotRendered lists values of raw that rendered does not hold,
+// descending into objects present in both.
+func configNotRendered(path string, raw, rendered map[string]interface{}) []ConfigChange {
+	var changes []ConfigChange
+	for k, rv := range raw {
+		p := joinConfigPath(path, k)
+		cv, ok := rendered[k]
+		if !ok {
+			changes = append(changes, ConfigChange{Path: p, Type: ConfigAdded, New: rv})
+			continue
+		}
+		rm, rIsMap := rv.(map[string]interface{})
+		cm, cIsMap := cv.(map[string]interface{})
+		if rIsMap && cIsMap {
+			changes = append(changes, configNotRendered(p, rm, cm)...)
+		} else if !reflect.DeepEqual(rv, cv) {
+			changes = append(changes, ConfigChange{Path: p, Type: ConfigChanged, Old: cv, New: rv})
+		}
+	}
+	return changes
+}
+
+// ReadPodFile returns the contents of a file in a pod's container, read
+// with cat. An empty container selects the pod's first container.
+func (c *Client) ReadPodFile(ctx context.Context, ns, pod, container, path string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	err := c.Exec(ctx, ns, pod, ExecOptions{
+		Container: container,
+		Command:   []string{"cat", path},
+		Stdout:    &stdout,
+		Stderr:    &stderr,
+	})
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to read %s in pod %s: %s", path, pod, msg)
+		}
+		return nil, fmt.Errorf("failed to read %s in pod %s: %w", path, pod, err)
+	}
+	return stdout.Bytes(), nil
+}
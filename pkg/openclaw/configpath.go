@@ -0,0 +1,208 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrConfigPathNotFound is returned when a config path does not exist.
+var ErrConfigPathNotFound = errors.New("config path not found")
+
+// ConfigPath addresses a value in openclaw.json, one key or array index per
+// element.
+type ConfigPath []string
+
+// ParseConfigPath parses a dotted path such as "agents.defaults.model" or a
+// JSON Pointer such as "/agents/defaults/model". In dotted paths, keys that
+// contain dots or brackets can be quoted as Go strings, gateway."some.key",
+// with \" and \\ for quotes and backslashes, and array indices written
+// either way, models.0 or models[0]. String returns a path in this form.
+func ParseConfigPath(s string) (ConfigPath, error) {
+	if strings.HasPrefix(s, "/") {
+		var path ConfigPath
+		for _, seg := range strings.Split(s[1:], "/") {
+			path = append(path, strings.NewReplacer("~1", "/", "~0", "~").Replace(seg))
+		}
+		return path, nil
+	}
+
+	var path ConfigPath
+	rest := s
+	for {
+		if strings.HasPrefix(rest, `"`) {
+			end := closingQuote(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid config path %q: unterminated quote", s)
+			}
+			key, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid config path %q: bad escape in %s", s, rest[:end+1])
+			}
+			path = append(path, key)
+			rest = rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			rest = rest[end:]
+			if key == "" && !strings.HasPrefix(rest, "[") {
+				return nil, fmt.Errorf("invalid config path %q: empty key", s)
+			}
+			if key != "" {
+				path = append(path, key)
+			}
+		}
+		// Array indices may also be written as in the diff: models[0].
+		for strings.HasPrefix(rest, "[") {
+			idx, after, ok := strings.Cut(rest[1:], "]")
+			if !ok {
+				return nil, fmt.Errorf("invalid config path %q: unterminated [", s)
+			}
+			if n, err := strconv.Atoi(idx); err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid config path %q: bad index %q", s, idx)
+			}
+			path = append(path, idx)
+			rest = after
+		}
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, ".") {
+			return nil, fmt.Errorf("invalid config path %q: expected . before %q", s, rest)
+		}
+		rest = rest[1:]
+	}
+	return path, nil
+}
+
+// closingQuote returns the index of the quote ending the quoted string s
+// starts with, skipping escaped quotes, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func (p ConfigPath) String() string {
+	s := ""
+	for _, seg := range p {
+		s = joinConfigPath(s, seg)
+	}
+	return s
+}
+
+// Lookup returns the value at path in a decoded config. Array elements are
+// addressed by index.
+func (p ConfigPath) Lookup(config interface{}) (interface{}, error) {
+	v := config
+	for i, seg := range p {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[seg]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrConfigPathNotFound, p[:i+1])
+			}
+			v = next
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("%w: %s", ErrConfigPathNotFound, p[:i+1])
+			}
+			v = node[idx]
+		default:
+			return nil, fmt.Errorf("%w: %s is not an object", ErrConfigPathNotFound, p[:i])
+		}
+	}
+	return v, nil
+}
+
+// ConfigValue is a value to set at a path; a nil Value removes the key.
+type ConfigValue struct {
+	Path  ConfigPath
+	Value interface{}
+}
+
+// SetConfigValues sets and removes values in spec.config.raw with a single
+// merge patch, leaving the rest of the config alone. A value replaces what
+// is at its path, objects included: keys of an object being replaced that
+// the new object does not have are removed. As in any merge patch, a null
+// inside an object removes that key rather than setting null. Paths must
+// lead through objects: a merge patch replaces arrays as a whole, so set the
+// whole array instead of one of its elements. Removing a key that is not set
+// fails with ErrConfigPathNotFound.
+func (c *Client) SetConfigValues(ctx context.Context, ns, name string, values []ConfigValue) error {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+	current, err := InlineConfig(inst)
+	if err != nil {
+		return err
+	}
+
+	raw := map[string]interface{}{}
+	for _, cv := range values {
+		if len(cv.Path) == 0 {
+			return fmt.Errorf("empty config path")
+		}
+		// Check the path against the current config: every parent must be an
+		// object or missing, and a key to remove must exist.
+		var node interface{} = current
+		for i, seg := range cv.Path {
+			obj, ok := node.(map[string]interface{})
+			if !ok {
+				if node == nil && cv.Value != nil {
+					break
+				}
+				if node == nil {
+					return fmt.Errorf("%w: %s", ErrConfigPathNotFound, cv.Path)
+				}
+				return fmt.Errorf("cannot set %s: %s is not an object", cv.Path, cv.Path[:i])
+			}
+			node = obj[seg]
+			if _, exists := obj[seg]; !exists && cv.Value == nil {
+				return fmt.Errorf("%w: %s", ErrConfigPathNotFound, cv.Path)
+			}
+		}
+
+		patch := raw
+		for _, seg := range cv.Path[:len(cv.Path)-1] {
+			next, ok := patch[seg].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				patch[seg] = next
+			}
+			patch = next
+		}
+		// node is now the current value at the path, if any. A merge patch
+		// merges objects, so turn an object into the patch that replaces
+		// the current one.
+		value := cv.Value
+		if obj, ok := value.(map[string]interface{}); ok {
+			if old, ok := node.(map[string]interface{}); ok {
+				value = configMergePatch(old, obj)
+			}
+		}
+		patch[cv.Path[len(cv.Path)-1]] = value
+	}
+
+	patch := specPatch(map[string]interface{}{
+		"config": map[string]interface{}{"raw": raw},
+	})
+	patch["metadata"] = map[string]interface{}{"resourceVersion": inst.ResourceVersion}
+	if _, err := c.patchInstance(ctx, ns, name, patch); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	return nil
+}
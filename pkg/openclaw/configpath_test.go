@@ -0,0 +1,277 @@
+package openclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dfake "k8s.io/client-go/dynamic/fake"
+	kfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseConfigPath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ConfigPath
+		wantErr bool
+	}{
+		{in: "agents.defaults.model", want: ConfigPath{"agents", "defaults", "model"}},
+		{in: "gateway", want: ConfigPath{"gateway"}},
+
+		// Quoting.
+		{in: `gateway."some.key"`, want: ConfigPath{"gateway", "some.key"}},
+		{in: `"a.b".c`, want: ConfigPath{"a.b", "c"}},
+		{in: `models."gpt-4.1".enabled`, want: ConfigPath{"models", "gpt-4.1", "enabled"}},
+		{in: `a."[0]"`, want: ConfigPath{"a", "[0]"}},
+		{in: `a.""`, want: ConfigPath{"a", ""}},
+		{in: `a."b c"`, want: ConfigPath{"a", "b c"}},
+
+		// Escaping inside quotes.
+		{in: `a."say \"hi\""`, want: ConfigPath{"a", `say "hi"`}},
+		{in: `a."back\\slash"`, want: ConfigPath{"a", `back\slash`}},
+		{in: `a."ends with \\"`, want: ConfigPath{"a", `ends with \`}},
+		{in: `a."ü"`, want: ConfigPath{"a", "ü"}},
+
+		// Array segments.
+		{in: "models.0.name", want: ConfigPath{"models", "0", "name"}},
+		{in: "models[0].name", want: ConfigPath{"models", "0", "name"}},
+		{in: "models[0][1]", want: ConfigPath{"models", "0", "1"}},
+		{in: "[2].name", want: ConfigPath{"2", "name"}},
+		{in: `"a.b"[3]`, want: ConfigPath{"a.b", "3"}},
+
+		// JSON Pointer.
+		{in: "/agents/defaults/model", want: ConfigPath{"agents", "defaults", "model"}},
+		{in: "/a~1b/c~0d", want: ConfigPath{"a/b", "c~d"}},
+		{in: "/a~01", want: ConfigPath{"a~1"}},
+		{in: "/models/0", want: ConfigPath{"models", "0"}},
+		{in: "/", want: ConfigPath{""}},
+
+		// Errors.
+		{in: "", wantErr: true},
+		{in: "a..b", wantErr: true},
+		{in: "a.", wantErr: true},
+		{in: ".a", wantErr: true},
+		{in: `a."b`, wantErr: true},
+		{in: `a."b\"`, wantErr: true},
+		{in: `a."b"c`, wantErr: true},
+		{in: `a."\q"`, wantErr: true},
+		{in: "models[x]", wantErr: true},
+		{in: "models[-1]", wantErr: true},
+		{in: "models[0", wantErr: true},
+		{in: "models[0]x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseConfigPath(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseConfigPath(%q) = %q, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseConfigPath(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseConfigPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConfigPathStringRoundTrip(t *testing.T) {
+	for _, path := range []ConfigPath{
+		{"agents", "defaults", "model"},
+		{"gateway", "some.key"},
+		{"a", `say "hi"`},
+		{"a", `back\slash`},
+		{"a", "[0]"},
+		{"models", "0", "name"},
+		{"a", "b c"},
+	} {
+		got, err := ParseConfigPath(path.String())
+		if err != nil {
+			t.Errorf("ParseConfigPath(%q): %v", path.String(), err)
+			continue
+		}
+		if !reflect.DeepEqual(got, path) {
+			t.Errorf("ParseConfigPath(%q) = %q, want %q", path.String(), got, path)
+		}
+	}
+}
+
+func TestConfigPathLookup(t *testing.T) {
+	config := decodeConfig(t, `{"gateway": {"port": 18789, "some.key": "x"}, "models": [{"name": "a"}, {"name": "b"}]}`)
+	tests := []struct {
+		path    ConfigPath
+		want    interface{}
+		wantErr bool
+	}{
+		{path: ConfigPath{"gateway", "port"}, want: float64(18789)},
+		{path: ConfigPath{"gateway", "some.key"}, want: "x"},
+		{path: ConfigPath{"models", "1", "name"}, want: "b"},
+		{path: ConfigPath{"gateway", "missing"}, wantErr: true},
+		{path: ConfigPath{"models", "2"}, wantErr: true},
+		{path: ConfigPath{"models", "x"}, wantErr: true},
+		{path: ConfigPath{"gateway", "port", "deeper"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tt.path.Lookup(config)
+		if tt.wantErr {
+			if !errors.Is(err, ErrConfigPathNotFound) {
+				t.Errorf("Lookup(%s) = %v, %v, want ErrConfigPathNotFound", tt.path, got, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Lookup(%s) = %v, %v, want %v", tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetConfigValues(t *testing.T) {
+	const current = `{
+		"gateway": {"port": 18789, "auth": {"enabled": true, "mode": "token"}},
+		"agents": {"defaults": {"model": "a", "tools": {"web": true, "shell": true}}},
+		"models": [{"name": "a"}]
+	}`
+	tests := []struct {
+		name    string
+		values  []ConfigValue
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "set a scalar",
+			values: []ConfigValue{{Path: ConfigPath{"gateway", "port"}, Value: float64(8080)}},
+			want: `{
+				"gateway": {"port": 8080, "auth": {"enabled": true, "mode": "token"}},
+				"agents": {"defaults": {"model": "a", "tools": {"web": true, "shell": true}}},
+				"models": [{"name": "a"}]
+			}`,
+		},
+		{
+			name:   "an object replaces the current one",
+			values: []ConfigValue{{Path: ConfigPath{"agents", "defaults", "tools"}, Value: map[string]interface{}{"web": false}}},
+			want: `{
+				"gateway": {"port": 18789, "auth": {"enabled": true, "mode": "token"}},
+				"agents": {"defaults": {"model": "a", "tools": {"web": false}}},
+				"models": [{"name": "a"}]
+			}`,
+		},
+		{
+			name: "nested objects are replaced too",
+			values: []ConfigValue{{Path: ConfigPath{"gateway"}, Value: map[string]interface{}{
+				"auth": map[string]interface{}{"enabled": false},
+			}}},
+			want: `{
+				"gateway": {"auth": {"enabled": false}},
+				"agents": {"defaults": {"model": "a", "tools": {"web": true, "shell": true}}},
+				"models": [{"name": "a"}]
+			}`,
+		},
+		{
+			name:   "arrays are replaced",
+			values: []ConfigValue{{Path: ConfigPath{"models"}, Value: []interface{}{map[string]interface{}{"name": "b"}}}},
+			want: `{
+				"gateway": {"port": 18789, "auth": {"enabled": true, "mode": "token"}},
+				"agents": {"defaults": {"model": "a", "tools": {"web": true, "shell": true}}},
+				"models": [{"name": "b"}]
+			}`,
+		},
+		{
+			name: "new keys and parents are created, keys removed",
+			values: []ConfigValue{
+				{Path: ConfigPath{"channels", "some.key", "enabled"}, Value: true},
+				{Path: ConfigPath{"gateway", "auth"}},
+			},
+			want: `{
+				"gateway": {"port": 18789},
+				"agents": {"defaults": {"model": "a", "tools": {"web": true, "shell": true}}},
+				"models": [{"name": "a"}],
+				"channels": {"some.key": {"enabled": true}}
+			}`,
+		},
+		{
+			name:    "removing a missing key",
+			values:  []ConfigValue{{Path: ConfigPath{"gateway", "missing"}}},
+			wantErr: ErrConfigPathNotFound,
+		},
+		{
+			name:    "removing below a missing parent",
+			values:  []ConfigValue{{Path: ConfigPath{"missing", "key"}}},
+			wantErr: ErrConfigPathNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newConfigTestClient(t, current)
+			err := c.SetConfigValues(context.TODO(), "ns", "agent", tt.values)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SetConfigValues: %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			inst, err := c.GetInstance(context.TODO(), "ns", "agent")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := InlineConfig(inst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := decodeConfig(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("config = %v\nwant %v", got, want)
+			}
+		})
+	}
+
+	t.Run("path through a non-object", func(t *testing.T) {
+		c := newConfigTestClient(t, current)
+		err := c.SetConfigValues(context.TODO(), "ns", "agent", []ConfigValue{
+			{Path: ConfigPath{"models", "0", "name"}, Value: "b"},
+		})
+		if err == nil {
+			t.Fatal("setting inside an array succeeded")
+		}
+	})
+}
+
+func decodeConfig(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &config); err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+// newConfigTestClient returns a client for a fake cluster holding one
+// instance, ns/agent, with config as its spec.config.raw.
+func newConfigTestClient(t *testing.T, config string) *Client {
+	t.Helper()
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&struct {
+		Config map[string]interface{} `json:"config"`
+	}{decodeConfig(t, config)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "openclaw.rocks/v1alpha1",
+		"kind":       "OpenClawInstance",
+		"metadata":   map[string]interface{}{"name": "agent", "namespace": "ns"},
+		"spec":       map[string]interface{}{"config": map[string]interface{}{"raw": raw["config"]}},
+	}}
+	dyn := dfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{kube.OpenClawGVR: "OpenClawInstanceList"}, obj)
+	return NewClient(&kube.Clients{Kube: kfake.NewSimpleClientset(), Dynamic: dyn})
+}
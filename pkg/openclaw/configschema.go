@@ -0,0 +1,187 @@
+package openclaw
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/jsonschema"
+)
+
+// bundledConfigSchema describes openclaw.json. It types the well-known
+// sections and keys. Keys it does not describe, which may be typos or keys
+// added by newer OpenClaw versions, are reported as unknown keys, which
+// "--validate=warn" only warns about.
+//
+//go:embed schema/openclaw.schema.json
+var bundledConfigSchema []byte
+
+// ConfigSchema returns the JSON Schema to validate openclaw.json with: the
+// file at path if set, otherwise the bundled one.
+func ConfigSchema(path string) (*jsonschema.Schema, error) {
+	if path == "" {
+		return jsonschema.Parse(bundledConfigSchema)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	schema, err := jsonschema.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// ConfigError is a problem in a config document.
+type ConfigError struct {
+	Path ConfigPath `json:"path"`
+	// Line is the 1-based line of the offending key or value, or 0 if not
+	// known.
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ConfigError) Error() string {
+	var b strings.Builder
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", e.Line)
+	}
+	if len(e.Path) > 0 {
+		b.WriteString(e.Path.String() + ": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// ValidateConfig parses a config document and checks it against schema.
+// Syntax errors and schema violations are returned with the line they are
+// on, so they can be shown next to the text that was edited.
+func ValidateConfig(schema *jsonschema.Schema, data []byte) (map[string]interface{}, []ConfigError) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, []ConfigError{syntaxError(data, err)}
+	}
+	if config == nil {
+		return nil, []ConfigError{{Line: 1, Message: "the configuration must be a JSON object"}}
+	}
+	if schema == nil {
+		return config, nil
+	}
+
+	violations := schema.Validate(config)
+	if len(violations) == 0 {
+		return config, nil
+	}
+	lines := configLines(data)
+	errs := make([]ConfigError, len(violations))
+	for i, v := range violations {
+		errs[i] = ConfigError{Path: ConfigPath(v.Path), Line: lines[strings.Join(v.Path, "\x00")], Message: v.Message}
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	return config, errs
+}
+
+func syntaxError(data []byte, err error) ConfigError {
+	var syntax *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntax):
+		return ConfigError{Line: lineAt(data, syntax.Offset), Message: "invalid JSON: " + syntax.Error()}
+	case errors.As(err, &typeErr):
+		return ConfigError{Line: lineAt(data, typeErr.Offset), Message: "the configuration must be a JSON object"}
+	}
+	return ConfigError{Message: "invalid JSON: " + err.Error()}
+}
+
+// configLines maps each key and array element of a JSON document, by its
+// path joined with NUL, to the line it starts on. The document itself is on
+// line 1.
+func configLines(data []byte) map[string]int {
+	type frame struct {
+		object bool
+		key    string
+		index  int
+	}
+	lines := map[string]int{"": 1}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var stack []frame
+	wantKey := false
+
+	pathOf := func(last string) string {
+		parts := make([]string, 0, len(stack))
+		for _, f := range stack[:len(stack)-1] {
+			if f.object {
+				parts = append(parts, f.key)
+			} else {
+				parts = append(parts, fmt.Sprint(f.index))
+			}
+		}
+		return strings.Join(append(parts, last), "\x00")
+	}
+	// valueDone advances the enclosing frame after a complete value.
+	valueDone := func() {
+		if len(stack) == 0 {
+			return
+		}
+		if top := &stack[len(stack)-1]; top.object {
+			wantKey = true
+		} else {
+			top.index++
+		}
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		offset := dec.InputOffset()
+
+		if wantKey {
+			if key, ok := tok.(string); ok {
+				stack[len(stack)-1].key = key
+				lines[pathOf(key)] = lineAt(data, offset)
+				wantKey = false
+				continue
+			}
+		}
+		if len(stack) > 0 && !stack[len(stack)-1].object {
+			if d, ok := tok.(json.Delim); !ok || d == '{' || d == '[' {
+				lines[pathOf(fmt.Sprint(stack[len(stack)-1].index))] = lineAt(data, offset)
+			}
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, frame{object: true})
+			wantKey = true
+		case json.Delim('['):
+			stack = append(stack, frame{})
+			wantKey = false
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			wantKey = false
+			valueDone()
+		default:
+			valueDone()
+		}
+	}
+	return lines
+}
+
+// lineAt returns the 1-based line of a byte offset.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
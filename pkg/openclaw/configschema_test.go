@@ -0,0 +1,73 @@
+package openclaw
+
+import (
+	"testing"
+)
+
+func TestBundledConfigSchema(t *testing.T) {
+	schema, err := ConfigSchema("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "top-level typo",
+			doc: `{
+  "gatway": {"port": 18789}
+}`,
+			want: []string{`line 2: gatway: unknown key (did you mean "gateway"?)`},
+		},
+		{
+			name: "typo in a section",
+			doc: `{
+  "gateway": {
+    "prot": 18789
+  }
+}`,
+			want: []string{`line 3: gateway.prot: unknown key (did you mean "port"?)`},
+		},
+		{
+			name: "open maps take any key",
+			doc:  `{"channels": {"telegram": {"enabled": true}}, "skills": {"entries": {"weather": {"enabled": false}}}}`,
+		},
+		{
+			name: "well-known keys are typed",
+			doc: `{
+  "logging": {"level": 3}
+}`,
+			want: []string{"line 2: logging.level: must be one of \"silent\", \"fatal\", \"error\", \"warn\", \"info\", \"debug\", \"trace\""},
+		},
+		{
+			name: "invalid JSON",
+			doc:  "{\n  \"a\": 1,\n}",
+			want: []string{"line 3: invalid JSON: invalid character '}' looking for beginning of object key string"},
+		},
+		{
+			name: "not an object",
+			doc:  `[1]`,
+			want: []string{"line 1: the configuration must be a JSON object"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ValidateConfig(schema, []byte(tt.doc))
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("errors = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("error %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetEnv sets plain-value environment variables, replacing any existing
+// variable with the same name. New variables are appended in name order.
+func (c *Client) SetEnv(ctx context.Context, ns, name string, vars map[string]string) error {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+
+	// Update existing or build new list
+	updated := make(map[string]bool)
+	envList := []corev1.EnvVar{}
+	for _, e := range inst.Spec.Env {
+		if val, found := vars[e.Name]; found {
+			envList = append(envList, corev1.EnvVar{Name: e.Name, Value: val})
+			updated[e.Name] = true
+		} else {
+			envList = append(envList, e)
+		}
+	}
+
+	// Add new vars that weren't updates
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		if !updated[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		envList = append(envList, corev1.EnvVar{Name: k, Value: vars[k]})
+	}
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"env": envList})); err != nil {
+		return fmt.Errorf("failed to update env vars: %w", err)
+	}
+	return nil
+}
+
+// UnsetEnv removes environment variables by name and returns how many were removed.
+func (c *Client) UnsetEnv(ctx context.Context, ns, name string, keys ...string) (int, error) {
+	toRemove := make(map[string]bool)
+	for _, k := range keys {
+		toRemove[k] = true
+	}
+
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return 0, err
+	}
+
+	filtered := []corev1.EnvVar{}
+	removed := 0
+	for _, e := range inst.Spec.Env {
+		if toRemove[e.Name] {
+			removed++
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+
+	if removed == 0 {
+		return 0, nil
+	}
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"env": filtered})); err != nil {
+		return 0, fmt.Errorf("failed to update env vars: %w", err)
+	}
+	return removed, nil
+}
+
+// AddEnvSecret references a Secret in envFrom. It returns false if the Secret
+// was already referenced.
+func (c *Client) AddEnvSecret(ctx context.Context, ns, name, secretName string) (bool, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return false, err
+	}
+	existing := inst.Spec.EnvFrom
+
+	// Check if already referenced
+	for _, ef := range existing {
+		if ef.SecretRef != nil && ef.SecretRef.Name == secretName {
+			return false, nil
+		}
+	}
+
+	existing = append(existing, corev1.EnvFromSource{
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		},
+	})
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"envFrom": existing})); err != nil {
+		return false, fmt.Errorf("failed to update envFrom: %w", err)
+	}
+	return true, nil
+}
+
+// RemoveEnvSecret drops a Secret from envFrom. It returns false if the Secret
+// was not referenced.
+func (c *Client) RemoveEnvSecret(ctx context.Context, ns, name, secretName string) (bool, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return false, err
+	}
+
+	filtered := []corev1.EnvFromSource{}
+	found := false
+	for _, ef := range inst.Spec.EnvFrom {
+		if ef.SecretRef != nil && ef.SecretRef.Name == secretName {
+			found = true
+			continue
+		}
+		filtered = append(filtered, ef)
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"envFrom": filtered})); err != nil {
+		return false, fmt.Errorf("failed to update envFrom: %w", err)
+	}
+	return true, nil
+}
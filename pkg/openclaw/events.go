@@ -0,0 +1,80 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Events gathers the events for an instance, its pods and its StatefulSet or
+// Deployment, deduplicated and sorted oldest first. Only the instance's own
+// events are required; the rest are best effort.
+func (c *Client) Events(ctx context.Context, ns, name string) (*corev1.EventList, error) {
+	events, err := c.eventsFor(ctx, ns, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	if pods, err := c.Pods(ctx, ns, name); err == nil {
+		for _, pod := range pods {
+			if podEvents, err := c.eventsFor(ctx, ns, pod.Name); err == nil {
+				events = append(events, podEvents...)
+			}
+		}
+	}
+
+	// Get events for managed StatefulSet/Deployment
+	if inst, err := c.GetInstance(ctx, ns, name); err == nil {
+		managed := inst.Status.Managed()
+		for _, resName := range []string{managed.StatefulSet, managed.Deployment} {
+			if resName != "" {
+				if resEvents, err := c.eventsFor(ctx, ns, resName); err == nil {
+					events = append(events, resEvents...)
+				}
+			}
+		}
+	}
+
+	// Deduplicate by UID
+	seen := make(map[string]bool)
+	deduped := &corev1.EventList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+		Items:    []corev1.Event{},
+	}
+	for _, e := range events {
+		uid := string(e.UID)
+		if !seen[uid] {
+			seen[uid] = true
+			e.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Event"}
+			deduped.Items = append(deduped.Items, e)
+		}
+	}
+
+	sort.SliceStable(deduped.Items, func(i, j int) bool {
+		return EventTime(deduped.Items[i]).Before(EventTime(deduped.Items[j]))
+	})
+	return deduped, nil
+}
+
+func (c *Client) eventsFor(ctx context.Context, ns, objName string) ([]corev1.Event, error) {
+	list, err := c.clients.Kube.CoreV1().Events(ns).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s", objName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// EventTime returns the last time an event was seen, falling back to when it
+// was first seen for events that never repeated.
+func EventTime(e corev1.Event) time.Time {
+	if e.LastTimestamp.IsZero() {
+		return e.FirstTimestamp.Time
+	}
+	return e.LastTimestamp.Time
+}
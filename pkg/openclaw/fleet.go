@@ -0,0 +1,167 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InstanceLabel is set by the operator on every pod it creates for an
+// instance, with the instance name as value.
+const InstanceLabel = "app.kubernetes.io/instance"
+
+// fleetPodSelector matches the pods of every instance.
+const fleetPodSelector = "app.kubernetes.io/name=openclaw"
+
+const byInstance = "instance"
+
+// FleetCache is an informer-backed, continuously updated view of all
+// instances in a namespace (or the cluster), their pods and related events.
+// Reads never hit the API server.
+type FleetCache struct {
+	instances cache.SharedIndexInformer
+	pods      cache.SharedIndexInformer
+	events    cache.SharedIndexInformer
+}
+
+// WatchFleet starts informers for instances, pods and events in ns (all
+// namespaces when empty) and waits for them to sync. The returned channel
+// receives a coalesced notification after any change; it is not closed.
+// The informers stop when ctx is done.
+func (c *Client) WatchFleet(ctx context.Context, ns string) (*FleetCache, <-chan struct{}, error) {
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	pods := c.clients.Kube.CoreV1().Pods(ns)
+	events := c.clients.Kube.CoreV1().Events(ns)
+
+	f := &FleetCache{
+		instances: cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return c.instances(ns).List(ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return c.instances(ns).Watch(ctx, opts)
+			},
+		}, &unstructured.Unstructured{}, 0, cache.Indexers{}),
+		pods: cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.LabelSelector = fleetPodSelector
+				return pods.List(ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.LabelSelector = fleetPodSelector
+				return pods.Watch(ctx, opts)
+			},
+		}, &corev1.Pod{}, 0, cache.Indexers{byInstance: podInstanceIndex}),
+		events: cache.NewSharedIndexInformer(&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return events.List(ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return events.Watch(ctx, opts)
+			},
+		}, &corev1.Event{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(_, _ interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	}
+
+	informers := []cache.SharedIndexInformer{f.instances, f.pods, f.events}
+	synced := make([]cache.InformerSynced, 0, len(informers))
+	for _, inf := range informers {
+		if _, err := inf.AddEventHandler(handler); err != nil {
+			return nil, nil, fmt.Errorf("failed to watch instances: %w", err)
+		}
+		go inf.Run(ctx.Done())
+		synced = append(synced, inf.HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return nil, nil, fmt.Errorf("failed to watch instances: %w", ctx.Err())
+	}
+	return f, changed, nil
+}
+
+func podInstanceIndex(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+	return []string{pod.Namespace + "/" + pod.Labels[InstanceLabel]}, nil
+}
+
+// InstanceObjects returns the cached instances sorted by namespace and name.
+func (f *FleetCache) InstanceObjects() []*unstructured.Unstructured {
+	items := f.instances.GetStore().List()
+	out := make([]*unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(*unstructured.Unstructured); ok {
+			out = append(out, obj)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].GetNamespace() != out[j].GetNamespace() {
+			return out[i].GetNamespace() < out[j].GetNamespace()
+		}
+		return out[i].GetName() < out[j].GetName()
+	})
+	return out
+}
+
+// Instances returns the cached instances, typed, sorted by namespace and name.
+// Instances that fail to decode are skipped.
+func (f *FleetCache) Instances() []*v1alpha1.OpenClawInstance {
+	objs := f.InstanceObjects()
+	out := make([]*v1alpha1.OpenClawInstance, 0, len(objs))
+	for _, obj := range objs {
+		if inst, err := v1alpha1.FromUnstructured(obj); err == nil {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// Pods returns the cached pods of an instance, sorted by name.
+func (f *FleetCache) Pods(ns, name string) []corev1.Pod {
+	items, _ := f.pods.GetIndexer().ByIndex(byInstance, ns+"/"+name)
+	out := make([]corev1.Pod, 0, len(items))
+	for _, item := range items {
+		if pod, ok := item.(*corev1.Pod); ok {
+			out = append(out, *pod)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Events returns cached events about an instance and the objects the operator
+// created for it, oldest first.
+func (f *FleetCache) Events(ns, name string) []corev1.Event {
+	items, _ := f.events.GetIndexer().ByIndex(cache.NamespaceIndex, ns)
+	var out []corev1.Event
+	for _, item := range items {
+		if e, ok := item.(*corev1.Event); ok && eventRelatedTo(e, name) {
+			out = append(out, *e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return EventTime(out[i]).Before(EventTime(out[j]))
+	})
+	return out
+}
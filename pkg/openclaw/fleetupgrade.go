@@ -0,0 +1,210 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+)
+
+// ErrFleetUpgradeHalted is returned by FleetUpgrade when a batch failed.
+var ErrFleetUpgradeHalted = errors.New("fleet upgrade halted")
+
+// FleetUpgradeOptions configures FleetUpgrade.
+type FleetUpgradeOptions struct {
+	UpgradeOptions
+	// BatchSize is how many instances are upgraded before checking that all
+	// of them came up healthy. Defaults to 1.
+	BatchSize int
+	// MaxUnavailable is how many instances of a batch are upgraded at the same
+	// time. Defaults to BatchSize.
+	MaxUnavailable int
+	// Timeout bounds how long each instance may take to become ready.
+	Timeout time.Duration
+	// RollbackOnFailure rolls failed instances back to their previous image.
+	RollbackOnFailure bool
+	// OnProgress, if set, is called for every step, one call at a time.
+	OnProgress func(FleetUpgradeProgress)
+}
+
+// FleetUpgradeStep is what happened in a FleetUpgradeProgress.
+type FleetUpgradeStep string
+
+const (
+	StepBatchStarted FleetUpgradeStep = "BatchStarted"
+	StepUpgrading    FleetUpgradeStep = "Upgrading"
+	StepReady        FleetUpgradeStep = "Ready"
+	StepSkipped      FleetUpgradeStep = "Skipped"
+	StepFailed       FleetUpgradeStep = "Failed"
+	StepRolledBack   FleetUpgradeStep = "RolledBack"
+)
+
+// FleetUpgradeProgress reports one step of a fleet upgrade.
+type FleetUpgradeProgress struct {
+	Step    FleetUpgradeStep
+	Batch   int
+	Batches int
+	// Instances is set for StepBatchStarted, Instance for the other steps,
+	// both as namespace/name.
+	Instances []string
+	Instance  string
+	Previous  v1alpha1.ImageSpec
+	Current   v1alpha1.ImageSpec
+	Err       error
+}
+
+// FleetUpgradeResult lists instances, as namespace/name, by outcome.
+type FleetUpgradeResult struct {
+	Upgraded   []string
+	Skipped    []string
+	Failed     []string
+	RolledBack []string
+	// NotAttempted were left alone because an earlier instance failed.
+	NotAttempted []string
+}
+
+// FleetUpgrade upgrades instances in batches. Each instance of a batch must
+// reach Running with Ready=True before the next batch starts; the first
+// batches thereby act as canaries for the rest. When an instance fails or
+// times out, no further instances are started, failed instances are
+// optionally rolled back, and ErrFleetUpgradeHalted is returned along with
+// the result. Instances already on the target image are skipped.
+func (c *Client) FleetUpgrade(ctx context.Context, instances []*v1alpha1.OpenClawInstance, opts FleetUpgradeOptions) (*FleetUpgradeResult, error) {
+	if opts.Tag == "" && opts.Digest == "" {
+		return nil, fmt.Errorf("a tag or digest is required")
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 1
+	}
+	if opts.MaxUnavailable < 1 || opts.MaxUnavailable > opts.BatchSize {
+		opts.MaxUnavailable = opts.BatchSize
+	}
+
+	var mu sync.Mutex
+	report := func(p FleetUpgradeProgress) {
+		if opts.OnProgress != nil {
+			opts.OnProgress(p)
+		}
+	}
+
+	result := &FleetUpgradeResult{}
+	batches := (len(instances) + opts.BatchSize - 1) / opts.BatchSize
+	ready := []WaitCondition{{Generation: true}, {Phase: v1alpha1.PhaseRunning}, {ConditionType: "Ready", ConditionStatus: "True"}}
+
+	for b := 0; b < batches; b++ {
+		start := b * opts.BatchSize
+		end := start + opts.BatchSize
+		if end > len(instances) {
+			end = len(instances)
+		}
+		batch := instances[start:end]
+
+		keys := make([]string, len(batch))
+		for i, inst := range batch {
+			keys[i] = inst.Namespace + "/" + inst.Name
+		}
+		mu.Lock()
+		report(FleetUpgradeProgress{Step: StepBatchStarted, Batch: b + 1, Batches: batches, Instances: keys})
+		mu.Unlock()
+
+		// failed halts the upgrade; of those, changed were upgraded and
+		// can be rolled back, the others were refused before any change.
+		var failed, changed []*v1alpha1.OpenClawInstance
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, opts.MaxUnavailable)
+		for i, inst := range batch {
+			slots <- struct{}{}
+			mu.Lock()
+			halted := len(failed) > 0
+			if halted {
+				result.NotAttempted = append(result.NotAttempted, keys[i:]...)
+			}
+			mu.Unlock()
+			if halted {
+				<-slots
+				break
+			}
+
+			wg.Add(1)
+			go func(inst *v1alpha1.OpenClawInstance, key string) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				progress := FleetUpgradeProgress{Batch: b + 1, Batches: batches, Instance: key, Previous: inst.Spec.Image}
+				step, upgraded, err := c.upgradeOne(ctx, inst, opts, ready, func(current v1alpha1.ImageSpec) {
+					mu.Lock()
+					defer mu.Unlock()
+					p := progress
+					p.Step, p.Current = StepUpgrading, current
+					report(p)
+				})
+
+				mu.Lock()
+				defer mu.Unlock()
+				progress.Step, progress.Err = step, err
+				switch step {
+				case StepSkipped:
+					result.Skipped = append(result.Skipped, key)
+				case StepReady:
+					result.Upgraded = append(result.Upgraded, key)
+				default:
+					result.Failed = append(result.Failed, key)
+					failed = append(failed, inst)
+					if upgraded {
+						changed = append(changed, inst)
+					}
+				}
+				report(progress)
+			}(inst, keys[i])
+		}
+		wg.Wait()
+
+		if len(failed) == 0 {
+			continue
+		}
+		for _, inst := range instances[end:] {
+			result.NotAttempted = append(result.NotAttempted, inst.Namespace+"/"+inst.Name)
+		}
+		if opts.RollbackOnFailure {
+			for _, inst := range changed {
+				key := inst.Namespace + "/" + inst.Name
+				rb, err := c.Rollback(ctx, inst.Namespace, inst.Name, RollbackOptions{})
+				p := FleetUpgradeProgress{Step: StepRolledBack, Batch: b + 1, Batches: batches, Instance: key, Err: err}
+				if err == nil {
+					p.Previous, p.Current = rb.Previous, rb.Current
+					result.RolledBack = append(result.RolledBack, key)
+				}
+				report(p)
+			}
+		}
+		return result, fmt.Errorf("%w: %d instance(s) failed in batch %d of %d", ErrFleetUpgradeHalted, len(failed), b+1, batches)
+	}
+	return result, nil
+}
+
+// upgradeOne upgrades a single instance and waits for it to become ready.
+// upgraded reports whether the image was changed.
+func (c *Client) upgradeOne(ctx context.Context, inst *v1alpha1.OpenClawInstance, opts FleetUpgradeOptions, ready []WaitCondition, onUpgrading func(v1alpha1.ImageSpec)) (step FleetUpgradeStep, upgraded bool, err error) {
+	if !upgradeChangesImage(inst.Spec.Image, opts.UpgradeOptions) {
+		return StepSkipped, false, nil
+	}
+	res, err := c.Upgrade(ctx, inst.Namespace, inst.Name, opts.UpgradeOptions)
+	if err != nil {
+		return StepFailed, false, err
+	}
+	onUpgrading(res.Current)
+
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	if _, err := c.WaitFor(waitCtx, inst.Namespace, inst.Name, ready...); err != nil {
+		return StepFailed, true, err
+	}
+	return StepReady, true, nil
+}
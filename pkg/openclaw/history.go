@@ -0,0 +1,226 @@
+package openclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageHistoryAnnotation holds the images an instance ran before its current
+// one, as a JSON list of ImageRevision, oldest first.
+const ImageHistoryAnnotation = "openclaw.rocks/image-history"
+
+// imageHistoryLimit is how many previous images are kept.
+const imageHistoryLimit = 10
+
+// ErrNoRevision is returned by Rollback when there is nothing to roll back to.
+var ErrNoRevision = errors.New("no such revision")
+
+// ImageRevision is an image the instance ran before it was replaced.
+type ImageRevision struct {
+	Revision   int                `json:"revision"`
+	Image      v1alpha1.ImageSpec `json:"image"`
+	ReplacedAt metav1.Time        `json:"replacedAt"`
+	// Backup is the backup taken just before the image was replaced, so it
+	// holds the last data written by this image. It is empty when no backup
+	// was taken, e.g. with "upgrade --skip-backup" or a fleet upgrade.
+	Backup string `json:"backup,omitempty"`
+}
+
+// ImageHistory returns the previous images of an instance, oldest first.
+func ImageHistory(inst *v1alpha1.OpenClawInstance) ([]ImageRevision, error) {
+	raw := inst.Annotations[ImageHistoryAnnotation]
+	if raw == "" {
+		return nil, nil
+	}
+	var history []ImageRevision
+	if err := json.Unmarshal([]byte(raw), &history); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on %s/%s: %w", ImageHistoryAnnotation, inst.Namespace, inst.Name, err)
+	}
+	return history, nil
+}
+
+// BackupFunc backs up an instance's data just before Upgrade or Rollback
+// changes its image, and returns the backup's path, which is recorded in the
+// image history for "rollback --restore-backup". An error stops the image
+// change.
+type BackupFunc func(ctx context.Context, inst *v1alpha1.OpenClawInstance) (string, error)
+
+// backupBeforeImageChange runs backup, if set, and returns the instance read
+// again afterwards: the backup changes its status, and with it the
+// resourceVersion setImage relies on. It fails if the spec was changed in
+// the meantime.
+func (c *Client) backupBeforeImageChange(ctx context.Context, inst *v1alpha1.OpenClawInstance, backup BackupFunc) (*v1alpha1.OpenClawInstance, string, error) {
+	if backup == nil {
+		return inst, "", nil
+	}
+	path, err := backup(ctx, inst)
+	if err != nil {
+		return nil, "", err
+	}
+	current, err := c.GetInstance(ctx, inst.Namespace, inst.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if current.Generation != inst.Generation {
+		return nil, "", fmt.Errorf("%s/%s was changed while it was being backed up; image not changed, the backup is at %s",
+			inst.Namespace, inst.Name, path)
+	}
+	return current, path, nil
+}
+
+// setImage patches spec.image, plus any extra spec fields, and appends the
+// image being replaced to the history along with backup, the path of a backup
+// taken just before, if any. The patch carries the resourceVersion read, so
+// a concurrent change makes it fail instead of losing history.
+func (c *Client) setImage(ctx context.Context, inst *v1alpha1.OpenClawInstance, image map[string]interface{}, extraSpec map[string]interface{}, backup string) (*v1alpha1.OpenClawInstance, error) {
+	history, err := ImageHistory(inst)
+	if err != nil {
+		return nil, err
+	}
+	next := 1
+	if len(history) > 0 {
+		next = history[len(history)-1].Revision + 1
+	}
+	history = append(history, ImageRevision{
+		Revision:   next,
+		Image:      inst.Spec.Image,
+		ReplacedAt: metav1.Now(),
+		Backup:     backup,
+	})
+	if len(history) > imageHistoryLimit {
+		history = history[len(history)-imageHistoryLimit:]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image history: %w", err)
+	}
+
+	spec := map[string]interface{}{"image": image}
+	for k, v := range extraSpec {
+		spec[k] = v
+	}
+	patch := specPatch(spec)
+	patch["metadata"] = map[string]interface{}{
+		"resourceVersion": inst.ResourceVersion,
+		"annotations": map[string]interface{}{
+			ImageHistoryAnnotation: string(data),
+		},
+	}
+	return c.patchInstance(ctx, inst.Namespace, inst.Name, patch)
+}
+
+// RollbackOptions selects what to roll back to.
+type RollbackOptions struct {
+	// ToRevision is the history revision to restore; 0 means the most recent.
+	ToRevision int
+	// RestoreBackup also restores the backup taken just before the revision
+	// was replaced.
+	RestoreBackup bool
+	// Backup, if set, is called once the rollback has passed its checks and
+	// just before the image is changed. See BackupFunc.
+	Backup BackupFunc
+}
+
+type RollbackResult struct {
+	Previous v1alpha1.ImageSpec
+	Current  v1alpha1.ImageSpec
+	Revision ImageRevision
+	// RestoreFrom is the backup being restored, if RestoreBackup was set.
+	RestoreFrom string
+}
+
+// PlanRollback returns the revision Rollback would restore, or why it would
+// refuse: there is no such revision, auto-update is active, or a backup to
+// restore is missing or cannot be restored now. It lets callers confirm the
+// rollback before anything is changed.
+func PlanRollback(inst *v1alpha1.OpenClawInstance, opts RollbackOptions) (ImageRevision, error) {
+	ns, name := inst.Namespace, inst.Name
+	history, err := ImageHistory(inst)
+	if err != nil {
+		return ImageRevision{}, err
+	}
+	if len(history) == 0 {
+		return ImageRevision{}, fmt.Errorf("%w: %s/%s has no upgrade history", ErrNoRevision, ns, name)
+	}
+
+	rev := history[len(history)-1]
+	if opts.ToRevision != 0 {
+		found := false
+		for _, r := range history {
+			if r.Revision == opts.ToRevision {
+				rev, found = r, true
+				break
+			}
+		}
+		if !found {
+			return ImageRevision{}, fmt.Errorf("%w: revision %d of %s/%s", ErrNoRevision, opts.ToRevision, ns, name)
+		}
+	}
+
+	// A rollback is a deliberate downgrade, so of the upgrade checks only
+	// the auto-update guard applies.
+	if err := checkAutoUpdate(inst); err != nil {
+		return ImageRevision{}, err
+	}
+	if opts.RestoreBackup {
+		if rev.Backup == "" {
+			return ImageRevision{}, fmt.Errorf("no backup was taken before revision %d of %s/%s was replaced", rev.Revision, ns, name)
+		}
+		if err := CheckRestorable(inst); err != nil {
+			return ImageRevision{}, err
+		}
+	}
+	return rev, nil
+}
+
+// Rollback restores a previous image from the instance's history, see
+// PlanRollback. The image being replaced is itself recorded, so a rollback
+// can be rolled back.
+func (c *Client) Rollback(ctx context.Context, ns, name string, opts RollbackOptions) (*RollbackResult, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := PlanRollback(inst, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	inst, backup, err := c.backupBeforeImageChange(ctx, inst, opts.Backup)
+	if err != nil {
+		return nil, err
+	}
+
+	var extra map[string]interface{}
+	result := &RollbackResult{Previous: inst.Spec.Image, Revision: rev}
+	if opts.RestoreBackup {
+		extra = map[string]interface{}{"restoreFrom": rev.Backup}
+		result.RestoreFrom = rev.Backup
+	}
+
+	// Unset fields are removed rather than emptied, so the operator's
+	// defaults apply exactly as they did for the revision.
+	image := map[string]interface{}{
+		"repository": nullIfEmpty(rev.Image.Repository),
+		"tag":        nullIfEmpty(rev.Image.Tag),
+		"digest":     nullIfEmpty(rev.Image.Digest),
+	}
+	updated, err := c.setImage(ctx, inst, image, extra, backup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back: %w", err)
+	}
+	result.Current = updated.Spec.Image
+	return result, nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
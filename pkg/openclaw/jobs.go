@@ -0,0 +1,175 @@
+package openclaw
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// ErrJobFailed is returned by WaitForJob when the job fails.
+var ErrJobFailed = errors.New("job failed")
+
+// WaitForJob blocks until a job completes. onUpdate, if set, is called with
+// every observed version of the job so callers can report progress.
+func (c *Client) WaitForJob(ctx context.Context, ns, jobName string, onUpdate func(*batchv1.Job)) (*batchv1.Job, error) {
+	jobs := c.clients.Kube.BatchV1().Jobs(ns)
+	selector := fields.OneTermEqualSelector("metadata.name", jobName).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return jobs.List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return jobs.Watch(ctx, opts)
+		},
+	}
+
+	var job *batchv1.Job
+	check := func(event watch.Event) (bool, error) {
+		j, ok := event.Object.(*batchv1.Job)
+		if !ok {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("job %s was deleted", jobName)
+		}
+		job = j
+		if onUpdate != nil {
+			onUpdate(j)
+		}
+		for _, cond := range j.Status.Conditions {
+			if cond.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch cond.Type {
+			case batchv1.JobComplete:
+				return true, nil
+			case batchv1.JobFailed:
+				return false, fmt.Errorf("%w: %s: %s", ErrJobFailed, jobName, cond.Message)
+			}
+		}
+		return false, nil
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, lw, &batchv1.Job{}, nil, check)
+	if err != nil && ctx.Err() != nil {
+		return job, fmt.Errorf("timed out waiting for job %s to complete", jobName)
+	}
+	return job, err
+}
+
+// JobPods returns the pods of a job, oldest first.
+func (c *Client) JobPods(ctx context.Context, ns, jobName string) ([]corev1.Pod, error) {
+	pods, err := c.clients.Kube.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: jobPodSelector(jobName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of job %s: %w", jobName, err)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp)
+	})
+	return pods.Items, nil
+}
+
+// FollowJobLogs writes the logs of every pod of a job to w, line by line, as
+// the pods start. Once stop is closed it stops waiting for new pods, prints
+// the logs of any pod it has not streamed yet and returns. A pod whose
+// container never started is skipped.
+func (c *Client) FollowJobLogs(ctx context.Context, ns, jobName string, w io.Writer, stop <-chan struct{}) error {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-waitCtx.Done():
+		}
+	}()
+
+	selector := jobPodSelector(jobName)
+	pods := c.clients.Kube.CoreV1().Pods(ns)
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.LabelSelector = selector
+			return pods.List(waitCtx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.LabelSelector = selector
+			return pods.Watch(waitCtx, opts)
+		},
+	}
+
+	streamed := map[string]bool{}
+	for {
+		var next *corev1.Pod
+		started := func(event watch.Event) (bool, error) {
+			pod, ok := event.Object.(*corev1.Pod)
+			if ok && event.Type != watch.Deleted && !streamed[pod.Name] && pod.Status.Phase != corev1.PodPending {
+				next = pod
+				return true, nil
+			}
+			return false, nil
+		}
+		if _, err := watchtools.UntilWithSync(waitCtx, lw, &corev1.Pod{}, nil, started); err != nil {
+			break
+		}
+		streamed[next.Name] = true
+		if err := c.copyPodLogs(ctx, ns, next, true, w); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	// Catch up on pods that started and finished after the last wait.
+	remaining, err := c.JobPods(ctx, ns, jobName)
+	if err != nil {
+		return err
+	}
+	for i := range remaining {
+		pod := &remaining[i]
+		if streamed[pod.Name] || pod.Status.Phase == corev1.PodPending {
+			continue
+		}
+		if err := c.copyPodLogs(ctx, ns, pod, false, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) copyPodLogs(ctx context.Context, ns string, pod *corev1.Pod, follow bool, w io.Writer) error {
+	for _, container := range pod.Spec.Containers {
+		stream, err := c.LogStream(ctx, ns, pod.Name, &corev1.PodLogOptions{Container: container.Name, Follow: follow})
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			fmt.Fprintln(w, scanner.Text())
+		}
+		stream.Close()
+		if err := scanner.Err(); err != nil && ctx.Err() == nil {
+			return fmt.Errorf("error reading logs from pod %s: %w", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+func jobPodSelector(jobName string) string {
+	return "job-name=" + jobName
+}
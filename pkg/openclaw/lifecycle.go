@@ -0,0 +1,329 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SkipBackupAnnotation tells the operator not to back up an instance's data
+// before deleting it.
+const SkipBackupAnnotation = "openclaw.rocks/skip-backup"
+
+// CreateOptions configures a new instance. Zero values are left unset so that
+// the operator's webhook defaults apply.
+type CreateOptions struct {
+	ImageRepository string
+	ImageTag        string
+	Skills          []string
+	StorageSize     string
+	StorageClass    string
+	CPURequest      string
+	MemoryRequest   string
+	Chromium        bool
+	Ollama          bool
+	OllamaModels    []string
+	WebTerminal     bool
+	Pnpm            bool
+	Python          bool
+	SelfConfigure   bool
+}
+
+// AllSelfConfigureActions are the actions an agent may take on itself when
+// self-configuration is enabled.
+var AllSelfConfigureActions = []string{"skills", "config", "workspaceFiles", "envVars"}
+
+func (c *Client) CreateInstance(ctx context.Context, ns, name string, opts CreateOptions) (*v1alpha1.OpenClawInstance, error) {
+	inst := &v1alpha1.OpenClawInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+	}
+	spec := &inst.Spec
+
+	spec.Image.Repository = opts.ImageRepository
+	spec.Image.Tag = opts.ImageTag
+	spec.Skills = opts.Skills
+	spec.Storage.Persistence.Size = opts.StorageSize
+	spec.Storage.Persistence.StorageClass = opts.StorageClass
+
+	if opts.CPURequest != "" || opts.MemoryRequest != "" {
+		spec.Resources.Requests = corev1.ResourceList{}
+		if opts.CPURequest != "" {
+			q, err := resource.ParseQuantity(opts.CPURequest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CPU request %q: %w", opts.CPURequest, err)
+			}
+			spec.Resources.Requests[corev1.ResourceCPU] = q
+		}
+		if opts.MemoryRequest != "" {
+			q, err := resource.ParseQuantity(opts.MemoryRequest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid memory request %q: %w", opts.MemoryRequest, err)
+			}
+			spec.Resources.Requests[corev1.ResourceMemory] = q
+		}
+	}
+
+	spec.Chromium.Enabled = opts.Chromium
+	if opts.Ollama {
+		spec.Ollama.Enabled = true
+		spec.Ollama.Models = opts.OllamaModels
+	}
+	spec.WebTerminal.Enabled = opts.WebTerminal
+	spec.RuntimeDeps.Pnpm = opts.Pnpm
+	spec.RuntimeDeps.Python = opts.Python
+
+	if opts.SelfConfigure {
+		spec.SelfConfigure.Enabled = true
+		spec.SelfConfigure.AllowedActions = AllSelfConfigureActions
+	}
+
+	obj, err := v1alpha1.ToUnstructured(inst)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := c.instances(ns).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance: %w", err)
+	}
+	return v1alpha1.FromUnstructured(created)
+}
+
+type DeleteOptions struct {
+	// SkipBackup disables the operator's pre-deletion backup.
+	SkipBackup bool
+}
+
+func (c *Client) DeleteInstance(ctx context.Context, ns, name string, opts DeleteOptions) error {
+	if opts.SkipBackup {
+		patch := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					SkipBackupAnnotation: "true",
+				},
+			},
+		}
+		if _, err := c.patchInstance(ctx, ns, name, patch); err != nil {
+			return fmt.Errorf("failed to set skip-backup annotation: %w", err)
+		}
+	}
+
+	if err := c.instances(ns).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete instance: %w", err)
+	}
+	return nil
+}
+
+// RestartResult lists the pods deleted by Restart, and those that could not be.
+type RestartResult struct {
+	Deleted []string
+	Failed  map[string]error
+}
+
+// Restart deletes the instance's pods so that the StatefulSet recreates them.
+// Failing to delete an individual pod is reported in the result, not as an error.
+func (c *Client) Restart(ctx context.Context, ns, name string) (*RestartResult, error) {
+	if _, err := c.GetInstanceObject(ctx, ns, name); err != nil {
+		return nil, err
+	}
+
+	pods, err := c.Pods(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestartResult{Failed: map[string]error{}}
+	for _, pod := range pods {
+		if err := c.clients.Kube.CoreV1().Pods(ns).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+			result.Failed[pod.Name] = err
+		} else {
+			result.Deleted = append(result.Deleted, pod.Name)
+		}
+	}
+	return result, nil
+}
+
+// UpgradeOptions selects the new image. At least one of Tag or Digest must be
+// set; setting only one clears the other. Setting both pins the tag to the
+// digest: the digest is deployed and the tag records which version it is.
+type UpgradeOptions struct {
+	Tag        string
+	Digest     string
+	Repository string
+	// Force allows downgrades and major version jumps, see CheckUpgrade.
+	Force bool
+	// Backup, if set, is called once the upgrade has passed its checks and
+	// just before the image is changed. See BackupFunc.
+	Backup BackupFunc
+}
+
+type UpgradeResult struct {
+	Previous v1alpha1.ImageSpec
+	Current  v1alpha1.ImageSpec
+	// Check is how the versions compared; a forced downgrade or major jump
+	// is worth a warning.
+	Check UpgradeCheck
+}
+
+// Upgrade sets a new image. The image being replaced is recorded in the
+// instance's image history, see Rollback. It refuses while auto-update is
+// active, and refuses downgrades and major jumps unless opts.Force is set.
+func (c *Client) Upgrade(ctx context.Context, ns, name string, opts UpgradeOptions) (*UpgradeResult, error) {
+	if opts.Tag == "" && opts.Digest == "" {
+		return nil, fmt.Errorf("a tag or digest is required")
+	}
+
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	check := CheckUpgrade(inst, opts)
+	if err := check.Err(opts.Force); err != nil {
+		return nil, err
+	}
+
+	imageSpec := map[string]interface{}{}
+	if opts.Repository != "" {
+		imageSpec["repository"] = opts.Repository
+	}
+	if opts.Digest != "" {
+		imageSpec["digest"] = opts.Digest
+		// Clear tag when setting digest, unless pinning it
+		imageSpec["tag"] = opts.Tag
+	} else {
+		imageSpec["tag"] = opts.Tag
+		// Clear digest when setting tag
+		imageSpec["digest"] = ""
+	}
+
+	var updated *v1alpha1.OpenClawInstance
+	if upgradeChangesImage(inst.Spec.Image, opts) {
+		var backup string
+		inst, backup, err = c.backupBeforeImageChange(ctx, inst, opts.Backup)
+		if err != nil {
+			return nil, err
+		}
+		updated, err = c.setImage(ctx, inst, imageSpec, nil, backup)
+	} else {
+		updated, err = c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"image": imageSpec}))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade: %w", err)
+	}
+	return &UpgradeResult{Previous: inst.Spec.Image, Current: updated.Spec.Image, Check: check}, nil
+}
+
+func upgradeChangesImage(current v1alpha1.ImageSpec, opts UpgradeOptions) bool {
+	if opts.Repository != "" && opts.Repository != current.Repository {
+		return true
+	}
+	if opts.Digest != "" {
+		return opts.Digest != current.Digest || (opts.Tag != "" && opts.Tag != current.Tag)
+	}
+	return opts.Tag != current.Tag || current.Digest != ""
+}
+
+// ErrInstanceBusy is returned when an operation would interfere with a backup
+// or restore that is in progress.
+var ErrInstanceBusy = errors.New("instance is busy")
+
+// CheckRestorable returns ErrInstanceBusy while the instance is being backed
+// up or restored, or a restore is already pending.
+func CheckRestorable(inst *v1alpha1.OpenClawInstance) error {
+	switch phase := inst.Status.CurrentPhase(); {
+	case phase == v1alpha1.PhaseBackingUp, phase == v1alpha1.PhaseRestoring:
+		return fmt.Errorf("%w: %s/%s is %s", ErrInstanceBusy, inst.Namespace, inst.Name, phase)
+	case inst.Spec.RestoreFrom != "":
+		return fmt.Errorf("%w: %s/%s already has a restore from %s pending", ErrInstanceBusy, inst.Namespace, inst.Name, inst.Spec.RestoreFrom)
+	}
+	return nil
+}
+
+// TriggerRestore sets spec.restoreFrom. The operator restores the instance's
+// data from path and clears the field when done. It refuses while the
+// instance is busy, see CheckRestorable.
+func (c *Client) TriggerRestore(ctx context.Context, ns, name, path string) error {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return err
+	}
+	if err := CheckRestorable(inst); err != nil {
+		return err
+	}
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"restoreFrom": path})); err != nil {
+		return fmt.Errorf("failed to trigger restore: %w", err)
+	}
+	return nil
+}
+
+// ClonedFromAnnotation records which instance a clone was created from.
+const ClonedFromAnnotation = "openclaw.rocks/cloned-from"
+
+// cloneDroppedFields are spec fields that name resources owned by the source
+// instance, or that must be unique, and so are not copied into a clone.
+var cloneDroppedFields = [][]string{
+	{"networking", "ingress", "hosts"},
+	{"storage", "persistence", "existingClaim"},
+	{"tailscale", "hostname"},
+}
+
+// cloneDroppedAnnotations describe the source instance's own state and
+// history, and so are not copied into a clone.
+var cloneDroppedAnnotations = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+	SkipBackupAnnotation:       true,
+	ImageHistoryAnnotation:     true,
+	AutoUpdatePausedAnnotation: true,
+}
+
+// CloneFromBackup creates a new instance newName next to the source instance,
+// with a copy of its spec and restoreFrom set to path, so that it starts from
+// the backup's data. The source instance is not touched. Fields that would
+// make the clone share resources with the source, such as ingress hosts or an
+// existing PVC, are dropped.
+func (c *Client) CloneFromBackup(ctx context.Context, ns, name, newName, path string) (*v1alpha1.OpenClawInstance, error) {
+	source, err := c.GetInstanceObject(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, _, err := unstructured.NestedMap(source.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec of %s/%s: %w", ns, name, err)
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	for _, field := range cloneDroppedFields {
+		unstructured.RemoveNestedField(spec, field...)
+	}
+	spec["restoreFrom"] = path
+
+	clone := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	clone.SetAPIVersion(source.GetAPIVersion())
+	clone.SetKind(source.GetKind())
+	clone.SetName(newName)
+	clone.SetNamespace(ns)
+	clone.SetLabels(source.GetLabels())
+
+	annotations := map[string]string{}
+	for k, v := range source.GetAnnotations() {
+		if !cloneDroppedAnnotations[k] {
+			annotations[k] = v
+		}
+	}
+	annotations[ClonedFromAnnotation] = ns + "/" + name
+	clone.SetAnnotations(annotations)
+
+	created, err := c.instances(ns).Create(ctx, clone, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance: %w", err)
+	}
+	return v1alpha1.FromUnstructured(created)
+}
@@ -0,0 +1,70 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// LogStream opens the log stream of a pod. The caller must close it.
+func (c *Client) LogStream(ctx context.Context, ns, podName string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	stream, err := c.clients.Kube.CoreV1().Pods(ns).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs from pod %s: %w", podName, err)
+	}
+	return stream, nil
+}
+
+// ExecOptions configures Exec. With TTY set, Stderr is ignored: the remote
+// terminal multiplexes both streams onto Stdout.
+type ExecOptions struct {
+	Container string
+	Command   []string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	TTY bool
+	// TerminalSizeQueue reports terminal resizes when TTY is set. Optional.
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Exec runs a command in a pod and streams its I/O until it exits.
+func (c *Client) Exec(ctx context.Context, ns, podName string, opts ExecOptions) error {
+	execOpts := &corev1.PodExecOptions{
+		Container: opts.Container,
+		Command:   opts.Command,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil && !opts.TTY,
+		TTY:       opts.TTY,
+	}
+
+	req := c.clients.Kube.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(ns).
+		SubResource("exec").
+		VersionedParams(execOpts, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(c.clients.Config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	streams := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.TerminalSizeQueue,
+	}
+	if !opts.TTY {
+		streams.Stderr = opts.Stderr
+	}
+	return exec.StreamWithContext(ctx, streams)
+}
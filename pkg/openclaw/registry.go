@@ -0,0 +1,188 @@
+package openclaw
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/registry"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/semver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RegistryOptions configures how an instance's image registry is reached.
+type RegistryOptions struct {
+	// Repository overrides spec.image.repository. spec.registry still applies.
+	Repository string
+	// PlainHTTP talks HTTP instead of HTTPS, e.g. to a local test registry.
+	// It is implied for localhost.
+	PlainHTTP bool
+}
+
+// ImageVersions lists the tags available for an instance's image.
+type ImageVersions struct {
+	Repository string     `json:"repository"`
+	Current    string     `json:"current,omitempty"`
+	Tags       []ImageTag `json:"tags"`
+}
+
+type ImageTag struct {
+	Tag     string `json:"tag"`
+	Current bool   `json:"current,omitempty"`
+	// Semver is false for tags that are not versions, such as "latest".
+	Semver     bool `json:"semver"`
+	Prerelease bool `json:"prerelease,omitempty"`
+}
+
+// ResolveDigest looks up the digest a tag of the instance's image currently
+// points to, authenticating with the instance's image pull secrets.
+func (c *Client) ResolveDigest(ctx context.Context, ns, name, tag string, opts RegistryOptions) (string, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return "", err
+	}
+	reg, ref, err := c.imageRegistry(ctx, inst, opts)
+	if err != nil {
+		return "", err
+	}
+	return reg.Digest(ctx, ref, tag)
+}
+
+// AvailableVersions lists the tags of the instance's image repository:
+// semantic versions first, newest first, then the other tags by name.
+func (c *Client) AvailableVersions(ctx context.Context, ns, name string, opts RegistryOptions) (*ImageVersions, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	reg, ref, err := c.imageRegistry(ctx, inst, opts)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := reg.Tags(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	current := inst.Spec.Image.Tag
+	if current == "" && inst.Spec.Image.Digest == "" {
+		current = "latest"
+	}
+	if current == "" && inst.Status.AutoUpdate != nil {
+		current = inst.Status.AutoUpdate.CurrentVersion
+	}
+
+	result := &ImageVersions{Repository: ref.String(), Current: current}
+	var versions []semver.Version
+	var other []string
+	for _, t := range tags {
+		if v, err := semver.Parse(t); err == nil {
+			versions = append(versions, v)
+		} else {
+			other = append(other, t)
+		}
+	}
+	semver.Sort(versions)
+	for _, v := range versions {
+		result.Tags = append(result.Tags, ImageTag{
+			Tag:        v.Original,
+			Current:    v.Original == current,
+			Semver:     true,
+			Prerelease: v.IsPrerelease(),
+		})
+	}
+	sort.Strings(other)
+	for _, t := range other {
+		result.Tags = append(result.Tags, ImageTag{Tag: t, Current: t == current})
+	}
+	return result, nil
+}
+
+// imageRegistry returns a registry client for the instance's image,
+// with credentials from its image pull secrets.
+func (c *Client) imageRegistry(ctx context.Context, inst *v1alpha1.OpenClawInstance, opts RegistryOptions) (*registry.Client, registry.Reference, error) {
+	spec := inst.Spec
+	if opts.Repository != "" {
+		spec.Image.Repository = opts.Repository
+	}
+	ref, err := registry.ParseReference(spec.ImageRepository())
+	if err != nil {
+		return nil, registry.Reference{}, err
+	}
+
+	creds := map[string]registry.Credentials{}
+	for _, ps := range spec.ImagePullSecrets {
+		secret, err := c.clients.Kube.CoreV1().Secrets(inst.Namespace).Get(ctx, ps.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, ref, fmt.Errorf("failed to get image pull secret %s: %w", ps.Name, err)
+		}
+		found, err := dockerCredentials(secret)
+		if err != nil {
+			return nil, ref, fmt.Errorf("invalid image pull secret %s: %w", ps.Name, err)
+		}
+		// The first secret listed wins, as with the kubelet.
+		for host, cred := range found {
+			if _, ok := creds[host]; !ok {
+				creds[host] = cred
+			}
+		}
+	}
+	return registry.New(registry.Options{Credentials: creds, PlainHTTP: opts.PlainHTTP}), ref, nil
+}
+
+// dockerCredentials reads a kubernetes.io/dockerconfigjson or
+// kubernetes.io/dockercfg secret, keyed by registry host.
+func dockerCredentials(secret *corev1.Secret) (map[string]registry.Credentials, error) {
+	type entry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	auths := map[string]entry{}
+	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+		var cfg struct {
+			Auths map[string]entry `json:"auths"`
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, err
+		}
+		auths = cfg.Auths
+	} else if data, ok := secret.Data[corev1.DockerConfigKey]; ok {
+		if err := json.Unmarshal(data, &auths); err != nil {
+			return nil, err
+		}
+	}
+
+	creds := map[string]registry.Credentials{}
+	for server, e := range auths {
+		if e.Username == "" && e.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(e.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for %s: %w", server, err)
+			}
+			e.Username, e.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		creds[registryHost(server)] = registry.Credentials{Username: e.Username, Password: e.Password}
+	}
+	return creds, nil
+}
+
+// registryHost normalizes a docker config server such as
+// "https://index.docker.io/v1/" to the host used in image references.
+func registryHost(server string) string {
+	host := server
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
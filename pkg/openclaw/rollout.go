@@ -0,0 +1,192 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrRolloutStuck is wrapped by RolloutStuckError.
+var ErrRolloutStuck = errors.New("rollout stuck")
+
+// stuckReasons are container waiting reasons that do not resolve on their own.
+var stuckReasons = map[string]bool{
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// rolloutPollInterval re-checks the StatefulSet between pod and event changes.
+const rolloutPollInterval = 2 * time.Second
+
+// RolloutStuckError reports a container of an updated pod that cannot start.
+type RolloutStuckError struct {
+	Pod       string
+	Container string
+	Reason    string
+	Message   string
+	// Restarted is set when the container has run before, so its previous
+	// logs explain the failure.
+	Restarted bool
+}
+
+func (e *RolloutStuckError) Error() string {
+	msg := fmt.Sprintf("%s: container %s in pod %s is in %s", ErrRolloutStuck, e.Container, e.Pod, e.Reason)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *RolloutStuckError) Unwrap() error { return ErrRolloutStuck }
+
+// RolloutStatus is a snapshot of an instance's StatefulSet rollout.
+type RolloutStatus struct {
+	StatefulSet     string
+	Replicas        int32
+	UpdatedReplicas int32
+	ReadyReplicas   int32
+	// Pods are the instance's pods; Updated marks those on the new revision.
+	Pods []RolloutPod
+	// Events are the events of the updated pods, oldest first.
+	Events []corev1.Event
+	// Done is set once every replica runs the new revision and is ready.
+	Done bool
+	// Stuck is set when an updated pod cannot start.
+	Stuck *RolloutStuckError
+}
+
+type RolloutPod struct {
+	Name       string
+	Updated    bool
+	Phase      corev1.PodPhase
+	Containers []corev1.ContainerStatus
+}
+
+// RolloutStatus reads the state of the instance's StatefulSet rollout. The
+// rollout only counts as done once the operator has reconciled the latest
+// instance spec into the StatefulSet.
+func (c *Client) RolloutStatus(ctx context.Context, ns, name string) (*RolloutStatus, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	stsName := inst.Status.Managed().StatefulSet
+	if stsName == "" {
+		stsName = name
+	}
+	sts, err := c.clients.Kube.AppsV1().StatefulSets(ns).Get(ctx, stsName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// Not created yet, e.g. right after create.
+		return &RolloutStatus{StatefulSet: stsName}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get StatefulSet %s: %w", stsName, err)
+	}
+
+	st := &RolloutStatus{
+		StatefulSet:     stsName,
+		Replicas:        1,
+		UpdatedReplicas: sts.Status.UpdatedReplicas,
+		ReadyReplicas:   sts.Status.ReadyReplicas,
+	}
+	if sts.Spec.Replicas != nil {
+		st.Replicas = *sts.Spec.Replicas
+	}
+
+	// Until then, the "update" revision is still the old one.
+	reconciled := inst.Status.ObservedGeneration >= inst.Generation &&
+		sts.Status.ObservedGeneration >= sts.Generation
+
+	pods, err := c.Pods(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		rp := RolloutPod{
+			Name:       pod.Name,
+			Updated:    reconciled && pod.Labels[appsv1.ControllerRevisionHashLabelKey] == sts.Status.UpdateRevision,
+			Phase:      pod.Status.Phase,
+			Containers: append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...),
+		}
+		st.Pods = append(st.Pods, rp)
+		if !rp.Updated {
+			continue
+		}
+
+		if events, err := c.eventsFor(ctx, ns, pod.Name); err == nil {
+			for _, e := range events {
+				// Events of a deleted pod with the same name linger.
+				if e.InvolvedObject.UID == pod.UID {
+					st.Events = append(st.Events, e)
+				}
+			}
+		}
+		for _, cs := range rp.Containers {
+			if w := cs.State.Waiting; w != nil && stuckReasons[w.Reason] && st.Stuck == nil {
+				st.Stuck = &RolloutStuckError{
+					Pod:       pod.Name,
+					Container: cs.Name,
+					Reason:    w.Reason,
+					Message:   w.Message,
+					Restarted: cs.RestartCount > 0,
+				}
+			}
+		}
+	}
+	sort.SliceStable(st.Events, func(i, j int) bool {
+		return EventTime(st.Events[i]).Before(EventTime(st.Events[j]))
+	})
+
+	st.Done = reconciled &&
+		st.UpdatedReplicas >= st.Replicas &&
+		st.ReadyReplicas >= st.Replicas &&
+		sts.Status.CurrentRevision == sts.Status.UpdateRevision
+	return st, nil
+}
+
+// WaitForRollout blocks until the instance's StatefulSet has rolled out,
+// calling onProgress with every new snapshot. It fails with a
+// *RolloutStuckError as soon as an updated pod cannot start.
+func (c *Client) WaitForRollout(ctx context.Context, ns, name string, onProgress func(*RolloutStatus)) (*RolloutStatus, error) {
+	changed, err := c.WatchInstance(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+
+	for {
+		st, err := c.RolloutStatus(ctx, ns, name)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if st != nil {
+			if onProgress != nil {
+				onProgress(st)
+			}
+			if st.Stuck != nil {
+				return st, st.Stuck
+			}
+			if st.Done {
+				return st, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return st, fmt.Errorf("timed out waiting for the rollout of %s/%s", ns, name)
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,555 @@
+package openclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/kube"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// Annotations recording who approved or rejected a self-config request. They
+// stay on the request as its audit trail. The request's status belongs to
+// the operator, so decisions are recorded here only; see SelfConfigPhase.
+const (
+	SelfConfigDecisionAnnotation  = "openclaw.rocks/decision"
+	SelfConfigDecidedByAnnotation = "openclaw.rocks/decided-by"
+	SelfConfigDecidedAtAnnotation = "openclaw.rocks/decided-at"
+	SelfConfigReasonAnnotation    = "openclaw.rocks/decision-reason"
+	// SelfConfigErrorAnnotation is set when an approved request could not
+	// be applied to its instance.
+	SelfConfigErrorAnnotation = "openclaw.rocks/decision-error"
+)
+
+// Values of SelfConfigDecisionAnnotation.
+const (
+	SelfConfigApproved = "approved"
+	SelfConfigRejected = "rejected"
+)
+
+// ErrSelfConfigDecided is returned when approving or rejecting a request that
+// is no longer pending.
+var ErrSelfConfigDecided = errors.New("self-config request is no longer pending")
+
+// ErrSelfConfigPolicy is returned when approving a request that goes beyond
+// its instance's self-configure policy without ApproveSelfConfigOptions.IgnorePolicy.
+var ErrSelfConfigPolicy = errors.New("self-config request goes beyond the self-configure policy")
+
+// ApproveSelfConfigOptions configures ApproveSelfConfig.
+type ApproveSelfConfigOptions struct {
+	// Reason is kept with the decision.
+	Reason string
+	// IgnorePolicy approves a request even if it asks for more than the
+	// instance's self-configure policy allows.
+	IgnorePolicy bool
+}
+
+func (c *Client) selfConfigs(ns string) dynamic.ResourceInterface {
+	return c.clients.Dynamic.Resource(kube.SelfConfigGVR).Namespace(ns)
+}
+
+// GetSelfConfig returns a self-config request.
+func (c *Client) GetSelfConfig(ctx context.Context, ns, name string) (*v1alpha1.OpenClawSelfConfig, error) {
+	obj, err := c.selfConfigs(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenClawSelfConfig %s/%s: %w", ns, name, err)
+	}
+	return v1alpha1.SelfConfigFromUnstructured(obj)
+}
+
+// ListSelfConfigs returns the self-config requests in a namespace, oldest
+// first. A non-empty instance keeps only the requests for that instance; an
+// empty namespace lists across all namespaces.
+func (c *Client) ListSelfConfigs(ctx context.Context, ns, instance string) ([]v1alpha1.OpenClawSelfConfig, error) {
+	items, _, err := c.listSelfConfigs(ctx, ns, instance)
+	return items, err
+}
+
+func (c *Client) listSelfConfigs(ctx context.Context, ns, instance string) ([]v1alpha1.OpenClawSelfConfig, string, error) {
+	list, err := c.selfConfigs(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list OpenClawSelfConfigs: %w", err)
+	}
+	items := []v1alpha1.OpenClawSelfConfig{}
+	for i := range list.Items {
+		sc, err := v1alpha1.SelfConfigFromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, "", err
+		}
+		if instance == "" || sc.Spec.InstanceRef == instance {
+			items = append(items, *sc)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		ti, tj := items[i].CreationTimestamp, items[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items, list.GetResourceVersion(), nil
+}
+
+// SelfConfigEvent is a change to a self-config request seen by
+// WatchSelfConfigs.
+type SelfConfigEvent struct {
+	Type       watch.EventType
+	SelfConfig *v1alpha1.OpenClawSelfConfig
+}
+
+// WatchSelfConfigs sends the existing self-config requests, as Added events,
+// followed by every change to them until ctx is done, when the channel is
+// closed. A non-empty instance keeps only the requests for that instance.
+func (c *Client) WatchSelfConfigs(ctx context.Context, ns, instance string) (<-chan SelfConfigEvent, error) {
+	items, rv, err := c.listSelfConfigs(ctx, ns, instance)
+	if err != nil {
+		return nil, err
+	}
+	w, err := watchtools.NewRetryWatcher(rv, &cache.ListWatch{
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return c.selfConfigs(ns).Watch(ctx, opts)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch OpenClawSelfConfigs: %w", err)
+	}
+
+	events := make(chan SelfConfigEvent)
+	go func() {
+		defer close(events)
+		defer w.Stop()
+		send := func(e SelfConfigEvent) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for i := range items {
+			if !send(SelfConfigEvent{Type: watch.Added, SelfConfig: &items[i]}) {
+				return
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-w.ResultChan():
+				if !ok {
+					return
+				}
+				obj, isObj := e.Object.(*unstructured.Unstructured)
+				if !isObj {
+					continue
+				}
+				sc, err := v1alpha1.SelfConfigFromUnstructured(obj)
+				if err != nil || (instance != "" && sc.Spec.InstanceRef != instance) {
+					continue
+				}
+				if !send(SelfConfigEvent{Type: e.Type, SelfConfig: sc}) {
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// SelfConfigDecision is the audit record of a human approving or rejecting
+// a self-config request.
+type SelfConfigDecision struct {
+	Decision  string    `json:"decision"`
+	DecidedBy string    `json:"decidedBy"`
+	DecidedAt time.Time `json:"decidedAt"`
+	Reason    string    `json:"reason,omitempty"`
+	// Error is why an approved request could not be applied.
+	Error string `json:"error,omitempty"`
+}
+
+// DecisionOf returns the recorded decision on a request, or nil if nobody
+// has approved or rejected it.
+func DecisionOf(sc *v1alpha1.OpenClawSelfConfig) *SelfConfigDecision {
+	decision := sc.Annotations[SelfConfigDecisionAnnotation]
+	if decision == "" {
+		return nil
+	}
+	d := &SelfConfigDecision{
+		Decision:  decision,
+		DecidedBy: sc.Annotations[SelfConfigDecidedByAnnotation],
+		Reason:    sc.Annotations[SelfConfigReasonAnnotation],
+		Error:     sc.Annotations[SelfConfigErrorAnnotation],
+	}
+	d.DecidedAt, _ = time.Parse(time.RFC3339, sc.Annotations[SelfConfigDecidedAtAnnotation])
+	return d
+}
+
+// SelfConfigPhase returns the phase of a request as the operator reports it
+// or, while the operator still reports it as pending, as the recorded
+// decision implies: Applied or Failed once approved, Denied once rejected.
+func SelfConfigPhase(sc *v1alpha1.OpenClawSelfConfig) string {
+	if phase := sc.Status.Phase; phase != "" && phase != v1alpha1.SelfConfigPhasePending {
+		return phase
+	}
+	d := DecisionOf(sc)
+	switch {
+	case d == nil:
+		return v1alpha1.SelfConfigPhasePending
+	case d.Decision == SelfConfigRejected:
+		return v1alpha1.SelfConfigPhaseDenied
+	case d.Error != "":
+		return v1alpha1.SelfConfigPhaseFailed
+	}
+	return v1alpha1.SelfConfigPhaseApplied
+}
+
+// SelfConfigPending reports whether a request still awaits a decision.
+func SelfConfigPending(sc *v1alpha1.OpenClawSelfConfig) bool {
+	phase := sc.Status.Phase
+	return (phase == "" || phase == v1alpha1.SelfConfigPhasePending) && DecisionOf(sc) == nil
+}
+
+// SelfConfigRetryable reports whether a request was approved but could not
+// be applied to its instance, so that approving it again retries.
+func SelfConfigRetryable(sc *v1alpha1.OpenClawSelfConfig) bool {
+	phase := sc.Status.Phase
+	d := DecisionOf(sc)
+	return (phase == "" || phase == v1alpha1.SelfConfigPhasePending) &&
+		d != nil && d.Decision == SelfConfigApproved && d.Error != ""
+}
+
+// SelfConfigChanges previews a request against the instance's current spec.
+// Skills are reported at "skills", config keys under "config.", workspace
+// files under "workspaceFiles." and environment variables under "env.".
+// Parts of the request that change nothing, such as removing a skill that is
+// not installed, are left out.
+func SelfConfigChanges(inst *v1alpha1.OpenClawInstance, sc *v1alpha1.OpenClawSelfConfig) ([]ConfigChange, error) {
+	changes, _, err := planSelfConfig(inst, sc)
+	return changes, err
+}
+
+// planSelfConfig returns a request's changes and the spec fields of the merge
+// patch that makes them.
+func planSelfConfig(inst *v1alpha1.OpenClawInstance, sc *v1alpha1.OpenClawSelfConfig) ([]ConfigChange, map[string]interface{}, error) {
+	var changes []ConfigChange
+	fields := map[string]interface{}{}
+	req := sc.Spec
+
+	// Skills
+	installed := make(map[string]bool)
+	for _, s := range inst.Spec.Skills {
+		installed[s] = true
+	}
+	remove := make(map[string]bool)
+	for _, s := range req.RemoveSkills {
+		remove[s] = true
+	}
+	skills := []string{}
+	for _, s := range inst.Spec.Skills {
+		if remove[s] {
+			changes = append(changes, ConfigChange{Path: "skills", Type: ConfigRemoved, Old: s})
+			continue
+		}
+		skills = append(skills, s)
+	}
+	for _, s := range req.AddSkills {
+		if !installed[s] && !remove[s] {
+			changes = append(changes, ConfigChange{Path: "skills", Type: ConfigAdded, New: s})
+			skills = append(skills, s)
+			installed[s] = true
+		}
+	}
+	if len(changes) > 0 {
+		fields["skills"] = skills
+	}
+
+	// Config
+	if req.ConfigPatch != nil && len(req.ConfigPatch.Raw) > 0 {
+		var patch map[string]interface{}
+		if err := json.Unmarshal(req.ConfigPatch.Raw, &patch); err != nil || patch == nil {
+			return nil, nil, fmt.Errorf("invalid spec.configPatch on %s/%s: must be a JSON object", sc.Namespace, sc.Name)
+		}
+		current, err := InlineConfig(inst)
+		if err != nil {
+			return nil, nil, err
+		}
+		diff := DiffConfig(current, mergeConfigPatch(current, patch))
+		for _, ch := range diff {
+			ch.Path = "config." + ch.Path
+			changes = append(changes, ch)
+		}
+		if len(diff) > 0 {
+			fields["config"] = map[string]interface{}{"raw": patch}
+		}
+	}
+
+	// Workspace files
+	files := map[string]interface{}{}
+	existing := inst.Spec.Workspace.InitialFiles
+	for _, f := range req.RemoveWorkspaceFiles {
+		if old, ok := existing[f]; ok {
+			changes = append(changes, ConfigChange{Path: joinConfigPath("workspaceFiles", f), Type: ConfigRemoved, Old: old})
+			files[f] = nil
+		}
+	}
+	for _, f := range sortedKeys(req.AddWorkspaceFiles) {
+		content := req.AddWorkspaceFiles[f]
+		old, ok := existing[f]
+		switch {
+		case !ok:
+			changes = append(changes, ConfigChange{Path: joinConfigPath("workspaceFiles", f), Type: ConfigAdded, New: content})
+		case old != content:
+			changes = append(changes, ConfigChange{Path: joinConfigPath("workspaceFiles", f), Type: ConfigChanged, Old: old, New: content})
+		default:
+			continue
+		}
+		files[f] = content
+	}
+	if len(files) > 0 {
+		fields["workspace"] = map[string]interface{}{"initialFiles": files}
+	}
+
+	// Environment variables
+	set := make(map[string]string)
+	for _, e := range req.AddEnvVars {
+		set[e.Name] = e.Value
+	}
+	unset := make(map[string]bool)
+	for _, n := range req.RemoveEnvVars {
+		unset[n] = true
+	}
+	envChanged := false
+	env := []interface{}{}
+	seen := make(map[string]bool)
+	for _, e := range inst.Spec.Env {
+		seen[e.Name] = true
+		var old interface{} = e.Value
+		if e.ValueFrom != nil {
+			old = e.ValueFrom
+		}
+		path := joinConfigPath("env", e.Name)
+		if val, ok := set[e.Name]; ok {
+			if e.ValueFrom != nil || e.Value != val {
+				changes = append(changes, ConfigChange{Path: path, Type: ConfigChanged, Old: old, New: val})
+				envChanged = true
+			}
+			env = append(env, map[string]interface{}{"name": e.Name, "value": val})
+			continue
+		}
+		if unset[e.Name] {
+			changes = append(changes, ConfigChange{Path: path, Type: ConfigRemoved, Old: old})
+			envChanged = true
+			continue
+		}
+		env = append(env, e)
+	}
+	for _, e := range req.AddEnvVars {
+		if !seen[e.Name] {
+			changes = append(changes, ConfigChange{Path: joinConfigPath("env", e.Name), Type: ConfigAdded, New: e.Value})
+			env = append(env, map[string]interface{}{"name": e.Name, "value": e.Value})
+			seen[e.Name] = true
+			envChanged = true
+		}
+	}
+	if envChanged {
+		fields["env"] = env
+	}
+
+	return changes, fields, nil
+}
+
+// mergeConfigPatch applies a JSON merge patch (RFC 7386) to a copy of
+// target.
+func mergeConfigPatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	out := map[string]interface{}{}
+	if t, ok := target.(map[string]interface{}); ok {
+		for k, v := range t {
+			out[k] = v
+		}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = mergeConfigPatch(out[k], v)
+	}
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ApproveSelfConfig records the caller's approval on a pending request and
+// makes the requested changes to the instance. The decision is recorded
+// first, against the request's resourceVersion, so two people deciding at
+// once cannot both succeed; if the instance cannot be updated afterwards the
+// error is recorded with it, and the request can be approved again to retry.
+// A request that goes beyond the instance's self-configure policy is refused
+// with ErrSelfConfigPolicy unless opts.IgnorePolicy is set.
+func (c *Client) ApproveSelfConfig(ctx context.Context, ns, name string, opts ApproveSelfConfigOptions) (*SelfConfigDecision, error) {
+	sc, err := c.GetSelfConfig(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	reason := opts.Reason
+	if SelfConfigRetryable(sc) {
+		if reason == "" {
+			reason = DecisionOf(sc).Reason
+		}
+	} else if !SelfConfigPending(sc) {
+		return nil, fmt.Errorf("%w: %s/%s is %s", ErrSelfConfigDecided, ns, name, selfConfigState(sc))
+	}
+	inst, err := c.GetInstance(ctx, ns, sc.Spec.InstanceRef)
+	if err != nil {
+		return nil, err
+	}
+	if violations := SelfConfigPolicyViolations(&inst.Spec.SelfConfigure, sc); len(violations) > 0 && !opts.IgnorePolicy {
+		return nil, fmt.Errorf("%w of %s/%s: %s", ErrSelfConfigPolicy, ns, inst.Name, strings.Join(violations, "; "))
+	}
+	_, fields, err := planSelfConfig(inst, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := c.recordSelfConfigDecision(ctx, sc, SelfConfigApproved, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) > 0 {
+		patch := specPatch(fields)
+		patch["metadata"] = map[string]interface{}{"resourceVersion": inst.ResourceVersion}
+		if _, err := c.patchInstance(ctx, ns, inst.Name, patch); err != nil {
+			err = fmt.Errorf("failed to update %s/%s: %w", ns, inst.Name, err)
+			if recordErr := c.recordSelfConfigError(ctx, ns, name, err); recordErr != nil {
+				return nil, fmt.Errorf("%w (and %v)", err, recordErr)
+			}
+			return nil, fmt.Errorf("%w; approve %s again to retry", err, name)
+		}
+	}
+	return decision, nil
+}
+
+// RejectSelfConfig records the caller's rejection on a pending request. The
+// instance is left alone.
+func (c *Client) RejectSelfConfig(ctx context.Context, ns, name, reason string) (*SelfConfigDecision, error) {
+	sc, err := c.GetSelfConfig(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if !SelfConfigPending(sc) {
+		return nil, fmt.Errorf("%w: %s/%s is %s", ErrSelfConfigDecided, ns, name, selfConfigState(sc))
+	}
+
+	return c.recordSelfConfigDecision(ctx, sc, SelfConfigRejected, reason)
+}
+
+// selfConfigState describes why a request is not pending.
+func selfConfigState(sc *v1alpha1.OpenClawSelfConfig) string {
+	if d := DecisionOf(sc); d != nil {
+		return fmt.Sprintf("already %s by %s", d.Decision, d.DecidedBy)
+	}
+	return sc.Status.Phase
+}
+
+func (c *Client) recordSelfConfigDecision(ctx context.Context, sc *v1alpha1.OpenClawSelfConfig, decision, reason string) (*SelfConfigDecision, error) {
+	user, err := c.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d := &SelfConfigDecision{
+		Decision:  decision,
+		DecidedBy: user,
+		DecidedAt: time.Now().UTC().Truncate(time.Second),
+		Reason:    reason,
+	}
+	annotations := map[string]interface{}{
+		SelfConfigDecisionAnnotation:  d.Decision,
+		SelfConfigDecidedByAnnotation: d.DecidedBy,
+		SelfConfigDecidedAtAnnotation: d.DecidedAt.Format(time.RFC3339),
+		SelfConfigReasonAnnotation:    nil,
+		SelfConfigErrorAnnotation:     nil,
+	}
+	if reason != "" {
+		annotations[SelfConfigReasonAnnotation] = reason
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": sc.ResourceVersion,
+			"annotations":     annotations,
+		},
+	}
+	if err := c.patchSelfConfig(ctx, sc.Namespace, sc.Name, patch); err != nil {
+		return nil, fmt.Errorf("failed to record decision on %s/%s: %w", sc.Namespace, sc.Name, err)
+	}
+	return d, nil
+}
+
+// recordSelfConfigError records why an approved request could not be
+// applied.
+func (c *Client) recordSelfConfigError(ctx context.Context, ns, name string, cause error) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{SelfConfigErrorAnnotation: cause.Error()},
+		},
+	}
+	if err := c.patchSelfConfig(ctx, ns, name, patch); err != nil {
+		return fmt.Errorf("failed to record the error on %s/%s: %w", ns, name, err)
+	}
+	return nil
+}
+
+func (c *Client) patchSelfConfig(ctx context.Context, ns, name string, patch map[string]interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to create patch: %w", err)
+	}
+	_, err = c.selfConfigs(ns).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// CurrentUser returns the name the API server knows the caller by. Clusters
+// older than Kubernetes 1.28 do not answer SelfSubjectReviews; there the user
+// set in the kubeconfig, if any, is used.
+func (c *Client) CurrentUser(ctx context.Context) (string, error) {
+	review, err := c.clients.Kube.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err == nil && review.Status.UserInfo.Username != "" {
+		return review.Status.UserInfo.Username, nil
+	}
+	if cfg := c.clients.Config; cfg != nil {
+		if cfg.Impersonate.UserName != "" {
+			return cfg.Impersonate.UserName, nil
+		}
+		if cfg.Username != "" {
+			return cfg.Username, nil
+		}
+	}
+	if err == nil {
+		err = errors.New("the API server did not return a user name")
+	}
+	return "", fmt.Errorf("failed to determine the current user: %w", err)
+}
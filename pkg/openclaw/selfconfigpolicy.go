@@ -0,0 +1,433 @@
+package openclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+)
+
+// SelfConfigPolicyOptions changes an instance's self-configure policy. Nil
+// and empty fields leave the policy as it is.
+type SelfConfigPolicyOptions struct {
+	// Enabled turns self-configuration on or off.
+	Enabled *bool
+	// Allow adds actions to spec.selfConfigure.allowedActions and turns
+	// self-configuration on; Deny removes them.
+	Allow []string
+	Deny  []string
+	// Skills, ConfigPaths and EnvVars change the rules for those actions.
+	Skills      SelfConfigRulesUpdate
+	ConfigPaths SelfConfigRulesUpdate
+	EnvVars     SelfConfigRulesUpdate
+}
+
+// SelfConfigRulesUpdate replaces the allow and deny patterns of a rule set.
+// A nil list is left as it is; an empty one removes the patterns.
+type SelfConfigRulesUpdate struct {
+	Allow []string
+	Deny  []string
+}
+
+// EffectiveSelfConfigureActions returns the actions an agent may take. The
+// operator allows every action when allowedActions is empty.
+func EffectiveSelfConfigureActions(policy *v1alpha1.SelfConfigureSpec) []string {
+	if !policy.Enabled {
+		return nil
+	}
+	if len(policy.AllowedActions) == 0 {
+		return AllSelfConfigureActions
+	}
+	return policy.AllowedActions
+}
+
+// ApplySelfConfigPolicy returns policy with opts applied, validated as by
+// ValidateSelfConfigPolicy.
+func ApplySelfConfigPolicy(policy v1alpha1.SelfConfigureSpec, opts SelfConfigPolicyOptions) (v1alpha1.SelfConfigureSpec, []string, error) {
+	for _, a := range append(append([]string{}, opts.Allow...), opts.Deny...) {
+		if !knownSelfConfigureAction(a) {
+			return policy, nil, fmt.Errorf("unknown self-configure action %q: must be one of %s", a, strings.Join(AllSelfConfigureActions, ", "))
+		}
+	}
+	for _, a := range opts.Allow {
+		if containsString(opts.Deny, a) {
+			return policy, nil, fmt.Errorf("action %q cannot be both allowed and denied", a)
+		}
+	}
+
+	if opts.Enabled != nil {
+		policy.Enabled = *opts.Enabled
+	}
+	if len(opts.Allow) > 0 || len(opts.Deny) > 0 {
+		// An empty allowedActions allows every action once enabled, so
+		// denying starts from all of them. Allowing on a disabled policy
+		// starts from none, so that only the actions named are enabled.
+		actions := policy.AllowedActions
+		if len(actions) == 0 && (policy.Enabled || len(opts.Allow) == 0) {
+			actions = AllSelfConfigureActions
+		}
+		var updated []string
+		for _, a := range AllSelfConfigureActions {
+			if (containsString(actions, a) || containsString(opts.Allow, a)) && !containsString(opts.Deny, a) {
+				updated = append(updated, a)
+			}
+		}
+		if len(updated) == 0 {
+			return policy, nil, errors.New("cannot deny every action: an empty allowedActions allows them all; turn self-configuration off with --disable instead")
+		}
+		policy.AllowedActions = updated
+		if len(opts.Allow) > 0 && opts.Enabled == nil {
+			policy.Enabled = true
+		}
+	}
+	policy.Skills = updateRules(policy.Skills, opts.Skills)
+	policy.ConfigPaths = updateRules(policy.ConfigPaths, opts.ConfigPaths)
+	policy.EnvVars = updateRules(policy.EnvVars, opts.EnvVars)
+
+	warnings, err := ValidateSelfConfigPolicy(&policy)
+	return policy, warnings, err
+}
+
+// ValidateSelfConfigPolicy checks a self-configure policy before it is sent
+// to the cluster: actions must be known and patterns well formed, and no
+// pattern may be both allowed and denied. It also returns warnings for
+// rules that have no effect.
+func ValidateSelfConfigPolicy(policy *v1alpha1.SelfConfigureSpec) ([]string, error) {
+	var problems []string
+	for _, a := range policy.AllowedActions {
+		if !knownSelfConfigureAction(a) {
+			problems = append(problems, fmt.Sprintf("allowedActions: unknown action %q", a))
+		}
+	}
+
+	check := func(section string, rules *v1alpha1.SelfConfigureRules, valid func(string) error) {
+		if rules == nil {
+			return
+		}
+		for _, list := range []struct {
+			name     string
+			patterns []string
+		}{{"allow", rules.Allow}, {"deny", rules.Deny}} {
+			for _, p := range list.patterns {
+				if err := valid(p); err != nil {
+					problems = append(problems, fmt.Sprintf("%s.%s: %v", section, list.name, err))
+				}
+			}
+		}
+		for _, p := range rules.Allow {
+			if containsString(rules.Deny, p) {
+				problems = append(problems, fmt.Sprintf("%s: %q is both allowed and denied", section, p))
+			}
+		}
+	}
+	check("skills", policy.Skills, validSkillPattern)
+	check("configPaths", policy.ConfigPaths, validConfigPathPattern)
+	check("envVars", policy.EnvVars, validEnvVarPattern)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid self-configure policy:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	var warnings []string
+	if !policy.Enabled {
+		return warnings, nil
+	}
+	actions := EffectiveSelfConfigureActions(policy)
+	for _, r := range []struct {
+		action, section string
+		rules           *v1alpha1.SelfConfigureRules
+	}{{"skills", "skills", policy.Skills}, {"config", "configPaths", policy.ConfigPaths}, {"envVars", "envVars", policy.EnvVars}} {
+		if r.rules != nil && !containsString(actions, r.action) {
+			warnings = append(warnings, fmt.Sprintf("%s rules have no effect: the %s action is not allowed", r.section, r.action))
+		}
+	}
+	return warnings, nil
+}
+
+// SetSelfConfigPolicy changes an instance's self-configure policy and
+// returns the new policy and any warnings about it. Nothing is written if
+// the resulting policy is invalid.
+//
+// The skills, configPaths and envVars rules are written first and read
+// back: if the installed CRD does not have them, the API server drops them,
+// and the policy is left without the actions or enabled flag changed rather
+// than allowing actions without the rules meant to restrict them.
+func (c *Client) SetSelfConfigPolicy(ctx context.Context, ns, name string, opts SelfConfigPolicyOptions) (*v1alpha1.SelfConfigureSpec, []string, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	policy, warnings, err := ApplySelfConfigPolicy(inst.Spec.SelfConfigure, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Lists are replaced as a whole by a merge patch; unset rules are sent as
+	// null so that previously set ones are removed.
+	rules := func(r *v1alpha1.SelfConfigureRules) interface{} {
+		if r == nil {
+			return nil
+		}
+		return r
+	}
+	patch := specPatch(map[string]interface{}{
+		"selfConfigure": map[string]interface{}{
+			"skills":      rules(policy.Skills),
+			"configPaths": rules(policy.ConfigPaths),
+			"envVars":     rules(policy.EnvVars),
+		},
+	})
+	patch["metadata"] = map[string]interface{}{"resourceVersion": inst.ResourceVersion}
+	updated, err := c.patchInstance(ctx, ns, name, patch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update self-configure policy: %w", err)
+	}
+	if pruned := prunedSelfConfigRules(&policy, &updated.Spec.SelfConfigure); len(pruned) > 0 {
+		return nil, nil, fmt.Errorf("the self-configure %s rules were not saved: the OpenClawInstance CRD in the cluster does not have them, upgrade the operator to use them; allowed actions were not changed",
+			strings.Join(pruned, ", "))
+	}
+
+	patch = specPatch(map[string]interface{}{
+		"selfConfigure": map[string]interface{}{
+			"enabled":        policy.Enabled,
+			"allowedActions": policy.AllowedActions,
+		},
+	})
+	patch["metadata"] = map[string]interface{}{"resourceVersion": updated.ResourceVersion}
+	updated, err = c.patchInstance(ctx, ns, name, patch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update self-configure policy: %w", err)
+	}
+	return &updated.Spec.SelfConfigure, warnings, nil
+}
+
+// prunedSelfConfigRules names the rule sets of sent that saved does not
+// have as sent.
+func prunedSelfConfigRules(sent, saved *v1alpha1.SelfConfigureSpec) []string {
+	var pruned []string
+	for _, r := range []struct {
+		section     string
+		sent, saved *v1alpha1.SelfConfigureRules
+	}{{"skills", sent.Skills, saved.Skills}, {"configPaths", sent.ConfigPaths, saved.ConfigPaths}, {"envVars", sent.EnvVars, saved.EnvVars}} {
+		if r.sent == nil {
+			continue
+		}
+		if r.saved == nil || !equalStrings(r.sent.Allow, r.saved.Allow) || !equalStrings(r.sent.Deny, r.saved.Deny) {
+			pruned = append(pruned, r.section)
+		}
+	}
+	return pruned
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SelfConfigPolicyViolations lists the ways a self-config request goes beyond
+// its instance's policy: actions that are not allowed, and skills, config
+// paths and environment variables the rules do not permit.
+func SelfConfigPolicyViolations(policy *v1alpha1.SelfConfigureSpec, sc *v1alpha1.OpenClawSelfConfig) []string {
+	if !policy.Enabled {
+		return []string{"self-configuration is disabled"}
+	}
+	var violations []string
+	allowed := EffectiveSelfConfigureActions(policy)
+	for _, a := range sc.Spec.Actions() {
+		if !containsString(allowed, a) {
+			violations = append(violations, fmt.Sprintf("action %s is not allowed", a))
+		}
+	}
+
+	for _, s := range append(append([]string{}, sc.Spec.AddSkills...), sc.Spec.RemoveSkills...) {
+		if !rulesPermit(policy.Skills, func(p string) bool { return globMatch(p, s) }) {
+			violations = append(violations, fmt.Sprintf("skill %q is not allowed", s))
+		}
+	}
+
+	if sc.Spec.ConfigPatch != nil && len(sc.Spec.ConfigPatch.Raw) > 0 {
+		var patch interface{}
+		if err := json.Unmarshal(sc.Spec.ConfigPatch.Raw, &patch); err == nil {
+			for _, path := range configPatchPaths(nil, patch) {
+				if !configPathPermitted(policy.ConfigPaths, path) {
+					violations = append(violations, fmt.Sprintf("config path %s is not allowed", path))
+				}
+			}
+		}
+	}
+
+	var envNames []string
+	for _, e := range sc.Spec.AddEnvVars {
+		envNames = append(envNames, e.Name)
+	}
+	for _, n := range append(envNames, sc.Spec.RemoveEnvVars...) {
+		if !rulesPermit(policy.EnvVars, func(p string) bool { return globMatch(p, n) }) {
+			violations = append(violations, fmt.Sprintf("env var %q is not allowed", n))
+		}
+	}
+	return violations
+}
+
+// rulesPermit applies rules with match deciding whether a pattern matches:
+// nothing denied may match, and if there are allow patterns one must.
+func rulesPermit(rules *v1alpha1.SelfConfigureRules, match func(pattern string) bool) bool {
+	if rules == nil {
+		return true
+	}
+	for _, p := range rules.Deny {
+		if match(p) {
+			return false
+		}
+	}
+	if len(rules.Allow) == 0 {
+		return true
+	}
+	for _, p := range rules.Allow {
+		if match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// configPathPermitted checks a path a request writes. An allow pattern
+// covers everything below it. A deny pattern also rejects writes above it,
+// since replacing or removing a parent changes what is denied.
+func configPathPermitted(rules *v1alpha1.SelfConfigureRules, path ConfigPath) bool {
+	if rules == nil {
+		return true
+	}
+	for _, p := range rules.Deny {
+		pattern, _ := ParseConfigPath(p)
+		if configPathPrefix(pattern, path) || configPathPrefix(path, pattern) {
+			return false
+		}
+	}
+	if len(rules.Allow) == 0 {
+		return true
+	}
+	for _, p := range rules.Allow {
+		pattern, _ := ParseConfigPath(p)
+		if configPathPrefix(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// configPathPrefix reports whether prefix leads to path or is path, with "*"
+// matching any one segment on either side.
+func configPathPrefix(prefix, path ConfigPath) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, seg := range prefix {
+		if seg != "*" && path[i] != "*" && seg != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// configPatchPaths returns the paths a merge patch writes: its leaves, and
+// the keys it sets to null or to anything other than an object.
+func configPatchPaths(prefix ConfigPath, patch interface{}) []ConfigPath {
+	obj, ok := patch.(map[string]interface{})
+	if !ok || (len(obj) == 0 && len(prefix) > 0) {
+		return []ConfigPath{prefix}
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var paths []ConfigPath
+	for _, k := range keys {
+		paths = append(paths, configPatchPaths(append(append(ConfigPath{}, prefix...), k), obj[k])...)
+	}
+	return paths
+}
+
+// globMatch matches s against a pattern in which * stands for any run of
+// characters and ? for one.
+func globMatch(pattern, s string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(expr)
+	return regexp.MustCompile("^" + expr + "$").MatchString(s)
+}
+
+func validSkillPattern(p string) error {
+	if p == "" || strings.ContainsAny(p, " \t\n") {
+		return fmt.Errorf("invalid skill pattern %q", p)
+	}
+	return nil
+}
+
+var envVarPatternRE = regexp.MustCompile(`^[A-Za-z_*?][A-Za-z0-9_*?]*$`)
+
+func validEnvVarPattern(p string) error {
+	if !envVarPatternRE.MatchString(p) {
+		return fmt.Errorf("invalid env var pattern %q: use letters, digits, _ and the wildcards * and ?", p)
+	}
+	return nil
+}
+
+func validConfigPathPattern(p string) error {
+	if p == "" {
+		return errors.New("empty config path")
+	}
+	path, err := ParseConfigPath(p)
+	if err != nil {
+		return err
+	}
+	for _, seg := range path {
+		if seg == "" {
+			return fmt.Errorf("invalid config path %q: empty key", p)
+		}
+	}
+	return nil
+}
+
+func knownSelfConfigureAction(a string) bool {
+	return containsString(AllSelfConfigureActions, a)
+}
+
+func updateRules(current *v1alpha1.SelfConfigureRules, u SelfConfigRulesUpdate) *v1alpha1.SelfConfigureRules {
+	if u.Allow == nil && u.Deny == nil {
+		return current
+	}
+	rules := &v1alpha1.SelfConfigureRules{}
+	if current != nil {
+		*rules = *current
+	}
+	if u.Allow != nil {
+		rules.Allow = u.Allow
+	}
+	if u.Deny != nil {
+		rules.Deny = u.Deny
+	}
+	if len(rules.Allow) == 0 && len(rules.Deny) == 0 {
+		return nil
+	}
+	return rules
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+package openclaw
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestApplySelfConfigPolicy(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name        string
+		policy      v1alpha1.SelfConfigureSpec
+		opts        SelfConfigPolicyOptions
+		wantEnabled bool
+		wantActions []string
+		wantErr     string
+	}{
+		{
+			name:        "allow on a disabled policy enables only that action",
+			opts:        SelfConfigPolicyOptions{Allow: []string{"skills"}},
+			wantEnabled: true,
+			wantActions: []string{"skills"},
+		},
+		{
+			name:        "deny on a disabled policy starts from all actions and stays disabled",
+			opts:        SelfConfigPolicyOptions{Deny: []string{"envVars"}},
+			wantActions: []string{"skills", "config", "workspaceFiles"},
+		},
+		{
+			name:        "deny on an enabled policy without actions starts from all actions",
+			policy:      v1alpha1.SelfConfigureSpec{Enabled: true},
+			opts:        SelfConfigPolicyOptions{Deny: []string{"config", "envVars"}},
+			wantEnabled: true,
+			wantActions: []string{"skills", "workspaceFiles"},
+		},
+		{
+			name:        "allow adds to the listed actions",
+			policy:      v1alpha1.SelfConfigureSpec{Enabled: true, AllowedActions: []string{"skills"}},
+			opts:        SelfConfigPolicyOptions{Allow: []string{"envVars"}},
+			wantEnabled: true,
+			wantActions: []string{"skills", "envVars"},
+		},
+		{
+			name:        "allow with --disable keeps it off",
+			opts:        SelfConfigPolicyOptions{Enabled: &off, Allow: []string{"config"}},
+			wantActions: []string{"config"},
+		},
+		{
+			name:        "enable alone keeps the actions",
+			policy:      v1alpha1.SelfConfigureSpec{AllowedActions: []string{"skills"}},
+			opts:        SelfConfigPolicyOptions{Enabled: &on},
+			wantEnabled: true,
+			wantActions: []string{"skills"},
+		},
+		{
+			name:    "denying every action",
+			policy:  v1alpha1.SelfConfigureSpec{Enabled: true, AllowedActions: []string{"skills"}},
+			opts:    SelfConfigPolicyOptions{Deny: []string{"skills"}},
+			wantErr: "cannot deny every action",
+		},
+		{
+			name:    "denying every action on an empty policy",
+			opts:    SelfConfigPolicyOptions{Deny: AllSelfConfigureActions},
+			wantErr: "cannot deny every action",
+		},
+		{
+			name:    "allowed and denied",
+			opts:    SelfConfigPolicyOptions{Allow: []string{"skills"}, Deny: []string{"skills"}},
+			wantErr: "cannot be both allowed and denied",
+		},
+		{
+			name:    "unknown action",
+			opts:    SelfConfigPolicyOptions{Allow: []string{"secrets"}},
+			wantErr: `unknown self-configure action "secrets"`,
+		},
+		{
+			name:    "invalid pattern",
+			opts:    SelfConfigPolicyOptions{ConfigPaths: SelfConfigRulesUpdate{Deny: []string{"a..b"}}},
+			wantErr: "invalid self-configure policy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := ApplySelfConfigPolicy(tt.policy, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ApplySelfConfigPolicy: %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Enabled != tt.wantEnabled || !reflect.DeepEqual(got.AllowedActions, tt.wantActions) {
+				t.Errorf("policy = enabled %v, actions %q; want enabled %v, actions %q",
+					got.Enabled, got.AllowedActions, tt.wantEnabled, tt.wantActions)
+			}
+		})
+	}
+}
+
+func TestApplySelfConfigPolicyRules(t *testing.T) {
+	policy := v1alpha1.SelfConfigureSpec{
+		Enabled: true,
+		Skills:  &v1alpha1.SelfConfigureRules{Allow: []string{"web-*"}},
+		EnvVars: &v1alpha1.SelfConfigureRules{Deny: []string{"AWS_*"}},
+	}
+	got, _, err := ApplySelfConfigPolicy(policy, SelfConfigPolicyOptions{
+		Skills:      SelfConfigRulesUpdate{Deny: []string{"web-shell"}},
+		ConfigPaths: SelfConfigRulesUpdate{Deny: []string{"gateway"}},
+		EnvVars:     SelfConfigRulesUpdate{Deny: []string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := v1alpha1.SelfConfigureSpec{
+		Enabled:     true,
+		Skills:      &v1alpha1.SelfConfigureRules{Allow: []string{"web-*"}, Deny: []string{"web-shell"}},
+		ConfigPaths: &v1alpha1.SelfConfigureRules{Deny: []string{"gateway"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("policy = %+v, want %+v", got, want)
+	}
+}
+
+func TestRulesPermit(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules *v1alpha1.SelfConfigureRules
+		value string
+		want  bool
+	}{
+		{name: "no rules", value: "anything", want: true},
+		{name: "empty allow list", rules: &v1alpha1.SelfConfigureRules{}, value: "anything", want: true},
+		{name: "empty allow list with deny", rules: &v1alpha1.SelfConfigureRules{Deny: []string{"AWS_*"}}, value: "OPENAI_KEY", want: true},
+		{name: "denied", rules: &v1alpha1.SelfConfigureRules{Deny: []string{"AWS_*"}}, value: "AWS_SECRET", want: false},
+		{name: "allowed", rules: &v1alpha1.SelfConfigureRules{Allow: []string{"web-*"}}, value: "web-search", want: true},
+		{name: "not in the allow list", rules: &v1alpha1.SelfConfigureRules{Allow: []string{"web-*"}}, value: "shell", want: false},
+		{
+			name:  "deny wins over allow",
+			rules: &v1alpha1.SelfConfigureRules{Allow: []string{"web-*"}, Deny: []string{"web-shell"}},
+			value: "web-shell",
+			want:  false,
+		},
+		{name: "? matches one character", rules: &v1alpha1.SelfConfigureRules{Allow: []string{"LOG_?"}}, value: "LOG_A", want: true},
+		{name: "? does not match two", rules: &v1alpha1.SelfConfigureRules{Allow: []string{"LOG_?"}}, value: "LOG_AB", want: false},
+		{name: "patterns match whole names", rules: &v1alpha1.SelfConfigureRules{Deny: []string{"KEY"}}, value: "API_KEY", want: true},
+		{name: "regexp characters are literal", rules: &v1alpha1.SelfConfigureRules{Allow: []string{"a.b"}}, value: "axb", want: false},
+	}
+	for _, tt := range tests {
+		got := rulesPermit(tt.rules, func(p string) bool { return globMatch(p, tt.value) })
+		if got != tt.want {
+			t.Errorf("%s: rulesPermit(%+v, %q) = %v, want %v", tt.name, tt.rules, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestConfigPathPermitted(t *testing.T) {
+	tests := []struct {
+		name  string
+		allow []string
+		deny  []string
+		path  string
+		want  bool
+	}{
+		{name: "empty allow list", path: "gateway.port", want: true},
+		{name: "deny the path itself", deny: []string{"gateway.port"}, path: "gateway.port", want: false},
+		{name: "deny above the path", deny: []string{"gateway"}, path: "gateway.auth.token", want: false},
+		{name: "deny below the path", deny: []string{"gateway.auth.token"}, path: "gateway", want: false},
+		{name: "deny beside the path", deny: []string{"gateway.auth"}, path: "gateway.port", want: true},
+		{name: "allow covers what is below", allow: []string{"agents.defaults"}, path: "agents.defaults.model", want: true},
+		{name: "allow does not cover what is above", allow: []string{"agents.defaults.model"}, path: "agents.defaults", want: false},
+		{name: "not in the allow list", allow: []string{"agents"}, path: "gateway.port", want: false},
+		{
+			name:  "deny wins over allow",
+			allow: []string{"gateway"},
+			deny:  []string{"gateway.auth"},
+			path:  "gateway.auth.mode",
+			want:  false,
+		},
+		{
+			name:  "deny below an allowed path",
+			allow: []string{"gateway"},
+			deny:  []string{"gateway.auth"},
+			path:  "gateway",
+			want:  false,
+		},
+		{name: "* in an allow pattern", allow: []string{"channels.*.enabled"}, path: "channels.telegram.enabled", want: true},
+		{name: "* matches one segment only", allow: []string{"channels.*.enabled"}, path: "channels.telegram.dmPolicy", want: false},
+		{name: "* in a deny pattern", deny: []string{"skills.entries.*.apiKey"}, path: "skills.entries.weather.apiKey", want: false},
+		{name: "* deny below the path", deny: []string{"skills.entries.*.apiKey"}, path: "skills.entries", want: false},
+		{name: "* deny beside the path", deny: []string{"skills.entries.*.apiKey"}, path: "skills.entries.weather.enabled", want: true},
+		{name: "quoted keys", deny: []string{`models."gpt-4.1"`}, path: `models."gpt-4.1".enabled`, want: false},
+	}
+	for _, tt := range tests {
+		path, err := ParseConfigPath(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules := &v1alpha1.SelfConfigureRules{Allow: tt.allow, Deny: tt.deny}
+		if got := configPathPermitted(rules, path); got != tt.want {
+			t.Errorf("%s: configPathPermitted(allow %q, deny %q, %s) = %v, want %v", tt.name, tt.allow, tt.deny, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestConfigPatchPaths(t *testing.T) {
+	tests := []struct {
+		patch string
+		want  []string
+	}{
+		{patch: `{"gateway": {"port": 1}}`, want: []string{"gateway.port"}},
+		{patch: `{"b": 1, "a": {"y": true, "x": "s"}}`, want: []string{"a.x", "a.y", "b"}},
+		{patch: `{"gateway": {"auth": null}}`, want: []string{"gateway.auth"}},
+		{patch: `{"models": [{"name": "a"}]}`, want: []string{"models"}},
+		{patch: `{"gateway": {}}`, want: []string{"gateway"}},
+		{patch: `{"some.key": 1}`, want: []string{`"some.key"`}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, p := range configPatchPaths(nil, decodeConfig(t, tt.patch)) {
+			got = append(got, p.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("configPatchPaths(%s) = %q, want %q", tt.patch, got, tt.want)
+		}
+	}
+}
+
+func TestSelfConfigPolicyViolations(t *testing.T) {
+	policy := &v1alpha1.SelfConfigureSpec{
+		Enabled:        true,
+		AllowedActions: []string{"skills", "config", "envVars"},
+		Skills:         &v1alpha1.SelfConfigureRules{Allow: []string{"web-*"}, Deny: []string{"web-shell"}},
+		ConfigPaths:    &v1alpha1.SelfConfigureRules{Deny: []string{"gateway"}},
+		EnvVars:        &v1alpha1.SelfConfigureRules{Deny: []string{"AWS_*"}},
+	}
+	request := func(spec v1alpha1.OpenClawSelfConfigSpec) *v1alpha1.OpenClawSelfConfig {
+		return &v1alpha1.OpenClawSelfConfig{Spec: spec}
+	}
+	tests := []struct {
+		name   string
+		policy *v1alpha1.SelfConfigureSpec
+		sc     *v1alpha1.OpenClawSelfConfig
+		want   []string
+	}{
+		{
+			name: "within the policy",
+			sc: request(v1alpha1.OpenClawSelfConfigSpec{
+				AddSkills:   []string{"web-search"},
+				ConfigPatch: &runtime.RawExtension{Raw: []byte(`{"agents": {"defaults": {"model": "a"}}}`)},
+				AddEnvVars:  []v1alpha1.SelfConfigEnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+			}),
+		},
+		{
+			name: "disabled policy",
+			policy: &v1alpha1.SelfConfigureSpec{
+				AllowedActions: []string{"skills"},
+			},
+			sc:   request(v1alpha1.OpenClawSelfConfigSpec{AddSkills: []string{"web-search"}}),
+			want: []string{"self-configuration is disabled"},
+		},
+		{
+			name: "action not allowed",
+			sc:   request(v1alpha1.OpenClawSelfConfigSpec{AddWorkspaceFiles: map[string]string{"a.md": "x"}}),
+			want: []string{"action workspaceFiles is not allowed"},
+		},
+		{
+			name: "denied skill, also when removing",
+			sc: request(v1alpha1.OpenClawSelfConfigSpec{
+				AddSkills:    []string{"web-shell"},
+				RemoveSkills: []string{"calendar"},
+			}),
+			want: []string{`skill "web-shell" is not allowed`, `skill "calendar" is not allowed`},
+		},
+		{
+			name: "config below and above a denied path",
+			sc: request(v1alpha1.OpenClawSelfConfigSpec{
+				ConfigPatch: &runtime.RawExtension{Raw: []byte(`{"gateway": {"auth": {"token": "x"}}, "logging": {"level": "debug"}}`)},
+			}),
+			want: []string{"config path gateway.auth.token is not allowed"},
+		},
+		{
+			name: "denied env vars, also when removing",
+			sc: request(v1alpha1.OpenClawSelfConfigSpec{
+				AddEnvVars:    []v1alpha1.SelfConfigEnvVar{{Name: "AWS_SECRET_ACCESS_KEY", Value: "x"}},
+				RemoveEnvVars: []string{"AWS_REGION", "LOG_LEVEL"},
+			}),
+			want: []string{`env var "AWS_SECRET_ACCESS_KEY" is not allowed`, `env var "AWS_REGION" is not allowed`},
+		},
+		{
+			name:   "empty allowedActions allows every action",
+			policy: &v1alpha1.SelfConfigureSpec{Enabled: true},
+			sc:     request(v1alpha1.OpenClawSelfConfigSpec{AddWorkspaceFiles: map[string]string{"a.md": "x"}}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.policy
+			if p == nil {
+				p = policy
+			}
+			got := SelfConfigPolicyViolations(p, tt.sc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sidecar identifies an optional container the operator can run next to the agent.
+type Sidecar string
+
+const (
+	SidecarChromium    Sidecar = "chromium"
+	SidecarTailscale   Sidecar = "tailscale"
+	SidecarOllama      Sidecar = "ollama"
+	SidecarWebTerminal Sidecar = "web-terminal"
+)
+
+var ErrUnknownSidecar = errors.New("unknown sidecar")
+
+// ParseSidecar accepts a sidecar name or one of its aliases (browser, terminal, ttyd).
+func ParseSidecar(name string) (Sidecar, error) {
+	switch name {
+	case "chromium", "browser":
+		return SidecarChromium, nil
+	case "tailscale":
+		return SidecarTailscale, nil
+	case "ollama":
+		return SidecarOllama, nil
+	case "web-terminal", "terminal", "ttyd":
+		return SidecarWebTerminal, nil
+	}
+	return "", fmt.Errorf("%w %q — available: chromium, tailscale, ollama, web-terminal", ErrUnknownSidecar, name)
+}
+
+// specKey is the field under spec that configures the sidecar.
+func (s Sidecar) specKey() string {
+	if s == SidecarWebTerminal {
+		return "webTerminal"
+	}
+	return string(s)
+}
+
+// SidecarOptions configures EnableSidecar. Only the fields relevant to the
+// sidecar being enabled are used.
+type SidecarOptions struct {
+	// Persistence keeps the Chromium profile on a PVC.
+	Persistence bool
+
+	// AuthSecret names the Secret holding the Tailscale auth key. Required for Tailscale.
+	AuthSecret string
+	// Mode is the Tailscale mode: serve (tailnet only) or funnel (public).
+	Mode     string
+	Hostname string
+
+	// Models are pre-pulled by Ollama.
+	Models []string
+	GPU    int
+
+	// ReadOnly makes the web terminal read-only.
+	ReadOnly bool
+}
+
+func (c *Client) EnableSidecar(ctx context.Context, ns, name string, sidecar Sidecar, opts SidecarOptions) error {
+	sidecarSpec := map[string]interface{}{"enabled": true}
+
+	switch sidecar {
+	case SidecarChromium:
+		if opts.Persistence {
+			sidecarSpec["persistence"] = map[string]interface{}{"enabled": true}
+		}
+
+	case SidecarTailscale:
+		if opts.AuthSecret == "" {
+			return fmt.Errorf("an auth key Secret is required for tailscale")
+		}
+		sidecarSpec["authKeySecretRef"] = map[string]interface{}{"name": opts.AuthSecret}
+		if opts.Mode != "" {
+			sidecarSpec["mode"] = opts.Mode
+		}
+		if opts.Hostname != "" {
+			sidecarSpec["hostname"] = opts.Hostname
+		}
+
+	case SidecarOllama:
+		if len(opts.Models) > 0 {
+			sidecarSpec["models"] = opts.Models
+		}
+		if opts.GPU > 0 {
+			sidecarSpec["gpu"] = opts.GPU
+		}
+
+	case SidecarWebTerminal:
+		if opts.ReadOnly {
+			sidecarSpec["readOnly"] = true
+		}
+
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownSidecar, sidecar)
+	}
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{sidecar.specKey(): sidecarSpec})); err != nil {
+		return fmt.Errorf("failed to enable %s: %w", sidecar, err)
+	}
+	return nil
+}
+
+func (c *Client) DisableSidecar(ctx context.Context, ns, name string, sidecar Sidecar) error {
+	if _, err := ParseSidecar(string(sidecar)); err != nil {
+		return err
+	}
+
+	patch := specPatch(map[string]interface{}{
+		sidecar.specKey(): map[string]interface{}{
+			"enabled": false,
+		},
+	})
+	if _, err := c.patchInstance(ctx, ns, name, patch); err != nil {
+		return fmt.Errorf("failed to disable %s: %w", sidecar, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddSkills appends skills that are not already installed and returns how
+// many were added. Nothing is written when every skill is already present.
+func (c *Client) AddSkills(ctx context.Context, ns, name string, skills ...string) (int, error) {
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return 0, err
+	}
+
+	// Dedup: build set of existing skills
+	seen := make(map[string]bool)
+	for _, s := range inst.Spec.Skills {
+		seen[s] = true
+	}
+
+	merged := append([]string{}, inst.Spec.Skills...)
+
+	added := 0
+	for _, s := range skills {
+		if !seen[s] {
+			merged = append(merged, s)
+			seen[s] = true
+			added++
+		}
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"skills": merged})); err != nil {
+		return 0, fmt.Errorf("failed to update skills: %w", err)
+	}
+	return added, nil
+}
+
+// RemoveSkills removes the given skills and returns how many were removed.
+func (c *Client) RemoveSkills(ctx context.Context, ns, name string, skills ...string) (int, error) {
+	toRemove := make(map[string]bool)
+	for _, s := range skills {
+		toRemove[s] = true
+	}
+
+	inst, err := c.GetInstance(ctx, ns, name)
+	if err != nil {
+		return 0, err
+	}
+
+	// Use empty slice instead of nil to clear all skills
+	filtered := []string{}
+	removed := 0
+	for _, s := range inst.Spec.Skills {
+		if toRemove[s] {
+			removed++
+		} else {
+			filtered = append(filtered, s)
+		}
+	}
+
+	if removed == 0 {
+		return 0, nil
+	}
+
+	if _, err := c.patchInstance(ctx, ns, name, specPatch(map[string]interface{}{"skills": filtered})); err != nil {
+		return 0, fmt.Errorf("failed to update skills: %w", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,112 @@
+package openclaw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/semver"
+)
+
+// AutoUpdatePausedAnnotation, set to "true", marks auto-update as turned off
+// by PauseAutoUpdate rather than disabled, so that ResumeAutoUpdate knows to
+// turn it back on. The operator does not read it: pausing sets
+// spec.autoUpdate.enabled to false, keeping the other auto-update settings.
+const AutoUpdatePausedAnnotation = "openclaw.rocks/auto-update-paused"
+
+var (
+	// ErrAutoUpdateActive is returned by Upgrade for instances the operator
+	// upgrades on its own, which would undo or race a manual upgrade.
+	ErrAutoUpdateActive = errors.New("auto-update is enabled")
+	// ErrUpgradeNeedsForce is returned by Upgrade for downgrades and major
+	// version jumps unless UpgradeOptions.Force is set.
+	ErrUpgradeNeedsForce = errors.New("upgrade requires --force")
+)
+
+// AutoUpdatePaused reports whether auto-update is paused for the instance,
+// that is off since PauseAutoUpdate.
+func AutoUpdatePaused(inst *v1alpha1.OpenClawInstance) bool {
+	return !inst.Spec.AutoUpdateEnabled() && inst.Annotations[AutoUpdatePausedAnnotation] == "true"
+}
+
+// UpgradeCheck describes a version change as far as the tags tell.
+type UpgradeCheck struct {
+	// From and To are the current and target tags. From falls back to the
+	// version reported by auto-update when the spec has no tag.
+	From string
+	To   string
+	// Comparable is set when both tags are semantic versions.
+	Comparable bool
+	Downgrade  bool
+	MajorJump  bool
+	// AutoUpdateActive is set when auto-update is enabled.
+	AutoUpdateActive bool
+}
+
+// NeedsForce reports whether the change is a downgrade or a major jump.
+func (u UpgradeCheck) NeedsForce() bool {
+	return u.Downgrade || u.MajorJump
+}
+
+// Warning describes why the change needs force, or is empty.
+func (u UpgradeCheck) Warning() string {
+	switch {
+	case u.Downgrade:
+		return fmt.Sprintf("%s -> %s is a downgrade; data written by the newer version may not be readable", u.From, u.To)
+	case u.MajorJump:
+		return fmt.Sprintf("%s -> %s crosses a major version; check the release notes for breaking changes", u.From, u.To)
+	}
+	return ""
+}
+
+// CheckUpgrade compares the instance's current tag with the target of opts.
+// Tags that are not semantic versions, and digest-only upgrades, are not
+// compared.
+func CheckUpgrade(inst *v1alpha1.OpenClawInstance, opts UpgradeOptions) UpgradeCheck {
+	check := UpgradeCheck{
+		From:             inst.Spec.Image.Tag,
+		To:               opts.Tag,
+		AutoUpdateActive: checkAutoUpdate(inst) != nil,
+	}
+	if check.From == "" && inst.Status.AutoUpdate != nil {
+		check.From = inst.Status.AutoUpdate.CurrentVersion
+	}
+
+	from, err := semver.Parse(check.From)
+	if err != nil {
+		return check
+	}
+	to, err := semver.Parse(check.To)
+	if err != nil {
+		return check
+	}
+	check.Comparable = true
+	check.Downgrade = to.LessThan(from)
+	check.MajorJump = to.Major > from.Major
+	return check
+}
+
+// Err returns why Upgrade refuses the change, or nil. force allows
+// downgrades and major jumps but not upgrading under active auto-update.
+func (u UpgradeCheck) Err(force bool) error {
+	if u.AutoUpdateActive {
+		return errAutoUpdateActive()
+	}
+	if u.NeedsForce() && !force {
+		return fmt.Errorf("%w: %s", ErrUpgradeNeedsForce, u.Warning())
+	}
+	return nil
+}
+
+// checkAutoUpdate refuses image changes the operator would undo or race
+// because auto-update is active.
+func checkAutoUpdate(inst *v1alpha1.OpenClawInstance) error {
+	if inst.Spec.AutoUpdateEnabled() {
+		return errAutoUpdateActive()
+	}
+	return nil
+}
+
+func errAutoUpdateActive() error {
+	return fmt.Errorf("%w; pause it first (claw autoupdate NAME pause) so the operator does not replace the new image", ErrAutoUpdateActive)
+}
@@ -0,0 +1,299 @@
+package openclaw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openclaw-rocks/kubectl-openclaw/pkg/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// ErrInstanceFailed is returned by WaitFor when the instance reaches the
+// Failed or Degraded phase for its current generation.
+var ErrInstanceFailed = errors.New("instance failed")
+
+// WaitCondition is one requirement WaitFor blocks on.
+type WaitCondition struct {
+	// Phase is the required status.phase.
+	Phase string
+	// ConditionType and ConditionStatus require a status condition, e.g. Ready=True.
+	ConditionType   string
+	ConditionStatus string
+	// Generation requires status.observedGeneration to equal metadata.generation,
+	// i.e. the operator has reconciled the latest spec.
+	Generation bool
+}
+
+// Settled is what commands wait for after changing an instance: the operator
+// has observed the change and the instance is Running again.
+var Settled = []WaitCondition{{Generation: true}, {Phase: v1alpha1.PhaseRunning}}
+
+// ParseWaitCondition parses the forms accepted by "wait --for":
+//
+//	phase=Running
+//	condition=Ready            (status defaults to True)
+//	condition=Ready=False
+//	generation
+func ParseWaitCondition(s string) (WaitCondition, error) {
+	key, value, _ := strings.Cut(s, "=")
+	switch strings.ToLower(key) {
+	case "phase":
+		if value == "" {
+			return WaitCondition{}, fmt.Errorf("invalid wait condition %q: phase requires a value", s)
+		}
+		return WaitCondition{Phase: value}, nil
+	case "condition":
+		condType, status, found := strings.Cut(value, "=")
+		if condType == "" {
+			return WaitCondition{}, fmt.Errorf("invalid wait condition %q: condition requires a type", s)
+		}
+		if !found {
+			status = string(metav1.ConditionTrue)
+		}
+		return WaitCondition{ConditionType: condType, ConditionStatus: status}, nil
+	case "generation":
+		return WaitCondition{Generation: true}, nil
+	}
+	return WaitCondition{}, fmt.Errorf("invalid wait condition %q: use phase=PHASE, condition=TYPE[=STATUS] or generation", s)
+}
+
+func (w WaitCondition) String() string {
+	switch {
+	case w.Phase != "":
+		return "phase=" + w.Phase
+	case w.ConditionType != "":
+		return "condition=" + w.ConditionType + "=" + w.ConditionStatus
+	case w.Generation:
+		return "generation"
+	}
+	return ""
+}
+
+// Met reports whether inst satisfies the condition.
+func (w WaitCondition) Met(inst *v1alpha1.OpenClawInstance) bool {
+	switch {
+	case w.Phase != "":
+		return strings.EqualFold(inst.Status.CurrentPhase(), w.Phase)
+	case w.ConditionType != "":
+		return strings.EqualFold(inst.Status.ConditionStatus(w.ConditionType), w.ConditionStatus)
+	case w.Generation:
+		return inst.Status.ObservedGeneration >= inst.Generation
+	}
+	return true
+}
+
+// WaitFor watches an instance until all conditions hold, and returns it. It
+// fails early with ErrInstanceFailed once the operator has observed the
+// current generation and reports Failed or Degraded, unless that phase is
+// what was asked for. Use a context deadline to bound the wait.
+func (c *Client) WaitFor(ctx context.Context, ns, name string, conditions ...WaitCondition) (*v1alpha1.OpenClawInstance, error) {
+	var last *v1alpha1.OpenClawInstance
+
+	check := func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Deleted:
+			return false, fmt.Errorf("instance %s/%s was deleted", ns, name)
+		case watch.Added, watch.Modified:
+		default:
+			return false, nil
+		}
+
+		obj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok {
+			return false, nil
+		}
+		inst, err := v1alpha1.FromUnstructured(obj)
+		if err != nil {
+			return false, err
+		}
+		last = inst
+
+		met := true
+		for _, cond := range conditions {
+			if !cond.Met(inst) {
+				met = false
+				break
+			}
+		}
+		if met {
+			return true, nil
+		}
+		if err := failedPhase(inst, conditions); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, c.instanceListWatch(ctx, ns, name), &unstructured.Unstructured{}, nil, check)
+	if err != nil {
+		if errors.Is(err, ErrInstanceFailed) || ctx.Err() == nil {
+			return last, err
+		}
+		if last == nil {
+			return nil, fmt.Errorf("timed out waiting for instance %s/%s", ns, name)
+		}
+		return last, fmt.Errorf("timed out waiting for %s/%s (phase %s)", ns, name, last.Status.CurrentPhase())
+	}
+	return last, nil
+}
+
+// failedPhase returns ErrInstanceFailed if the instance is Failed or Degraded
+// for its current spec and none of the conditions asks for that phase.
+func failedPhase(inst *v1alpha1.OpenClawInstance, conditions []WaitCondition) error {
+	phase := inst.Status.CurrentPhase()
+	if phase != v1alpha1.PhaseFailed && phase != v1alpha1.PhaseDegraded {
+		return nil
+	}
+	if inst.Status.ObservedGeneration < inst.Generation {
+		// The phase describes the previous spec; give the operator a chance.
+		return nil
+	}
+	for _, cond := range conditions {
+		if strings.EqualFold(cond.Phase, phase) {
+			return nil
+		}
+	}
+
+	msg := ""
+	if ready := inst.Status.Condition("Ready"); ready != nil && ready.Message != "" {
+		msg = ": " + ready.Message
+	}
+	return fmt.Errorf("%w: %s/%s is %s%s", ErrInstanceFailed, inst.Namespace, inst.Name, phase, msg)
+}
+
+// WaitUntilRestorable blocks until the instance is no longer BackingUp or
+// Restoring, e.g. after a backup taken just before a restore. It fails at
+// once if something else keeps the instance from being restored, see
+// CheckRestorable.
+func (c *Client) WaitUntilRestorable(ctx context.Context, ns, name string) error {
+	var phase string
+	check := func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Deleted:
+			return false, fmt.Errorf("instance %s/%s was deleted", ns, name)
+		case watch.Added, watch.Modified:
+		default:
+			return false, nil
+		}
+		obj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok {
+			return false, nil
+		}
+		inst, err := v1alpha1.FromUnstructured(obj)
+		if err != nil {
+			return false, err
+		}
+		phase = inst.Status.CurrentPhase()
+		if phase == v1alpha1.PhaseBackingUp || phase == v1alpha1.PhaseRestoring {
+			return false, nil
+		}
+		return true, CheckRestorable(inst)
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, c.instanceListWatch(ctx, ns, name), &unstructured.Unstructured{}, nil, check)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for %s/%s to leave phase %s", ns, name, phase)
+	}
+	return err
+}
+
+// WaitForDeletion blocks until the instance no longer exists.
+func (c *Client) WaitForDeletion(ctx context.Context, ns, name string) error {
+	gone := func(store cache.Store) (bool, error) {
+		return len(store.List()) == 0, nil
+	}
+	deleted := func(event watch.Event) (bool, error) {
+		return event.Type == watch.Deleted, nil
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, c.instanceListWatch(ctx, ns, name), &unstructured.Unstructured{}, gone, deleted)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for %s/%s to be deleted", ns, name)
+	}
+	return err
+}
+
+// WaitForPodsReady blocks until the instance has at least minPods pods, all
+// created at or after since and Ready. It is used after Restart, where the
+// instance itself does not change.
+func (c *Client) WaitForPodsReady(ctx context.Context, ns, name string, since time.Time, minPods int) error {
+	// creationTimestamp has second precision.
+	since = since.Truncate(time.Second)
+	ready := map[string]bool{}
+
+	check := func(event watch.Event) (bool, error) {
+		pod, ok := event.Object.(*corev1.Pod)
+		if !ok {
+			return false, nil
+		}
+		switch event.Type {
+		case watch.Deleted:
+			delete(ready, pod.Name)
+		case watch.Added, watch.Modified:
+			ready[pod.Name] = !pod.CreationTimestamp.Time.Before(since) && podReady(pod)
+		}
+
+		if len(ready) < minPods || len(ready) == 0 {
+			return false, nil
+		}
+		for _, r := range ready {
+			if !r {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	selector := PodLabelSelector(name)
+	pods := c.clients.Kube.CoreV1().Pods(ns)
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.LabelSelector = selector
+			return pods.List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.LabelSelector = selector
+			return pods.Watch(ctx, opts)
+		},
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, lw, &corev1.Pod{}, nil, check)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for pods of %s/%s to become ready", ns, name)
+	}
+	return err
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// instanceListWatch lists and watches a single instance by name.
+func (c *Client) instanceListWatch(ctx context.Context, ns, name string) cache.ListerWatcher {
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return c.instances(ns).List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return c.instances(ns).Watch(ctx, opts)
+		},
+	}
+}
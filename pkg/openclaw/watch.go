@@ -0,0 +1,105 @@
+package openclaw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WatchInstance notifies on the returned channel whenever the instance, one of
+// its pods, or an event about them changes. Notifications are coalesced: a
+// burst of changes may produce a single send, so receivers should re-read
+// whatever they display. Watching stops when ctx is done; the channel is not
+// closed, so receivers should also select on ctx.Done().
+func (c *Client) WatchInstance(ctx context.Context, ns, name string) (<-chan struct{}, error) {
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	instanceSelector := fmt.Sprintf("metadata.name=%s", name)
+	podSelector := PodLabelSelector(name)
+	pods := c.clients.Kube.CoreV1().Pods(ns)
+	events := c.clients.Kube.CoreV1().Events(ns)
+
+	informers := []cache.SharedInformer{
+		cache.NewSharedInformer(&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.FieldSelector = instanceSelector
+				return c.instances(ns).List(ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.FieldSelector = instanceSelector
+				return c.instances(ns).Watch(ctx, opts)
+			},
+		}, &unstructured.Unstructured{}, 0),
+		cache.NewSharedInformer(&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.LabelSelector = podSelector
+				return pods.List(ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.LabelSelector = podSelector
+				return pods.Watch(ctx, opts)
+			},
+		}, &corev1.Pod{}, 0),
+		// Events cannot be selected by "instance or one of its objects", so
+		// watch the namespace and filter by name.
+		cache.NewSharedInformer(&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return events.List(ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return events.Watch(ctx, opts)
+			},
+		}, &corev1.Event{}, 0),
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { notifyIfRelated(obj, name, notify) },
+		UpdateFunc: func(_, obj interface{}) { notifyIfRelated(obj, name, notify) },
+		DeleteFunc: func(obj interface{}) { notifyIfRelated(obj, name, notify) },
+	}
+
+	synced := make([]cache.InformerSynced, 0, len(informers))
+	for _, inf := range informers {
+		if _, err := inf.AddEventHandler(handler); err != nil {
+			return nil, fmt.Errorf("failed to watch %s/%s: %w", ns, name, err)
+		}
+		go inf.Run(ctx.Done())
+		synced = append(synced, inf.HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return nil, fmt.Errorf("failed to watch %s/%s: %w", ns, name, ctx.Err())
+	}
+
+	return changed, nil
+}
+
+// notifyIfRelated calls notify unless obj is an event about an unrelated object.
+func notifyIfRelated(obj interface{}, name string, notify func()) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if e, ok := obj.(*corev1.Event); ok && !eventRelatedTo(e, name) {
+		return
+	}
+	notify()
+}
+
+// eventRelatedTo reports whether an event is about the named instance or one
+// of the objects the operator created for it, which share its name as prefix.
+func eventRelatedTo(e *corev1.Event, name string) bool {
+	involved := e.InvolvedObject.Name
+	return involved == name || strings.HasPrefix(involved, name+"-")
+}
@@ -0,0 +1,373 @@
+// Package registry is a minimal client for the OCI distribution API: it lists
+// the tags of a repository and resolves tags to manifest digests. It handles
+// the bearer token and basic auth challenges used by Docker Hub, GHCR, ECR,
+// Harbor and the reference registry.
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// manifestMediaTypes are accepted when resolving a digest, so that
+// multi-platform images resolve to their index, as the kubelet does.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Reference is an image repository on a registry.
+type Reference struct {
+	// Registry is the host, e.g. ghcr.io or localhost:5000.
+	Registry string
+	// Repository is the path, e.g. openclaw/openclaw.
+	Repository string
+}
+
+func (r Reference) String() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// ParseReference splits an image name without tag or digest into registry and
+// repository, applying Docker Hub's defaults to names without a registry.
+func ParseReference(name string) (Reference, error) {
+	if name == "" || strings.ContainsAny(name, "@ ") {
+		return Reference{}, fmt.Errorf("invalid image repository %q", name)
+	}
+	first, rest, found := strings.Cut(name, "/")
+	if !found || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		// Docker Hub, e.g. "nginx" or "library/nginx".
+		if !found {
+			return Reference{Registry: "docker.io", Repository: "library/" + name}, nil
+		}
+		return Reference{Registry: "docker.io", Repository: name}, nil
+	}
+	return Reference{Registry: first, Repository: rest}, nil
+}
+
+// Credentials authenticate against one registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Options configures a Client.
+type Options struct {
+	// Credentials by registry host. Optional; anonymous access is tried
+	// without them.
+	Credentials map[string]Credentials
+	// PlainHTTP talks HTTP instead of HTTPS. It is implied for localhost.
+	PlainHTTP bool
+	// HTTPClient defaults to a client with a 30s timeout.
+	HTTPClient *http.Client
+}
+
+// Client talks to OCI registries. It caches bearer tokens per scope.
+type Client struct {
+	opts Options
+	http *http.Client
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func New(opts Options) *Client {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{opts: opts, http: httpClient, tokens: map[string]string{}}
+}
+
+// Tags lists all tags of a repository, following pagination.
+func (c *Client) Tags(ctx context.Context, ref Reference) ([]string, error) {
+	var tags []string
+	next := "/v2/" + ref.Repository + "/tags/list?n=1000"
+	for next != "" {
+		resp, err := c.do(ctx, http.MethodGet, ref, next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", ref, err)
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tags of %s: %w", ref, err)
+		}
+		tags = append(tags, page.Tags...)
+		next = nextLink(resp.Header.Get("Link"))
+	}
+	return tags, nil
+}
+
+// Digest resolves a tag to the digest of its manifest or index.
+func (c *Client) Digest(ctx context.Context, ref Reference, tag string) (string, error) {
+	header := http.Header{"Accept": {strings.Join(manifestMediaTypes, ", ")}}
+	path := "/v2/" + ref.Repository + "/manifests/" + tag
+
+	resp, err := c.do(ctx, http.MethodHead, ref, path, header)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s:%s: %w", ref, tag, err)
+	}
+	resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Some registries omit the header on HEAD; hash the manifest instead.
+	resp, err = c.do(ctx, http.MethodGet, ref, path, header)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s:%s: %w", ref, tag, err)
+	}
+	defer resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest of %s:%s: %w", ref, tag, err)
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// do sends a request, answering one auth challenge if the registry asks.
+// Non-2xx responses are returned as errors.
+func (c *Client) do(ctx context.Context, method string, ref Reference, path string, header http.Header) (*http.Response, error) {
+	scope := "repository:" + ref.Repository + ":pull"
+	send := func(auth string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL(ref.Registry)+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		return c.http.Do(req)
+	}
+
+	resp, err := send(c.cachedToken(ref.Registry, scope))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		auth, err := c.authorize(ctx, ref.Registry, scope, challenge)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = send(auth); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp, nil
+}
+
+// authorize answers a WWW-Authenticate challenge and returns the
+// Authorization header to retry with.
+func (c *Client) authorize(ctx context.Context, registry, scope, challenge string) (string, error) {
+	creds, hasCreds := c.opts.Credentials[registry]
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCreds {
+			return "", fmt.Errorf("registry %s requires credentials; add an image pull secret", registry)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(creds.Username, creds.Password)
+		return req.Header.Get("Authorization"), nil
+
+	case "bearer":
+		realm := params["realm"]
+		if realm == "" {
+			return "", fmt.Errorf("registry %s sent a bearer challenge without realm", registry)
+		}
+		u, err := url.Parse(realm)
+		if err != nil {
+			return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+		}
+		q := u.Query()
+		if params["service"] != "" {
+			q.Set("service", params["service"])
+		}
+		if s := params["scope"]; s != "" {
+			scope = s
+		}
+		q.Set("scope", scope)
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if hasCreds {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to get registry token from %s: %w", u.Host, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to get registry token from %s: %w", u.Host, responseError(resp))
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return "", fmt.Errorf("failed to decode registry token: %w", err)
+		}
+		t := token.Token
+		if t == "" {
+			t = token.AccessToken
+		}
+		auth := "Bearer " + t
+		c.mu.Lock()
+		c.tokens[registry+" "+scope] = auth
+		c.mu.Unlock()
+		return auth, nil
+	}
+	return "", fmt.Errorf("registry %s requires unsupported authentication %q", registry, scheme)
+}
+
+func (c *Client) cachedToken(registry, scope string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[registry+" "+scope]
+}
+
+func (c *Client) baseURL(registry string) string {
+	if registry == "docker.io" {
+		registry = "registry-1.docker.io"
+	}
+	host := registry
+	if h, _, ok := strings.Cut(registry, ":"); ok {
+		host = h
+	}
+	if c.opts.PlainHTTP || host == "localhost" || host == "127.0.0.1" {
+		return "http://" + registry
+	}
+	return "https://" + registry
+}
+
+// parseChallenge parses `Bearer realm="...",service="...",scope="..."`.
+// Quoted values may contain commas and backslash-escaped quotes; parameter
+// names are lower-cased.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for {
+		rest = strings.TrimLeft(rest, " ,")
+		if rest == "" {
+			break
+		}
+		var key, value string
+		key, rest, _ = strings.Cut(rest, "=")
+		rest = strings.TrimLeft(rest, " ")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest = unquote(rest[1:])
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+			value = strings.TrimSpace(value)
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
+
+// unquote reads a quoted-string whose opening quote has been consumed and
+// returns its value and what follows the closing quote. An unterminated
+// string runs to the end.
+func unquote(s string) (string, string) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:]
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), ""
+}
+
+// nextLink extracts the path of the `<...>; rel="next"` entry of a Link
+// header, which may list other relations too.
+func nextLink(header string) string {
+	for header != "" {
+		start := strings.Index(header, "<")
+		end := strings.Index(header, ">")
+		if start < 0 || end < start {
+			return ""
+		}
+		link := header[start+1 : end]
+		header = header[end+1:]
+
+		// The entry's parameters run up to the next link.
+		params := header
+		if i := strings.Index(params, "<"); i >= 0 {
+			params = params[:i]
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(param, "=")
+			if !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+			// rel may be quoted and hold several space-separated relations.
+			value = strings.TrimSuffix(strings.TrimSpace(value), ",")
+			for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+				if strings.EqualFold(rel, "next") {
+					if u, err := url.Parse(link); err == nil && u.IsAbs() {
+						return u.RequestURI()
+					}
+					return link
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func responseError(resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body) == nil && len(body.Errors) > 0 {
+		return fmt.Errorf("%s: %s: %s", resp.Status, body.Errors[0].Code, body.Errors[0].Message)
+	}
+	return fmt.Errorf("%s", resp.Status)
+}
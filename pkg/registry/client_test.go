@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChallenge(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		wantScheme string
+		wantParams map[string]string
+	}{
+		{
+			name:       "docker hub",
+			header:     `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{
+				"realm":   "https://auth.docker.io/token",
+				"service": "registry.docker.io",
+				"scope":   "repository:library/alpine:pull",
+			},
+		},
+		{
+			name:       "quoted comma in scope",
+			header:     `Bearer realm="https://ghcr.io/token",scope="repository:org/app:pull,push",service="ghcr.io"`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{
+				"realm":   "https://ghcr.io/token",
+				"scope":   "repository:org/app:pull,push",
+				"service": "ghcr.io",
+			},
+		},
+		{
+			name:       "spaces after commas and around equals",
+			header:     `Bearer realm = "https://r.example/token", service="r.example"`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{"realm": "https://r.example/token", "service": "r.example"},
+		},
+		{
+			name:       "escaped quote",
+			header:     `Basic realm="say \"hi\""`,
+			wantScheme: "Basic",
+			wantParams: map[string]string{"realm": `say "hi"`},
+		},
+		{
+			name:       "unquoted values and upper-case names",
+			header:     `Bearer Realm=https://r.example/token,Service=r.example`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{"realm": "https://r.example/token", "service": "r.example"},
+		},
+		{
+			name:       "realm only",
+			header:     `Basic realm="Registry Realm"`,
+			wantScheme: "Basic",
+			wantParams: map[string]string{"realm": "Registry Realm"},
+		},
+		{
+			name:       "no parameters",
+			header:     `Bearer`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{},
+		},
+		{
+			name:       "unterminated quote",
+			header:     `Bearer realm="https://r.example/token`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{"realm": "https://r.example/token"},
+		},
+		{
+			name:       "empty value and trailing comma",
+			header:     `Bearer realm="https://r.example/token",service=,`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{"realm": "https://r.example/token", "service": ""},
+		},
+		{
+			name:       "empty header",
+			header:     ``,
+			wantScheme: "",
+			wantParams: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme, params := parseChallenge(tt.header)
+			if scheme != tt.wantScheme {
+				t.Errorf("scheme = %q, want %q", scheme, tt.wantScheme)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"none", ``, ""},
+		{"next", `</v2/org/app/tags/list?last=v1.2&n=100>; rel="next"`, "/v2/org/app/tags/list?last=v1.2&n=100"},
+		{"unquoted rel", `</v2/app/tags/list?last=b>; rel=next`, "/v2/app/tags/list?last=b"},
+		{"absolute URL", `<https://ghcr.io/v2/org/app/tags/list?last=v1&n=50>; rel="next"`, "/v2/org/app/tags/list?last=v1&n=50"},
+		{"prev before next", `</v2/app/tags/list?last=a>; rel="prev", </v2/app/tags/list?last=c>; rel="next"`, "/v2/app/tags/list?last=c"},
+		{"next before prev", `</v2/app/tags/list?last=c>; rel="next", </v2/app/tags/list?last=a>; rel="prev"`, "/v2/app/tags/list?last=c"},
+		{"several relations", `</v2/app/tags/list?last=c>; rel="last next"`, "/v2/app/tags/list?last=c"},
+		{"other parameters", `</v2/app/tags/list?last=c>; type="text/html"; rel="next"`, "/v2/app/tags/list?last=c"},
+		{"only prev", `</v2/app/tags/list?last=a>; rel="prev"`, ""},
+		{"no rel", `</v2/app/tags/list?last=a>`, ""},
+		{"rel=next outside a link", `rel="next"`, ""},
+		{"unterminated link", `</v2/app/tags/list?last=a; rel="next"`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextLink(tt.header); got != tt.want {
+				t.Errorf("nextLink(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
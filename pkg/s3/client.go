@@ -0,0 +1,175 @@
+// Package s3 is a minimal client for listing objects in S3-compatible object
+// stores (AWS S3, MinIO, Ceph RGW, R2, ...). Requests use path-style URLs and
+// AWS Signature Version 4, so no SDK is needed.
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config describes how to reach an object store.
+type Config struct {
+	// Endpoint is the base URL, e.g. http://minio.minio:9000. Defaults to the
+	// AWS endpoint of Region.
+	Endpoint string
+	// Region is used for request signing. Defaults to us-east-1.
+	Region string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// HTTPClient defaults to a client with a 30s timeout.
+	HTTPClient *http.Client
+}
+
+// Client lists objects. Requests are anonymous when no access key is set.
+type Client struct {
+	cfg      Config
+	endpoint *url.URL
+	http     *http.Client
+}
+
+// Object is one entry of a listing.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// New validates cfg and returns a client.
+func New(cfg Config) (*Client, error) {
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	if !strings.Contains(cfg.Endpoint, "://") {
+		cfg.Endpoint = "https://" + cfg.Endpoint
+	}
+	u, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{cfg: cfg, endpoint: u, http: httpClient}, nil
+}
+
+// Endpoint returns the base URL requests are sent to.
+func (c *Client) Endpoint() string {
+	return c.endpoint.String()
+}
+
+// List returns every object in bucket whose key starts with prefix, in key
+// order. It follows continuation tokens until the listing is complete.
+func (c *Client) List(ctx context.Context, bucket, prefix string) ([]Object, error) {
+	var objects []Object
+	token := ""
+	for {
+		page, err := c.listPage(ctx, bucket, prefix, token, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range page.Contents {
+			objects = append(objects, Object{Key: o.Key, Size: o.Size, LastModified: o.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// Exists reports whether key is an object, or a prefix with objects below
+// key+"/".
+func (c *Client) Exists(ctx context.Context, bucket, key string) (bool, error) {
+	key = strings.TrimSuffix(key, "/")
+	page, err := c.listPage(ctx, bucket, key+"/", "", 1)
+	if err != nil {
+		return false, err
+	}
+	if len(page.Contents) > 0 {
+		return true, nil
+	}
+	// An exact match sorts before any other key sharing the prefix.
+	page, err = c.listPage(ctx, bucket, key, "", 1)
+	if err != nil {
+		return false, err
+	}
+	return len(page.Contents) > 0 && page.Contents[0].Key == key, nil
+}
+
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// listPage performs one ListObjectsV2 request.
+func (c *Client) listPage(ctx context.Context, bucket, prefix, token string, maxKeys int) (*listBucketResult, error) {
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	if token != "" {
+		query.Set("continuation-token", token)
+	}
+	if maxKeys > 0 {
+		query.Set("max-keys", strconv.Itoa(maxKeys))
+	}
+
+	u := *c.endpoint
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + bucket
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build S3 request: %w", err)
+	}
+	c.sign(req, time.Now().UTC())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list s3://%s/%s: %w", bucket, prefix, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if xml.Unmarshal(body, &e) == nil && e.Code != "" {
+			return nil, fmt.Errorf("failed to list s3://%s/%s: %s: %s", bucket, prefix, e.Code, e.Message)
+		}
+		return nil, fmt.Errorf("failed to list s3://%s/%s: %s", bucket, prefix, resp.Status)
+	}
+
+	var result listBucketResult
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode S3 listing: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,197 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a version range such as ">=1.2 <2", "~1.4" or "^2 || ^3".
+// Comparisons separated by spaces or commas must all hold; alternatives are
+// separated by "||". Supported operators are =, !=, >, >=, <, <=, ~ (patch
+// updates, or minor updates when only a major is given) and ^ (updates that
+// keep the leftmost non-zero part). A version without operator, such as
+// "1.4" or "1.4.x", matches everything it does not specify.
+//
+// Partial versions follow npm: they stand for the range of versions they
+// match, so ">1.2" means ">=1.3.0", "<=1.2" means "<1.3.0", ">=1.2" means
+// ">=1.2.0" and "<1.2" means "<1.2.0".
+//
+// As with npm, a prerelease only matches a range whose comparisons name a
+// prerelease of the same major, minor and patch version: ">=1.2.3-rc.1"
+// matches 1.2.3-rc.2 but not 1.2.4-rc.1.
+type Constraint struct {
+	original string
+	anyOf    [][]comparison
+}
+
+type comparison struct {
+	op string
+	v  Version
+	// upper, for != of a partial version, is the first version above the
+	// range that is excluded.
+	upper *Version
+}
+
+// ParseConstraint parses a version constraint.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{original: s}
+	for _, alt := range strings.Split(s, "||") {
+		var group []comparison
+		fields := strings.FieldsFunc(alt, func(r rune) bool { return r == ' ' || r == ',' })
+		for i := 0; i < len(fields); i++ {
+			term := fields[i]
+			// Allow a space between operator and version, as in ">= 1.2".
+			if strings.Trim(term, "<>=!~^") == "" && i+1 < len(fields) {
+				i++
+				term += fields[i]
+			}
+			cmps, err := parseTerm(term)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", s, err)
+			}
+			group = append(group, cmps...)
+		}
+		if len(group) == 0 {
+			return Constraint{}, fmt.Errorf("invalid version constraint %q: empty range", s)
+		}
+		c.anyOf = append(c.anyOf, group)
+	}
+	return c, nil
+}
+
+func (c Constraint) String() string {
+	return c.original
+}
+
+// Check reports whether v satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, group := range c.anyOf {
+		if checkGroup(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkGroup(group []comparison, v Version) bool {
+	pre := !v.IsPrerelease()
+	for _, cmp := range group {
+		if !cmp.check(v) {
+			return false
+		}
+		if cmp.v.IsPrerelease() && cmp.v.Major == v.Major && cmp.v.Minor == v.Minor && cmp.v.Patch == v.Patch {
+			pre = true
+		}
+	}
+	return pre
+}
+
+func (cmp comparison) check(v Version) bool {
+	n := v.Compare(cmp.v)
+	switch cmp.op {
+	case "=":
+		return n == 0
+	case "!=":
+		if cmp.upper != nil {
+			return n < 0 || v.Compare(*cmp.upper) >= 0
+		}
+		return n != 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	}
+	return false
+}
+
+// parseTerm turns one term into plain comparisons, expanding ~, ^ and
+// partial versions into ranges.
+func parseTerm(term string) ([]comparison, error) {
+	op := ""
+	for _, o := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, o) {
+			op, term = o, strings.TrimSpace(term[len(o):])
+			break
+		}
+	}
+	if term == "*" || term == "x" || term == "X" {
+		switch op {
+		case ">", "<", "!=":
+			// Nothing is above, below or outside every version.
+			return []comparison{{op: "<", v: Version{}}}, nil
+		}
+		return []comparison{{op: ">=", v: Version{}}}, nil
+	}
+
+	// Count the specified parts; wildcards end the version.
+	parts := strings.Split(strings.SplitN(strings.TrimPrefix(term, "v"), "-", 2)[0], ".")
+	specified := 0
+	for _, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		specified++
+	}
+	if specified == 0 {
+		return nil, fmt.Errorf("invalid version %q", term)
+	}
+	trimmed := strings.Join(parts[:specified], ".")
+	if specified == len(parts) {
+		trimmed = term
+	}
+	v, err := Parse(trimmed)
+	if err != nil {
+		return nil, err
+	}
+
+	// upper is the first version above the range of a partial version.
+	upper := func(parts int) Version {
+		switch parts {
+		case 1:
+			return Version{Major: v.Major + 1}
+		case 2:
+			return Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+
+	switch op {
+	case "", "=":
+		if specified == 3 {
+			return []comparison{{op: "=", v: v}}, nil
+		}
+		return []comparison{{op: ">=", v: v}, {op: "<", v: upper(specified)}}, nil
+	case ">", "<=", "!=":
+		// A partial version is the range it matches: above it is above the
+		// range, up to it includes all of it, and != excludes all of it.
+		// >= and < need no change as they compare with the range's start.
+		if specified < 3 {
+			hi := upper(specified)
+			switch op {
+			case ">":
+				return []comparison{{op: ">=", v: hi}}, nil
+			case "<=":
+				return []comparison{{op: "<", v: hi}}, nil
+			}
+			return []comparison{{op: "!=", v: v, upper: &hi}}, nil
+		}
+	case "~":
+		if specified == 1 {
+			return []comparison{{op: ">=", v: v}, {op: "<", v: upper(1)}}, nil
+		}
+		return []comparison{{op: ">=", v: v}, {op: "<", v: upper(2)}}, nil
+	case "^":
+		switch {
+		case v.Major > 0 || specified == 1:
+			return []comparison{{op: ">=", v: v}, {op: "<", v: upper(1)}}, nil
+		case v.Minor > 0 || specified == 2:
+			return []comparison{{op: ">=", v: v}, {op: "<", v: upper(2)}}, nil
+		}
+		return []comparison{{op: ">=", v: v}, {op: "<", v: upper(3)}}, nil
+	}
+	return []comparison{{op: op, v: v}}, nil
+}
@@ -0,0 +1,126 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		match      []string
+		noMatch    []string
+	}{
+		// Full versions with every operator.
+		{constraint: "1.2.3", match: []string{"1.2.3", "v1.2.3"}, noMatch: []string{"1.2.4", "1.2.2"}},
+		{constraint: "=1.2.3", match: []string{"1.2.3"}, noMatch: []string{"1.2.4"}},
+		{constraint: "!=1.2.3", match: []string{"1.2.2", "1.2.4"}, noMatch: []string{"1.2.3"}},
+		{constraint: ">1.2.3", match: []string{"1.2.4", "2.0.0"}, noMatch: []string{"1.2.3", "1.0.0"}},
+		{constraint: ">=1.2.3", match: []string{"1.2.3", "1.3.0"}, noMatch: []string{"1.2.2"}},
+		{constraint: "<1.2.3", match: []string{"1.2.2", "0.1.0"}, noMatch: []string{"1.2.3", "1.3.0"}},
+		{constraint: "<=1.2.3", match: []string{"1.2.3", "1.2.2"}, noMatch: []string{"1.2.4"}},
+
+		// Partial versions with every operator, as npm reads them.
+		{constraint: "1.2", match: []string{"1.2.0", "1.2.9"}, noMatch: []string{"1.1.9", "1.3.0"}},
+		{constraint: "=1.2", match: []string{"1.2.0", "1.2.9"}, noMatch: []string{"1.1.9", "1.3.0"}},
+		{constraint: "!=1.2", match: []string{"1.1.9", "1.3.0"}, noMatch: []string{"1.2.0", "1.2.9"}},
+		{constraint: ">1.2", match: []string{"1.3.0", "2.0.0"}, noMatch: []string{"1.2.0", "1.2.9"}},
+		{constraint: ">=1.2", match: []string{"1.2.0", "1.3.0"}, noMatch: []string{"1.1.9"}},
+		{constraint: "<1.2", match: []string{"1.1.9"}, noMatch: []string{"1.2.0", "1.2.9"}},
+		{constraint: "<=1.2", match: []string{"1.2.0", "1.2.9"}, noMatch: []string{"1.3.0"}},
+		{constraint: "1", match: []string{"1.0.0", "1.9.9"}, noMatch: []string{"0.9.9", "2.0.0"}},
+		{constraint: "!=1", match: []string{"0.9.9", "2.0.0"}, noMatch: []string{"1.0.0", "1.9.9"}},
+		{constraint: ">1", match: []string{"2.0.0"}, noMatch: []string{"1.9.9"}},
+		{constraint: ">=1", match: []string{"1.0.0"}, noMatch: []string{"0.9.9"}},
+		{constraint: "<1", match: []string{"0.9.9"}, noMatch: []string{"1.0.0"}},
+		{constraint: "<=1", match: []string{"1.9.9"}, noMatch: []string{"2.0.0"}},
+
+		// x ranges.
+		{constraint: "*", match: []string{"0.0.0", "1.2.3", "99.0.0"}},
+		{constraint: "x", match: []string{"1.2.3"}},
+		{constraint: ">=*", match: []string{"1.2.3"}},
+		{constraint: ">*", noMatch: []string{"0.0.0", "1.2.3"}},
+		{constraint: "<*", noMatch: []string{"0.0.0", "1.2.3"}},
+		{constraint: "1.x", match: []string{"1.0.0", "1.9.9"}, noMatch: []string{"2.0.0", "0.9.0"}},
+		{constraint: "1.2.x", match: []string{"1.2.0", "1.2.9"}, noMatch: []string{"1.3.0"}},
+		{constraint: "1.2.*", match: []string{"1.2.5"}, noMatch: []string{"1.3.0"}},
+		{constraint: "1.X.x", match: []string{"1.5.0"}, noMatch: []string{"2.0.0"}},
+		{constraint: ">1.x", match: []string{"2.0.0"}, noMatch: []string{"1.9.9"}},
+		{constraint: "<=1.2.x", match: []string{"1.2.9"}, noMatch: []string{"1.3.0"}},
+		{constraint: "<1.x", match: []string{"0.9.9"}, noMatch: []string{"1.0.0"}},
+
+		// Tilde ranges.
+		{constraint: "~1.2.3", match: []string{"1.2.3", "1.2.9"}, noMatch: []string{"1.2.2", "1.3.0"}},
+		{constraint: "~1.2", match: []string{"1.2.0", "1.2.9"}, noMatch: []string{"1.3.0"}},
+		{constraint: "~1", match: []string{"1.0.0", "1.9.9"}, noMatch: []string{"2.0.0"}},
+		{constraint: "~0.2.3", match: []string{"0.2.3", "0.2.9"}, noMatch: []string{"0.3.0"}},
+		{constraint: "~1.2.x", match: []string{"1.2.0"}, noMatch: []string{"1.3.0"}},
+
+		// Caret ranges.
+		{constraint: "^1.2.3", match: []string{"1.2.3", "1.9.9"}, noMatch: []string{"1.2.2", "2.0.0"}},
+		{constraint: "^1.2", match: []string{"1.2.0", "1.9.9"}, noMatch: []string{"1.1.9", "2.0.0"}},
+		{constraint: "^1", match: []string{"1.0.0", "1.9.9"}, noMatch: []string{"2.0.0"}},
+		{constraint: "^0.2.3", match: []string{"0.2.3", "0.2.9"}, noMatch: []string{"0.3.0"}},
+		{constraint: "^0.0.3", match: []string{"0.0.3"}, noMatch: []string{"0.0.4"}},
+		{constraint: "^0.0", match: []string{"0.0.0", "0.0.9"}, noMatch: []string{"0.1.0"}},
+		{constraint: "^0.x", match: []string{"0.9.9"}, noMatch: []string{"1.0.0"}},
+		{constraint: "^1.x", match: []string{"1.9.9"}, noMatch: []string{"2.0.0"}},
+
+		// Combinations.
+		{constraint: ">=1.2 <2", match: []string{"1.2.0", "1.9.9"}, noMatch: []string{"1.1.9", "2.0.0"}},
+		{constraint: ">=1.2, <2", match: []string{"1.5.0"}, noMatch: []string{"2.0.0"}},
+		{constraint: ">= 1.2 < 2", match: []string{"1.5.0"}, noMatch: []string{"2.0.0"}},
+		{constraint: "^1 || ^3", match: []string{"1.5.0", "3.0.0"}, noMatch: []string{"2.0.0"}},
+		{constraint: "~1.4 || >=2.1", match: []string{"1.4.2", "2.1.0"}, noMatch: []string{"1.5.0", "2.0.0"}},
+
+		// Prereleases only match a range naming one of the same version.
+		{constraint: ">=1.0.0", match: []string{"1.1.0"}, noMatch: []string{"1.1.0-rc.1", "2.0.0-beta"}},
+		{constraint: "^1", noMatch: []string{"1.5.0-rc.1"}},
+		{constraint: ">=1.2.3-rc.1", match: []string{"1.2.3-rc.1", "1.2.3-rc.2", "1.2.3", "1.3.0"}, noMatch: []string{"1.2.3-beta", "1.2.4-rc.1", "2.0.0-rc.1"}},
+		{constraint: ">1.2.3-alpha.3", match: []string{"1.2.3-alpha.7", "3.4.5"}, noMatch: []string{"1.2.3-alpha.3", "3.4.5-alpha.9"}},
+		{constraint: "~1.2.3-beta.2", match: []string{"1.2.3-beta.4", "1.2.9"}, noMatch: []string{"1.2.4-beta.2", "1.2.3-beta.1"}},
+		{constraint: "^1.2.3-beta.2 || ^2.0.0-rc.1", match: []string{"1.2.3-beta.3", "2.0.0-rc.2"}, noMatch: []string{"1.3.0-beta", "2.1.0-rc.1"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		for _, s := range tt.match {
+			if v := mustParse(t, s); !c.Check(v) {
+				t.Errorf("%q does not match %s", tt.constraint, s)
+			}
+		}
+		for _, s := range tt.noMatch {
+			if v := mustParse(t, s); c.Check(v) {
+				t.Errorf("%q matches %s", tt.constraint, s)
+			}
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"||",
+		"^1 ||",
+		">=",
+		"latest",
+		"1.2.3.4",
+		">=1.2 <two",
+		"~x.1",
+	} {
+		if _, err := ParseConstraint(s); err == nil {
+			t.Errorf("ParseConstraint(%q): want error", s)
+		}
+	}
+}
+
+func mustParse(t *testing.T, s string) Version {
+	t.Helper()
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Version
+		wantErr bool
+	}{
+		{in: "1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{in: "v1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{in: "1.2", want: Version{Major: 1, Minor: 2}},
+		{in: "v2", want: Version{Major: 2}},
+		{in: "2026.3.1-beta.2", want: Version{Major: 2026, Minor: 3, Patch: 1, Prerelease: "beta.2"}},
+		{in: "1.2.3-rc.1+build.5", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}},
+		{in: "1.2.3+build.5", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{in: "1.2.3-x-y", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "x-y"}},
+		{in: "", wantErr: true},
+		{in: "v", wantErr: true},
+		{in: "latest", wantErr: true},
+		{in: "1.2.3.4", wantErr: true},
+		{in: "1..3", wantErr: true},
+		{in: "1.2.3-", wantErr: true},
+		{in: "-1.2.3", wantErr: true},
+		{in: "1.2.x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		tt.want.Original = tt.in
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for in, want := range map[string]string{
+		"v1":             "1.0.0",
+		"1.2":            "1.2.0",
+		"1.2.3-rc.1+abc": "1.2.3-rc.1",
+	} {
+		v, err := Parse(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.String(); got != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestPrecedence checks the ordering example of the semver 2.0.0 spec, plus
+// numeric and build metadata cases.
+func TestPrecedence(t *testing.T) {
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := Parse(ordered[i])
+			b, _ := Parse(ordered[j])
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	a, _ := Parse("1.0.0+build.1")
+	b, _ := Parse("1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Errorf("build metadata must not affect precedence")
+	}
+}
+
+func TestSort(t *testing.T) {
+	var versions []Version
+	for _, s := range []string{"1.0.0", "2.0.0-rc.1", "1.10.0", "2.0.0", "1.2.0"} {
+		v, _ := Parse(s)
+		versions = append(versions, v)
+	}
+	Sort(versions)
+
+	want := []string{"2.0.0", "2.0.0-rc.1", "1.10.0", "1.2.0", "1.0.0"}
+	for i, v := range versions {
+		if v.String() != want[i] {
+			t.Errorf("Sort()[%d] = %s, want %s", i, v, want[i])
+		}
+	}
+}